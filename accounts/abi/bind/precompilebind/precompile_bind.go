@@ -35,6 +35,7 @@ package precompilebind
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/ava-labs/subnet-evm/accounts/abi"
@@ -45,12 +46,13 @@ import (
 var errNoAnonymousEvent = errors.New("event type must not be anonymous")
 
 const (
-	ContractFileName     = "contract.go"
-	ConfigFileName       = "config.go"
-	ModuleFileName       = "module.go"
-	EventFileName        = "event.go"
-	ContractTestFileName = "contract_test.go"
-	ConfigTestFileName   = "config_test.go"
+	ContractFileName      = "contract.go"
+	ConfigFileName        = "config.go"
+	ModuleFileName        = "module.go"
+	EventFileName         = "event.go"
+	ContractTestFileName  = "contract_test.go"
+	ConfigTestFileName    = "config_test.go"
+	SimulatedTestFileName = "simulated_test.go"
 )
 
 type PrecompileBindFile struct {
@@ -73,13 +75,58 @@ func NewPrecompileBindFile(fileName string, content string, isTest bool) Precomp
 // PrecompileBind generates a Go binding for a precompiled contract. It returns a slice of
 // PrecompileBindFile structs containing the file name and its contents.
 func PrecompileBind(types []string, abiData string, bytecodes []string, fsigs []map[string]string, pkg string, lang bind.Lang, libs map[string]string, aliases map[string]string, abifilename string, generateTests bool) ([]PrecompileBindFile, error) {
+	return precompileBind(types, abiData, bytecodes, fsigs, pkg, lang, libs, aliases, abifilename, generateTests, nil, "")
+}
+
+// PrecompileBindSpec generates a precompiled contract from [spec]. In addition to the
+// files generated by [PrecompileBind], it generates the Solidity interface and its Go
+// bindings. If [generateTests] is set, a simulated backend test is also generated, which
+// imports the precompile package from [importPath].
+func PrecompileBindSpec(spec *PrecompileSpec, abifilename string, importPath string, generateTests bool) ([]PrecompileBindFile, error) {
+	if err := spec.Verify(); err != nil {
+		return nil, err
+	}
+	if generateTests && importPath == "" {
+		return nil, errors.New("import path is required to generate the simulated test")
+	}
+	abiData, err := spec.ABI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ABI: %w", err)
+	}
+	var (
+		types = []string{spec.Type}
+		pkg   = spec.PackageName()
+		bins  = []string{""}
+	)
+	result, err := precompileBind(types, abiData, bins, nil, pkg, bind.LangGo, nil, nil, abifilename, generateTests, spec, importPath)
+	if err != nil {
+		return nil, err
+	}
+
+	sol, err := solidityInterface(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate solidity interface: %w", err)
+	}
+	result = append(result, NewPrecompileBindFile(spec.InterfaceName()+".sol", sol, false))
+
+	bindings, err := bind.Bind([]string{spec.InterfaceName()}, []string{abiData}, bins, nil, "bindings", bind.LangGo, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate go bindings: %w", err)
+	}
+	bindingsFileName := filepath.Join(pkg+"test", "bindings", "gen_"+strings.ToLower(spec.InterfaceName())+"_binding.go")
+	result = append(result, NewPrecompileBindFile(bindingsFileName, bindings, false))
+
+	return result, nil
+}
+
+func precompileBind(types []string, abiData string, bytecodes []string, fsigs []map[string]string, pkg string, lang bind.Lang, libs map[string]string, aliases map[string]string, abifilename string, generateTests bool, spec *PrecompileSpec, importPath string) ([]PrecompileBindFile, error) {
 	// create hooks
-	configHook := createPrecompileHook(abifilename, tmplSourcePrecompileConfigGo)
-	contractHook := createPrecompileHook(abifilename, tmplSourcePrecompileContractGo)
-	moduleHook := createPrecompileHook(abifilename, tmplSourcePrecompileModuleGo)
-	eventHook := createPrecompileHook(abifilename, tmplSourcePrecompileEventGo)
-	configTestHook := createPrecompileHook(abifilename, tmplSourcePrecompileConfigTestGo)
-	contractTestHook := createPrecompileHook(abifilename, tmplSourcePrecompileContractTestGo)
+	configHook := createPrecompileHook(abifilename, spec, importPath, tmplSourcePrecompileConfigGo)
+	contractHook := createPrecompileHook(abifilename, spec, importPath, tmplSourcePrecompileContractGo)
+	moduleHook := createPrecompileHook(abifilename, spec, importPath, tmplSourcePrecompileModuleGo)
+	eventHook := createPrecompileHook(abifilename, spec, importPath, tmplSourcePrecompileEventGo)
+	configTestHook := createPrecompileHook(abifilename, spec, importPath, tmplSourcePrecompileConfigTestGo)
+	contractTestHook := createPrecompileHook(abifilename, spec, importPath, tmplSourcePrecompileContractTestGo)
 
 	if err := verifyABI(abiData); err != nil {
 		return nil, err
@@ -122,13 +169,24 @@ func PrecompileBind(types []string, abiData string, bytecodes []string, fsigs []
 			return nil, fmt.Errorf("failed to generate contract test binding: %w", err)
 		}
 		result = append(result, NewPrecompileBindFile(ContractTestFileName, contractTestBind, true))
+
+		// The simulated test calls the precompile through the bindings generated from the spec.
+		if spec != nil {
+			simulatedTestHook := createPrecompileHook(abifilename, spec, importPath, tmplSourcePrecompileSimulatedTestGo)
+			simulatedTestBind, err := bind.BindHelper(types, abis, bytecodes, fsigs, pkg, lang, libs, aliases, simulatedTestHook)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate simulated test binding: %w", err)
+			}
+			result = append(result, NewPrecompileBindFile(SimulatedTestFileName, simulatedTestBind, true))
+		}
 	}
 
 	return result, nil
 }
 
 // createPrecompileHook creates a bind hook for precompiled contracts.
-func createPrecompileHook(abifilename string, template string) bind.BindHook {
+// [spec] and [importPath] are optional and only set when generating from a [PrecompileSpec].
+func createPrecompileHook(abifilename string, spec *PrecompileSpec, importPath string, template string) bind.BindHook {
 	return func(lang bind.Lang, pkg string, types []string, contracts map[string]*bind.TmplContract, structs map[string]*bind.TmplStruct) (interface{}, string, error) {
 		// verify first
 		if lang != bind.LangGo {
@@ -167,6 +225,13 @@ func createPrecompileHook(abifilename string, template string) bind.BindHook {
 			AllowList:    isAllowList,
 			Funcs:        funcs,
 			ABIFilename:  abifilename,
+			ImportPath:   importPath,
+		}
+		if spec != nil {
+			precompileContract.GasCosts = spec.GasCosts()
+			precompileContract.RequiredRoles = spec.RequiredRoles()
+			precompileContract.Address = spec.Address
+			precompileContract.InterfaceType = spec.InterfaceName()
 		}
 
 		data := &tmplPrecompileData{
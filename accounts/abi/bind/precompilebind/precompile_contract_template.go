@@ -18,6 +18,47 @@ type tmplPrecompileContract struct {
 	AllowList   bool                        // Indicator whether the contract uses AllowList precompile
 	Funcs       map[string]*bind.TmplMethod // Contract functions that include both Calls + Transacts in tmplContract
 	ABIFilename string                      // Path to the ABI file
+
+	// The following fields are only set when generating from a [PrecompileSpec].
+	GasCosts      map[string]uint64 // Gas costs keyed by the original function name
+	RequiredRoles map[string]string // Allowlist roles required to call functions keyed by the original function name
+	Address       string            // Hex address of the precompile
+	InterfaceType string            // Name of the Solidity interface and its Go bindings
+	ImportPath    string            // Go import path of the generated precompile package
+}
+
+// RequiredRole returns the allowlist role required to call [method], or an empty string
+// if [method] is not gated by the allowlist. Unless a role is declared, state-changing
+// functions require the enabled role and read-only functions are not gated.
+func (c *tmplPrecompileContract) RequiredRole(method *bind.TmplMethod) string {
+	if !c.AllowList {
+		return ""
+	}
+	if role, ok := c.RequiredRoles[method.Original.Name]; ok {
+		if role == roleNone {
+			return ""
+		}
+		return role
+	}
+	if method.Original.IsConstant() {
+		return ""
+	}
+	return roleEnabled
+}
+
+// CanCall returns true if a caller with [role] is allowed to call [method].
+// [role] is one of NoRole, Enabled, Manager or Admin.
+func (c *tmplPrecompileContract) CanCall(method *bind.TmplMethod, role string) bool {
+	switch c.RequiredRole(method) {
+	case roleEnabled:
+		return role == roleEnabled || role == roleManager || role == roleAdmin
+	case roleManager:
+		return role == roleManager || role == roleAdmin
+	case roleAdmin:
+		return role == roleAdmin
+	default:
+		return true
+	}
 }
 
 // tmplSourcePrecompileContractGo is the Go precompiled contract source template.
@@ -56,7 +97,7 @@ const (
 	// You should also increase gas costs of functions that read from AllowList storage.
 	{{- end}}
 	{{- range .Contract.Funcs}}
-	{{.Normalized.Name}}GasCost uint64 = 1 /* SET A GAS COST HERE */ {{if not .Original.IsConstant | and $contract.AllowList}} + allowlist.ReadAllowListGasCost {{end}}
+	{{.Normalized.Name}}GasCost uint64 = {{with index $contract.GasCosts .Original.Name}}{{.}}{{else}}1 /* SET A GAS COST HERE */{{end}} {{if $contract.RequiredRole .}} + allowlist.ReadAllowListGasCost {{end}}
 	{{- end}}
 	{{- if .Contract.Fallback}}
 	{{.Contract.Type}}FallbackGasCost uint64 = 1 // SET A GAS COST LESS THAN 2300 HERE
@@ -77,10 +118,11 @@ var (
 // Singleton StatefulPrecompiledContract and signatures.
 var (
 	{{- range .Contract.Funcs}}
+	{{- $func := .}}
 
-	{{- if not .Original.IsConstant | and $contract.AllowList}}
+	{{- with $contract.RequiredRole $func}}
 
-	ErrCannot{{.Normalized.Name}} = errors.New("non-enabled cannot call {{.Original.Name}}")
+	ErrCannot{{$func.Normalized.Name}} = errors.New("non-{{decapitalise .}} cannot call {{$func.Original.Name}}")
 	{{- end}}
 	{{- end}}
 
@@ -249,15 +291,16 @@ func {{decapitalise .Normalized.Name}}(accessibleState contract.AccessibleState,
 	}
 	{{- end}}
 
-	{{if not .Original.IsConstant | and $contract.AllowList}}
-	// Allow list is enabled and {{.Normalized.Name}} is a state-changer function.
-	// This part of the code restricts the function to be called only by enabled/admin addresses in the allow list.
+	{{$func := .}}
+	{{- with $contract.RequiredRole $func}}
+	// Allow list is enabled and {{$func.Normalized.Name}} requires the {{decapitalise .}} role.
+	// This part of the code restricts the function to be called only by addresses with at least the {{decapitalise .}} role in the allow list.
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
 	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
-	if !callerStatus.IsEnabled() {
-		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannot{{.Normalized.Name}}, caller)
+	if !callerStatus.Is{{.}}() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannot{{$func.Normalized.Name}}, caller)
 	}
 	// allow list code ends here.
   {{end}}
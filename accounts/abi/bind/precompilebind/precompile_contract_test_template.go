@@ -46,7 +46,7 @@ var(
 		{{- if $contract.AllowList}}
 		{{- $roles := mkList "NoRole" "Enabled" "Manager" "Admin"}}
 		{{- range $role := $roles}}
-		{{- $fail := and (not $func.Original.IsConstant) (eq $role "NoRole")}}
+		{{- $fail := not ($contract.CanCall $func $role)}}
 		{
 			Name:       "calling {{decapitalise $func.Normalized.Name}} from {{$role}} should {{- if $fail}} fail {{- else}} succeed{{- end}}",
 			Caller:     allowlisttest.Test{{$role}}Addr,
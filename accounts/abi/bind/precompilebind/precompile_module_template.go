@@ -30,7 +30,11 @@ const ConfigKey = "{{decapitalise .Contract.Type}}Config"
 // ContractAddress is the defined address of the precompile contract.
 // This should be unique across all precompile contracts.
 // See precompile/registry/registry.go for registered precompile contracts and more information.
+{{- if .Contract.Address}}
+var ContractAddress = common.HexToAddress("{{.Contract.Address}}")
+{{- else}}
 var ContractAddress = common.HexToAddress("{ASUITABLEHEXADDRESS}") // SET A SUITABLE HEX ADDRESS HERE
+{{- end}}
 
 // Module is the precompile module. It is used to register the precompile contract.
 var Module = modules.Module{
@@ -0,0 +1,393 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompilebind
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+)
+
+const (
+	// customCodeMarker marks the start of a region that is edited by the user.
+	// Everything from the marker to the end of the enclosing function or struct
+	// is preserved when a precompile is regenerated.
+	customCodeMarker = "CUSTOM CODE STARTS HERE"
+
+	gasCostPlaceholder = "SET A GAS COST HERE"
+	addressPlaceholder = "ASUITABLEHEXADDRESS"
+)
+
+// RegenerateReport describes how user code was carried over by [Regenerate].
+type RegenerateReport struct {
+	// RemovedFunctions are the functions of the previous ABI that no longer exist.
+	RemovedFunctions []string
+	// RemovedEvents are the events of the previous ABI that no longer exist.
+	RemovedEvents []string
+	// DroppedRegions are the user code regions, formatted as {file}: {declaration},
+	// that no longer have a counterpart in the generated code.
+	DroppedRegions []string
+	// KeptFiles are the existing test files that were kept as is.
+	KeptFiles []string
+}
+
+// Regenerate merges the user code of an existing precompile into the [generated] files.
+// [existing] contains the current content of the precompile files keyed by file name,
+// [oldABI] is the ABI the existing precompile was generated from and [newABI] is the
+// ABI [generated] was generated from.
+//
+// For every function and struct of contract.go, config.go, module.go and event.go, the
+// region starting at the "CUSTOM CODE STARTS HERE" marker is taken from the existing file.
+// Placeholder gas costs and contract addresses are replaced by the existing values, and
+// declarations added by the user are appended. Existing test files are kept as is.
+func Regenerate(generated []PrecompileBindFile, existing map[string][]byte, oldABI string, newABI string) ([]PrecompileBindFile, *RegenerateReport, error) {
+	report := &RegenerateReport{}
+	removed, err := removedDeclarations(oldABI, newABI, report)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]PrecompileBindFile, 0, len(generated))
+	for _, file := range generated {
+		oldSrc, ok := existing[file.FileName]
+		switch {
+		case !ok:
+			result = append(result, file)
+		case file.IsTest:
+			report.KeptFiles = append(report.KeptFiles, file.FileName)
+		case !isMergeable(file.FileName):
+			result = append(result, file)
+		default:
+			merged, dropped, err := mergeSource(file.FileName, []byte(file.Content), oldSrc, removed)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to merge %s: %w", file.FileName, err)
+			}
+			for _, name := range dropped {
+				report.DroppedRegions = append(report.DroppedRegions, file.FileName+": "+name)
+			}
+			result = append(result, NewPrecompileBindFile(file.FileName, string(merged), false))
+		}
+	}
+	return result, report, nil
+}
+
+func isMergeable(fileName string) bool {
+	switch fileName {
+	case ContractFileName, ConfigFileName, ModuleFileName, EventFileName:
+		return true
+	default:
+		return false
+	}
+}
+
+// removedDeclarations returns the names of the declarations generated for the functions
+// and events of [oldABI] that are not in [newABI]. These are not carried over as user code.
+func removedDeclarations(oldABI string, newABI string, report *RegenerateReport) (map[string]bool, error) {
+	removed := make(map[string]bool)
+	if oldABI == "" {
+		return removed, nil
+	}
+	oldParsed, err := abi.JSON(strings.NewReader(oldABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse previous ABI: %w", err)
+	}
+	newParsed, err := abi.JSON(strings.NewReader(newABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	for name := range oldParsed.Methods {
+		if _, ok := newParsed.Methods[name]; ok {
+			continue
+		}
+		report.RemovedFunctions = append(report.RemovedFunctions, name)
+		n := abi.ToCamelCase(name)
+		for _, decl := range []string{
+			n + "GasCost", "ErrCannot" + n, n + "Input", n + "Output",
+			"Pack" + n, "Pack" + n + "Output", "Unpack" + n + "Input", "Unpack" + n + "Output",
+			strings.ToLower(n[:1]) + n[1:],
+		} {
+			removed[decl] = true
+		}
+	}
+	for name := range oldParsed.Events {
+		if _, ok := newParsed.Events[name]; ok {
+			continue
+		}
+		report.RemovedEvents = append(report.RemovedEvents, name)
+		n := abi.ToCamelCase(name)
+		for _, decl := range []string{
+			n + "EventData", "Get" + n + "EventGasCost", "Pack" + n + "Event", "Unpack" + n + "EventData",
+		} {
+			removed[decl] = true
+		}
+	}
+	sort.Strings(report.RemovedFunctions)
+	sort.Strings(report.RemovedEvents)
+	return removed, nil
+}
+
+// sourceRegion is a byte range of a source file.
+type sourceRegion struct {
+	start, end int
+}
+
+// parsedSource holds the regions of a Go source file relevant for merging.
+type parsedSource struct {
+	fset *token.FileSet
+	file *ast.File
+	// custom are the user code regions keyed by the enclosing declaration.
+	custom map[string]sourceRegion
+	// values are the values of single name var and const specs, up to the end of the line.
+	values map[string]sourceRegion
+	// decls are the top-level declarations, including their doc comments, keyed by name.
+	decls map[string]sourceRegion
+}
+
+func parseSource(fileName string, src []byte) (*parsedSource, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, fileName, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	p := &parsedSource{
+		fset:   fset,
+		file:   file,
+		custom: make(map[string]sourceRegion),
+		values: make(map[string]sourceRegion),
+		decls:  make(map[string]sourceRegion),
+	}
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	// markerIn returns the offset of the first marker comment in [start, end).
+	markerIn := func(start, end token.Pos) (int, bool) {
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				if c.Pos() > start && c.End() < end && strings.Contains(c.Text, customCodeMarker) {
+					return offset(c.Pos()), true
+				}
+			}
+		}
+		return 0, false
+	}
+	declStart := func(doc *ast.CommentGroup, pos token.Pos) int {
+		if doc != nil {
+			return offset(doc.Pos())
+		}
+		return offset(pos)
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			name := funcDeclName(decl)
+			p.decls[name] = sourceRegion{declStart(decl.Doc, decl.Pos()), offset(decl.End())}
+			if decl.Body == nil {
+				continue
+			}
+			if start, ok := markerIn(decl.Body.Lbrace, decl.Body.Rbrace); ok {
+				p.custom[name] = sourceRegion{start, offset(decl.Body.Rbrace)}
+			}
+		case *ast.GenDecl:
+			names := make([]string, 0, len(decl.Specs))
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, spec.Name.Name)
+					st, ok := spec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					if start, ok := markerIn(st.Fields.Opening, st.Fields.Closing); ok {
+						p.custom[spec.Name.Name] = sourceRegion{start, offset(st.Fields.Closing)}
+					}
+				case *ast.ValueSpec:
+					for _, ident := range spec.Names {
+						names = append(names, ident.Name)
+					}
+					if len(spec.Names) != 1 || len(spec.Values) == 0 {
+						continue
+					}
+					start := offset(spec.Values[0].Pos())
+					end := start + bytes.IndexByte(src[start:], '\n')
+					if end < start {
+						end = len(src)
+					}
+					p.values[spec.Names[0].Name] = sourceRegion{start, end}
+				}
+			}
+			if decl.Tok == token.IMPORT || len(names) == 0 {
+				continue
+			}
+			// Grouped declarations are keyed by all of their names.
+			p.decls[strings.Join(names, ",")] = sourceRegion{declStart(decl.Doc, decl.Pos()), offset(decl.End())}
+		}
+	}
+	return p, nil
+}
+
+// funcDeclName returns the name of [decl], prefixed by the receiver type for methods.
+func funcDeclName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+	typ := decl.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name + "." + decl.Name.Name
+	}
+	return decl.Name.Name
+}
+
+type replacement struct {
+	region sourceRegion
+	text   []byte
+}
+
+// mergeSource merges the user code of [oldSrc] into [newSrc]. It returns the merged
+// source and the names of the declarations of [oldSrc] with user code that were dropped.
+func mergeSource(fileName string, newSrc []byte, oldSrc []byte, removed map[string]bool) ([]byte, []string, error) {
+	newParsed, err := parseSource(fileName, newSrc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse generated source: %w", err)
+	}
+	oldParsed, err := parseSource(fileName, oldSrc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse existing source: %w", err)
+	}
+	text := func(src []byte, r sourceRegion) []byte { return src[r.start:r.end] }
+
+	var replacements []replacement
+	for name, region := range newParsed.custom {
+		if oldRegion, ok := oldParsed.custom[name]; ok {
+			replacements = append(replacements, replacement{region, text(oldSrc, oldRegion)})
+		}
+	}
+	for name, region := range newParsed.values {
+		newValue := string(text(newSrc, region))
+		if !strings.Contains(newValue, gasCostPlaceholder) && !strings.Contains(newValue, addressPlaceholder) {
+			continue
+		}
+		if oldRegion, ok := oldParsed.values[name]; ok {
+			replacements = append(replacements, replacement{region, text(oldSrc, oldRegion)})
+		}
+	}
+	// Apply the replacements back to front so that offsets stay valid.
+	sort.Slice(replacements, func(i, j int) bool {
+		return replacements[i].region.start > replacements[j].region.start
+	})
+	merged := append([]byte(nil), newSrc...)
+	for _, r := range replacements {
+		merged = append(merged[:r.region.start], append(append([]byte(nil), r.text...), merged[r.region.end:]...)...)
+	}
+
+	// Carry over the declarations added by the user and report the dropped user code.
+	newNames := make(map[string]bool)
+	for name := range newParsed.decls {
+		for _, n := range strings.Split(name, ",") {
+			newNames[n] = true
+		}
+	}
+	var dropped []string
+	for _, name := range sortedKeys(oldParsed.decls) {
+		if _, ok := newParsed.decls[name]; ok {
+			continue
+		}
+		names := strings.Split(name, ",")
+		if isRemoved(names, removed) {
+			if _, ok := oldParsed.custom[name]; ok {
+				dropped = append(dropped, name)
+			}
+			continue
+		}
+		// A grouped declaration sharing names with the generated code was regrouped
+		// by the template. Names added to the group by the user cannot be carried over.
+		if isGenerated(names, newNames) {
+			for _, n := range names {
+				if !newNames[n] && !removed[n] {
+					dropped = append(dropped, n)
+				}
+			}
+			continue
+		}
+		merged = append(merged, '\n', '\n')
+		merged = append(merged, text(oldSrc, oldParsed.decls[name])...)
+	}
+
+	merged, err = mergeImports(fileName, merged, oldParsed.file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return merged, dropped, nil
+}
+
+// isRemoved returns true if any of [names] were generated for a function or event
+// that is no longer in the ABI.
+func isRemoved(names []string, removed map[string]bool) bool {
+	for _, n := range names {
+		if removed[n] {
+			return true
+		}
+	}
+	return false
+}
+
+// isGenerated returns true if any of [names] is declared in the generated source.
+func isGenerated(names []string, newNames map[string]bool) bool {
+	for _, n := range names {
+		if newNames[n] {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]sourceRegion) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mergeImports adds the imports of [oldFile] used by [src] and formats the result.
+func mergeImports(fileName string, src []byte, oldFile *ast.File) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, fileName, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse merged source: %w", err)
+	}
+	for _, imp := range oldFile.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		name := ""
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if !astutil.AddNamedImport(fset, file, name, path) {
+			continue
+		}
+		if name != "_" && !astutil.UsesImport(file, path) {
+			astutil.DeleteNamedImport(fset, file, name, path)
+		}
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, fmt.Errorf("failed to format merged source: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompilebind
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegenerate(t *testing.T) {
+	require := require.New(t)
+
+	oldSpec, err := ParsePrecompileSpec([]byte(testSpecYAML))
+	require.NoError(err)
+	oldABI, err := oldSpec.ABI()
+	require.NoError(err)
+	oldFiles, err := PrecompileBindSpec(oldSpec, "contract.abi", "example.com/precompile/helloworld", true)
+	require.NoError(err)
+
+	existing := make(map[string][]byte)
+	for _, file := range oldFiles {
+		content := file.Content
+		switch file.FileName {
+		case ContractFileName:
+			content = strings.Replace(content, "SayHelloGasCost      uint64 = 5000", "SayHelloGasCost      uint64 = 5001", 1)
+			content = strings.Replace(content, "ResetGreetingGasCost uint64 = 1 /* SET A GAS COST HERE */", "ResetGreetingGasCost uint64 = 777", 1)
+			// setGreeting keeps its custom code, resetGreeting is removed below.
+			content = strings.Replace(content, "\t_ = inputStruct // CUSTOM CODE OPERATES ON INPUT\n", "\tstoreGreeting(inputStruct) // USER SET GREETING\n", 1)
+			content = strings.Replace(content, "\t// CUSTOM CODE STARTS HERE\n\t// this function does not return an output, leave this one as is\n\tpackedOutput := []byte{}\n\n\t// Return the packed output and the remaining gas\n\treturn packedOutput, remainingGas, nil\n}\n\n// createHelloWorldPrecompile",
+				"\t// CUSTOM CODE STARTS HERE\n\treturn nil, remainingGas, nil // USER RESET GREETING\n}\n\n// createHelloWorldPrecompile", 1)
+			content += "\nfunc storeGreeting(greeting string) { _ = strings.ToUpper(greeting) }\n"
+			content = strings.Replace(content, "import (\n", "import (\n\t\"strings\"\n", 1)
+		case ModuleFileName:
+			content = strings.Replace(content, `common.HexToAddress("0x0300000000000000000000000000000000000000")`, `common.HexToAddress("0x0300000000000000000000000000000000000099")`, 1)
+		case ContractTestFileName:
+			content += "\n// USER TEST\n"
+		}
+		existing[file.FileName] = []byte(content)
+	}
+
+	// Remove resetGreeting and add a new function.
+	newSpec, err := ParsePrecompileSpec([]byte(strings.Replace(testSpecYAML, "  - name: resetGreeting\n    requiredRole: admin\n", "  - name: clearGreeting\n", 1)))
+	require.NoError(err)
+	newABI, err := newSpec.ABI()
+	require.NoError(err)
+	newFiles, err := PrecompileBindSpec(newSpec, "contract.abi", "example.com/precompile/helloworld", true)
+	require.NoError(err)
+
+	merged, report, err := Regenerate(newFiles, existing, oldABI, newABI)
+	require.NoError(err)
+	require.Equal([]string{"resetGreeting"}, report.RemovedFunctions)
+	require.Empty(report.RemovedEvents)
+	require.Equal([]string{"contract.go: resetGreeting"}, report.DroppedRegions)
+	require.ElementsMatch([]string{ContractTestFileName, ConfigTestFileName, SimulatedTestFileName}, report.KeptFiles)
+
+	contents := make(map[string]string)
+	for _, file := range merged {
+		contents[file.FileName] = file.Content
+	}
+	require.NotContains(contents, ContractTestFileName)
+	require.NotContains(contents, ConfigTestFileName)
+
+	contract := contents[ContractFileName]
+	// Values set by the spec are regenerated, placeholders keep the user value.
+	require.Contains(contract, "SayHelloGasCost      uint64 = 5000")
+	require.Contains(contract, "ClearGreetingGasCost uint64 = 1 /* SET A GAS COST HERE */")
+	require.NotContains(contract, "ResetGreeting")
+	require.Contains(contract, "storeGreeting(inputStruct) // USER SET GREETING")
+	require.Contains(contract, "func storeGreeting(greeting string)")
+	require.Contains(contract, "\t\"strings\"\n")
+	require.NotContains(contract, "USER RESET GREETING")
+	require.Contains(contract, "func clearGreeting(")
+
+	// The address was set by the spec, so it is regenerated.
+	require.Contains(contents[ModuleFileName], `common.HexToAddress("0x0300000000000000000000000000000000000000")`)
+}
+
+func TestMergeSourcePlaceholders(t *testing.T) {
+	require := require.New(t)
+
+	oldSrc := `package p
+
+var ContractAddress = common.HexToAddress("0x0300000000000000000000000000000000000005")
+
+const (
+	FooGasCost uint64 = 250 // tuned
+)
+
+type Config struct {
+	Upgrade
+
+	// CUSTOM CODE STARTS HERE
+	Limit uint64
+}
+
+func (c *Config) Verify() error {
+	// CUSTOM CODE STARTS HERE
+	return verifyLimit(c.Limit)
+}
+
+func verifyLimit(uint64) error { return nil }
+`
+	newSrc := `package p
+
+var ContractAddress = common.HexToAddress("{ASUITABLEHEXADDRESS}")
+
+const (
+	FooGasCost uint64 = 1 /* SET A GAS COST HERE */
+	BarGasCost uint64 = 1 /* SET A GAS COST HERE */
+)
+
+type Config struct {
+	Upgrade
+
+	// CUSTOM CODE STARTS HERE
+	// Add your own custom fields for Config here
+}
+
+func (c *Config) Verify() error {
+	// CUSTOM CODE STARTS HERE
+	return nil
+}
+`
+	merged, dropped, err := mergeSource(ConfigFileName, []byte(newSrc), []byte(oldSrc), nil)
+	require.NoError(err)
+	require.Empty(dropped)
+	require.Equal(`package p
+
+var ContractAddress = common.HexToAddress("0x0300000000000000000000000000000000000005")
+
+const (
+	FooGasCost uint64 = 250 // tuned
+	BarGasCost uint64 = 1   /* SET A GAS COST HERE */
+)
+
+type Config struct {
+	Upgrade
+
+	// CUSTOM CODE STARTS HERE
+	Limit uint64
+}
+
+func (c *Config) Verify() error {
+	// CUSTOM CODE STARTS HERE
+	return verifyLimit(c.Limit)
+}
+
+func verifyLimit(uint64) error { return nil }
+`, string(merged))
+}
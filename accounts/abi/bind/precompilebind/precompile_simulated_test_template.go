@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// #skiplint: import_testing_only_in_tests
+package precompilebind
+
+// tmplSourcePrecompileSimulatedTestGo is the Go simulated backend test source template.
+const tmplSourcePrecompileSimulatedTestGo = `
+// Code generated
+// This file is a generated simulated backend test with the skeleton of test functions.
+// The file is generated by a template. Please inspect every code and comment in this file before use.
+
+package {{.Package}}_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+	{{- if .Contract.AllowList}}
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist/allowlisttest"
+	{{- end}}
+	"github.com/ava-labs/subnet-evm/precompile/contracts/testutils"
+	"github.com/ava-labs/subnet-evm/utils"
+	"{{.Contract.ImportPath}}"
+
+	sim "github.com/ava-labs/subnet-evm/ethclient/simulated"
+	{{.Package}}bindings "{{.Contract.ImportPath}}/{{.Package}}test/bindings"
+)
+
+// Reference imports to suppress errors from unused imports. This code and any unnecessary imports can be removed.
+var (
+	_ = big.NewInt
+	_ = common.Big0
+	_ = bind.CallOpts{}
+)
+
+var (
+	adminKey, _        = crypto.GenerateKey()
+	unprivilegedKey, _ = crypto.GenerateKey()
+
+	adminAddress        = crypto.PubkeyToAddress(adminKey.PublicKey)
+	unprivilegedAddress = crypto.PubkeyToAddress(unprivilegedKey.PublicKey)
+)
+
+func TestMain(m *testing.M) {
+	// Ensure libevm extras are registered for tests.
+	core.RegisterExtras()
+	customtypes.Register()
+	params.RegisterExtras()
+	m.Run()
+}
+
+{{- $contract := .Contract}}
+{{- $structs := .Structs}}
+{{- $pkg := .Package}}
+{{- $bindings := printf "%sbindings.%s" .Package $contract.InterfaceType}}
+
+func new{{$contract.Type}}Config() *{{$pkg}}.Config {
+	return {{$pkg}}.NewConfig(utils.NewUint64(0){{if $contract.AllowList}}, []common.Address{adminAddress}, nil, nil{{end}})
+}
+
+func Test{{$contract.Type}}(t *testing.T) {
+	chainID := params.TestChainConfig.ChainID
+	admin := testutils.NewAuth(t, adminKey, chainID)
+	unprivileged := testutils.NewAuth(t, unprivilegedKey, chainID)
+	// Reference the transactors to suppress errors if they are not used by the tests below.
+	_, _ = admin, unprivileged
+
+	type testCase struct {
+		name string
+		test func(t *testing.T, backend *sim.Backend, precompile *{{$bindings}})
+	}
+
+	// These tests call each function of the precompile through the Go bindings
+	// generated from the Solidity interface. Functions gated by the allowlist are
+	// called from each role to verify the gate. You should write your own tests for specific cases.
+	testCases := []testCase{
+		{{- if $contract.AllowList}}
+		{
+			name: "admin is set at genesis",
+			test: func(t *testing.T, _ *sim.Backend, precompile *{{$bindings}}) {
+				allowlisttest.VerifyRole(t, precompile, adminAddress, allowlist.AdminRole)
+				allowlisttest.VerifyRole(t, precompile, unprivilegedAddress, allowlist.NoRole)
+			},
+		},
+		{{- end}}
+		{{- range $contract.Funcs}}
+		{{- $func := .}}
+		{{- if $contract.RequiredRole $func}}
+		{{- range $role := mkList "NoRole" "Enabled" "Manager" "Admin"}}
+		{{- $ok := $contract.CanCall $func $role}}
+		{
+			name: "{{$role}} {{if $ok}}can{{else}}cannot{{end}} call {{$func.Original.Name}}",
+			test: func(t *testing.T, backend *sim.Backend, precompile *{{$bindings}}) {
+				{{- if eq $role "Enabled"}}
+				allowlisttest.SetAsEnabled(t, backend, precompile, admin, unprivilegedAddress)
+				{{- else if eq $role "Manager"}}
+				allowlisttest.SetAsManager(t, backend, precompile, admin, unprivilegedAddress)
+				{{- else if eq $role "Admin"}}
+				allowlisttest.SetAsAdmin(t, backend, precompile, admin, unprivilegedAddress)
+				{{- end}}
+				{{- if $func.Original.IsConstant}}
+				{{if len $func.Normalized.Outputs | ne 0}}_, {{end}}err := precompile.{{$func.Normalized.Name}}(&bind.CallOpts{From: unprivilegedAddress}, {{range $func.Normalized.Inputs}}{{bindtypenew .Type $structs}}, {{end}})
+				{{- else}}
+				{{if $ok}}tx{{else}}_{{end}}, err := precompile.{{$func.Normalized.Name}}(unprivileged, {{range $func.Normalized.Inputs}}{{bindtypenew .Type $structs}}, {{end}})
+				{{- end}}
+				{{- if $ok}}
+				require.NoError(t, err)
+				{{- if not $func.Original.IsConstant}}
+				testutils.WaitReceiptSuccessful(t, backend, tx)
+				{{- end}}
+				{{- else}}
+				require.ErrorContains(t, err, {{$pkg}}.ErrCannot{{$func.Normalized.Name}}.Error()) //nolint:forbidigo // upstream error wrapped as string
+				{{- end}}
+			},
+		},
+		{{- end}}
+		{{- else if $func.Original.IsConstant}}
+		{
+			name: "can call {{$func.Original.Name}}",
+			test: func(t *testing.T, _ *sim.Backend, precompile *{{$bindings}}) {
+				// CUSTOM CODE STARTS HERE
+				// set test inputs and verify the outputs here
+				{{if len $func.Normalized.Outputs | ne 0}}_, {{end}}err := precompile.{{$func.Normalized.Name}}(&bind.CallOpts{}, {{range $func.Normalized.Inputs}}{{bindtypenew .Type $structs}}, {{end}})
+				require.NoError(t, err)
+			},
+		},
+		{{- else}}
+		{
+			name: "can call {{$func.Original.Name}}",
+			test: func(t *testing.T, backend *sim.Backend, precompile *{{$bindings}}) {
+				// CUSTOM CODE STARTS HERE
+				// set test inputs and verify the resulting state here
+				tx, err := precompile.{{$func.Normalized.Name}}(admin, {{range $func.Normalized.Inputs}}{{bindtypenew .Type $structs}}, {{end}})
+				require.NoError(t, err)
+				testutils.WaitReceiptSuccessful(t, backend, tx)
+			},
+		},
+		{{- end}}
+		{{- end}}
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := testutils.NewBackendWithPrecompile(t, new{{$contract.Type}}Config(), []common.Address{adminAddress, unprivilegedAddress})
+			defer backend.Close()
+
+			precompile, err := {{$pkg}}bindings.New{{$contract.InterfaceType}}({{$pkg}}.ContractAddress, backend.Client())
+			require.NoError(t, err)
+
+			tc.test(t, backend, precompile)
+		})
+	}
+}
+{{- if $contract.AllowList}}
+
+func Test{{$contract.Type}}AllowListEvents(t *testing.T) {
+	admin := testutils.NewAuth(t, adminKey, params.TestChainConfig.ChainID)
+	allowlisttest.RunAllowListEventTests(t, new{{$contract.Type}}Config(), {{$pkg}}.ContractAddress, admin, adminAddress, unprivilegedAddress)
+}
+{{- end}}
+`
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompilebind
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// tmplSourcePrecompileSolidity is the Solidity interface source template.
+// It is rendered directly from a [PrecompileSpec].
+const tmplSourcePrecompileSolidity = `//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.24;
+{{- if .AllowList}}
+import "precompile/allowlist/IAllowList.sol";
+{{- end}}
+
+interface {{.InterfaceName}} {{if .AllowList}}is IAllowList {{end}}{
+{{- range .Events}}
+  {{- if .Comment}}
+  // {{.Comment}}
+  {{- end}}
+  event {{.Name}}({{eventArgs .Inputs}});
+{{- end}}
+{{- range .Functions}}
+{{if .Comment}}
+  // {{.Comment}}
+{{- end}}
+  function {{.Name}}({{args .Inputs "calldata"}}) external{{modifier .StateMutability}}{{if .Outputs}} returns ({{args .Outputs "memory"}}){{end}};
+{{- end}}
+}
+`
+
+var solidityFuncs = template.FuncMap{
+	"args": func(args []ArgSpec, location string) string {
+		parts := make([]string, 0, len(args))
+		for _, arg := range args {
+			part := arg.Type
+			if isSolidityReferenceType(arg.Type) {
+				part += " " + location
+			}
+			if arg.Name != "" {
+				part += " " + arg.Name
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, ", ")
+	},
+	"eventArgs": func(args []ArgSpec) string {
+		parts := make([]string, 0, len(args))
+		for _, arg := range args {
+			part := arg.Type
+			if arg.Indexed {
+				part += " indexed"
+			}
+			if arg.Name != "" {
+				part += " " + arg.Name
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, ", ")
+	},
+	"modifier": func(mutability string) string {
+		switch mutability {
+		case "pure", "view", "payable":
+			return " " + mutability
+		default:
+			return ""
+		}
+	},
+}
+
+// isSolidityReferenceType returns true if [typ] requires a data location
+// when used as an external function argument.
+func isSolidityReferenceType(typ string) bool {
+	return typ == "string" || typ == "bytes" || strings.HasSuffix(typ, "]")
+}
+
+// solidityInterface renders the Solidity interface of [spec].
+func solidityInterface(spec *PrecompileSpec) (string, error) {
+	tmpl, err := template.New("solidity").Funcs(solidityFuncs).Parse(tmplSourcePrecompileSolidity)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, spec); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
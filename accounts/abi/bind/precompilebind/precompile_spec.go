@@ -0,0 +1,322 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompilebind
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/libevm/common"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+)
+
+var (
+	errNoSpecType         = errors.New("spec type must be set")
+	errNoSpecFunctions    = errors.New("spec must declare at least one function")
+	errDuplicateSpecName  = errors.New("duplicate name in spec")
+	errInvalidMutability  = errors.New("invalid state mutability")
+	errUnsupportedArgType = errors.New("unsupported argument type")
+	errInvalidSpecAddress = errors.New("invalid contract address")
+	errInvalidRole        = errors.New("invalid required role")
+	errRoleWithoutAllow   = errors.New("required role is only valid with the allowlist")
+)
+
+// Roles that can be required to call a function of a precompile using the allowlist.
+// These match the Is{Role} functions of [allowlist.Role] and the Test{Role}Addr
+// addresses of allowlisttest.
+const (
+	roleNone    = "None"
+	roleEnabled = "Enabled"
+	roleManager = "Manager"
+	roleAdmin   = "Admin"
+)
+
+// PrecompileSpec is a single-file description of a precompile. It declares
+// everything needed to generate the precompile package, its Solidity interface,
+// the Go bindings used by tests and a simulated backend test harness.
+// Specs can be written in either YAML or JSON.
+type PrecompileSpec struct {
+	// Type is the name of the precompile, e.g. NativeMinter.
+	// The Solidity interface and bindings are generated as I{Type}.
+	Type string `json:"type"`
+	// Package is the Go package name (default = lowercase {Type}).
+	Package string `json:"package,omitempty"`
+	// Address is the hex address of the precompile. If empty, a placeholder
+	// is generated which must be replaced before use.
+	Address string `json:"address,omitempty"`
+	// AllowList indicates the precompile embeds the allowlist.
+	AllowList bool `json:"allowList,omitempty"`
+	// Functions are the functions exposed by the precompile.
+	Functions []FunctionSpec `json:"functions"`
+	// Events are the events emitted by the precompile.
+	Events []EventSpec `json:"events,omitempty"`
+}
+
+// FunctionSpec describes a single precompile function.
+type FunctionSpec struct {
+	Name string `json:"name"`
+	// Comment is written above the function in the Solidity interface.
+	Comment string    `json:"comment,omitempty"`
+	Inputs  []ArgSpec `json:"inputs,omitempty"`
+	Outputs []ArgSpec `json:"outputs,omitempty"`
+	// StateMutability is one of pure, view, nonpayable or payable (default = nonpayable).
+	StateMutability string `json:"stateMutability,omitempty"`
+	// GasCost is the gas charged for the function. If zero, a placeholder
+	// cost is generated which must be replaced before use.
+	GasCost uint64 `json:"gasCost,omitempty"`
+	// RequiredRole is the allowlist role a caller needs to call the function.
+	// One of none, enabled, manager or admin. If empty, state-changing functions
+	// require the enabled role and read-only functions can be called by anyone.
+	// Only valid if the precompile uses the allowlist.
+	RequiredRole string `json:"requiredRole,omitempty"`
+}
+
+// EventSpec describes a single precompile event.
+type EventSpec struct {
+	Name    string    `json:"name"`
+	Comment string    `json:"comment,omitempty"`
+	Inputs  []ArgSpec `json:"inputs,omitempty"`
+}
+
+// ArgSpec describes a function or event argument.
+type ArgSpec struct {
+	Name string `json:"name"`
+	// Type is the Solidity type of the argument, e.g. address or uint256[].
+	// Tuples are not supported.
+	Type string `json:"type"`
+	// Indexed is only valid for event arguments.
+	Indexed bool `json:"indexed,omitempty"`
+}
+
+// ParsePrecompileSpec parses and verifies a YAML or JSON encoded [PrecompileSpec].
+func ParsePrecompileSpec(data []byte) (*PrecompileSpec, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(jsonData))
+	dec.DisallowUnknownFields()
+	spec := new(PrecompileSpec)
+	if err := dec.Decode(spec); err != nil {
+		return nil, fmt.Errorf("failed to decode spec: %w", err)
+	}
+	if err := spec.Verify(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Verify checks that [s] is well formed.
+func (s *PrecompileSpec) Verify() error {
+	if s.Type == "" {
+		return errNoSpecType
+	}
+	if len(s.Functions) == 0 {
+		return errNoSpecFunctions
+	}
+	if s.Address != "" && !common.IsHexAddress(s.Address) {
+		return fmt.Errorf("%w: %s", errInvalidSpecAddress, s.Address)
+	}
+	names := make(map[string]bool)
+	for _, fn := range s.Functions {
+		if names[fn.Name] {
+			return fmt.Errorf("%w: %s", errDuplicateSpecName, fn.Name)
+		}
+		names[fn.Name] = true
+		if s.AllowList {
+			if _, ok := allowlist.AllowListABI.Methods[fn.Name]; ok {
+				return fmt.Errorf("%w: %s is already declared by the allowlist", errDuplicateSpecName, fn.Name)
+			}
+		}
+		if fn.RequiredRole != "" {
+			if !s.AllowList {
+				return fmt.Errorf("%w: %s", errRoleWithoutAllow, fn.Name)
+			}
+			if _, err := normalizeRole(fn.RequiredRole); err != nil {
+				return fmt.Errorf("function %s: %w", fn.Name, err)
+			}
+		}
+		switch fn.StateMutability {
+		case "", "pure", "view", "nonpayable", "payable":
+		default:
+			return fmt.Errorf("%w for %s: %s", errInvalidMutability, fn.Name, fn.StateMutability)
+		}
+		if err := verifyArgs(fn.Inputs, false); err != nil {
+			return fmt.Errorf("function %s: %w", fn.Name, err)
+		}
+		if err := verifyArgs(fn.Outputs, false); err != nil {
+			return fmt.Errorf("function %s: %w", fn.Name, err)
+		}
+	}
+	names = make(map[string]bool)
+	for _, event := range s.Events {
+		if names[event.Name] {
+			return fmt.Errorf("%w: %s", errDuplicateSpecName, event.Name)
+		}
+		names[event.Name] = true
+		if err := verifyArgs(event.Inputs, true); err != nil {
+			return fmt.Errorf("event %s: %w", event.Name, err)
+		}
+	}
+	return nil
+}
+
+func verifyArgs(args []ArgSpec, isEvent bool) error {
+	for _, arg := range args {
+		if arg.Indexed && !isEvent {
+			return fmt.Errorf("argument %s cannot be indexed", arg.Name)
+		}
+		typ, err := abi.NewType(arg.Type, "", nil)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", errUnsupportedArgType, arg.Type, err)
+		}
+		if hasTuple(typ) {
+			return fmt.Errorf("%w: %s", errUnsupportedArgType, arg.Type)
+		}
+	}
+	return nil
+}
+
+func hasTuple(typ abi.Type) bool {
+	switch typ.T {
+	case abi.TupleTy:
+		return true
+	case abi.SliceTy, abi.ArrayTy:
+		return hasTuple(*typ.Elem)
+	default:
+		return false
+	}
+}
+
+// PackageName returns the Go package name of the precompile.
+func (s *PrecompileSpec) PackageName() string {
+	if s.Package != "" {
+		return s.Package
+	}
+	return strings.ToLower(s.Type)
+}
+
+// InterfaceName returns the name of the Solidity interface and its Go bindings.
+func (s *PrecompileSpec) InterfaceName() string {
+	return "I" + s.Type
+}
+
+// GasCosts returns the declared gas cost of each function keyed by the function name.
+// Functions without a declared gas cost are omitted.
+func (s *PrecompileSpec) GasCosts() map[string]uint64 {
+	costs := make(map[string]uint64)
+	for _, fn := range s.Functions {
+		if fn.GasCost != 0 {
+			costs[fn.Name] = fn.GasCost
+		}
+	}
+	return costs
+}
+
+// RequiredRoles returns the normalized required role of each function keyed by the
+// function name. Functions without a declared role are omitted.
+func (s *PrecompileSpec) RequiredRoles() map[string]string {
+	roles := make(map[string]string)
+	for _, fn := range s.Functions {
+		if role, err := normalizeRole(fn.RequiredRole); err == nil && role != "" {
+			roles[fn.Name] = role
+		}
+	}
+	return roles
+}
+
+func normalizeRole(role string) (string, error) {
+	switch strings.ToLower(role) {
+	case "":
+		return "", nil
+	case "none":
+		return roleNone, nil
+	case "enabled":
+		return roleEnabled, nil
+	case "manager":
+		return roleManager, nil
+	case "admin":
+		return roleAdmin, nil
+	default:
+		return "", fmt.Errorf("%w: %s", errInvalidRole, role)
+	}
+}
+
+type abiArgJSON struct {
+	Indexed      bool   `json:"indexed,omitempty"`
+	InternalType string `json:"internalType"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+}
+
+type abiEntryJSON struct {
+	Anonymous       *bool        `json:"anonymous,omitempty"`
+	Inputs          []abiArgJSON `json:"inputs"`
+	Name            string       `json:"name"`
+	Outputs         []abiArgJSON `json:"outputs,omitempty"`
+	StateMutability string       `json:"stateMutability,omitempty"`
+	Type            string       `json:"type"`
+}
+
+// ABI returns the JSON ABI of the precompile. This includes the allowlist
+// functions and events if the precompile uses the allowlist.
+func (s *PrecompileSpec) ABI() (string, error) {
+	entries := make([]abiEntryJSON, 0, len(s.Events)+len(s.Functions))
+	for _, event := range s.Events {
+		anonymous := false
+		entries = append(entries, abiEntryJSON{
+			Anonymous: &anonymous,
+			Inputs:    toABIArgs(event.Inputs),
+			Name:      event.Name,
+			Type:      "event",
+		})
+	}
+	for _, fn := range s.Functions {
+		entries = append(entries, abiEntryJSON{
+			Inputs:          toABIArgs(fn.Inputs),
+			Name:            fn.Name,
+			Outputs:         toABIArgs(fn.Outputs),
+			StateMutability: fn.mutability(),
+			Type:            "function",
+		})
+	}
+	if s.AllowList {
+		var allowListEntries []abiEntryJSON
+		if err := json.Unmarshal([]byte(allowlist.AllowListRawABI), &allowListEntries); err != nil {
+			return "", err
+		}
+		entries = append(entries, allowListEntries...)
+	}
+	out, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func toABIArgs(args []ArgSpec) []abiArgJSON {
+	res := make([]abiArgJSON, 0, len(args))
+	for _, arg := range args {
+		res = append(res, abiArgJSON{
+			Indexed:      arg.Indexed,
+			InternalType: arg.Type,
+			Name:         arg.Name,
+			Type:         arg.Type,
+		})
+	}
+	return res
+}
+
+func (f FunctionSpec) mutability() string {
+	if f.StateMutability == "" {
+		return "nonpayable"
+	}
+	return f.StateMutability
+}
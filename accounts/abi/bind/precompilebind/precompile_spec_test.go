@@ -0,0 +1,200 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompilebind
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+)
+
+const testSpecYAML = `
+type: HelloWorld
+address: "0x0300000000000000000000000000000000000000"
+allowList: true
+functions:
+  - name: sayHello
+    stateMutability: view
+    gasCost: 5000
+    outputs:
+      - {name: result, type: string}
+  - name: setGreeting
+    comment: Set the greeting to [response].
+    gasCost: 20000
+    inputs:
+      - {name: response, type: string}
+  - name: resetGreeting
+    requiredRole: admin
+events:
+  - name: GreetingChanged
+    inputs:
+      - {name: sender, type: address, indexed: true}
+      - {name: oldGreeting, type: string}
+      - {name: newGreeting, type: string}
+`
+
+func TestParsePrecompileSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr error
+	}{
+		{
+			name: "valid yaml",
+			spec: testSpecYAML,
+		},
+		{
+			name: "valid json",
+			spec: `{"type":"Hello","functions":[{"name":"hello","stateMutability":"pure"}]}`,
+		},
+		{
+			name:    "missing type",
+			spec:    `{"functions":[{"name":"hello"}]}`,
+			wantErr: errNoSpecType,
+		},
+		{
+			name:    "no functions",
+			spec:    `{"type":"Hello"}`,
+			wantErr: errNoSpecFunctions,
+		},
+		{
+			name:    "duplicate function",
+			spec:    `{"type":"Hello","functions":[{"name":"hello"},{"name":"hello"}]}`,
+			wantErr: errDuplicateSpecName,
+		},
+		{
+			name:    "function declared by allowlist",
+			spec:    `{"type":"Hello","allowList":true,"functions":[{"name":"setAdmin","inputs":[{"name":"addr","type":"address"}]}]}`,
+			wantErr: errDuplicateSpecName,
+		},
+		{
+			name:    "invalid state mutability",
+			spec:    `{"type":"Hello","functions":[{"name":"hello","stateMutability":"constant"}]}`,
+			wantErr: errInvalidMutability,
+		},
+		{
+			name:    "invalid argument type",
+			spec:    `{"type":"Hello","functions":[{"name":"hello","inputs":[{"name":"a","type":"foo"}]}]}`,
+			wantErr: errUnsupportedArgType,
+		},
+		{
+			name:    "tuple argument type",
+			spec:    `{"type":"Hello","functions":[{"name":"hello","inputs":[{"name":"a","type":"tuple"}]}]}`,
+			wantErr: errUnsupportedArgType,
+		},
+		{
+			name:    "required role without allowlist",
+			spec:    `{"type":"Hello","functions":[{"name":"hello","requiredRole":"admin"}]}`,
+			wantErr: errRoleWithoutAllow,
+		},
+		{
+			name:    "invalid required role",
+			spec:    `{"type":"Hello","allowList":true,"functions":[{"name":"hello","requiredRole":"owner"}]}`,
+			wantErr: errInvalidRole,
+		},
+		{
+			name:    "invalid address",
+			spec:    `{"type":"Hello","address":"0x01","functions":[{"name":"hello"}]}`,
+			wantErr: errInvalidSpecAddress,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParsePrecompileSpec([]byte(test.spec))
+			require.ErrorIs(t, err, test.wantErr)
+		})
+	}
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := ParsePrecompileSpec([]byte(`{"type":"Hello","functions":[{"name":"hello","gas":1}]}`))
+		require.ErrorContains(t, err, "unknown field") //nolint:forbidigo // encoding/json does not export this error
+	})
+}
+
+func TestPrecompileSpecABI(t *testing.T) {
+	require := require.New(t)
+
+	spec, err := ParsePrecompileSpec([]byte(testSpecYAML))
+	require.NoError(err)
+
+	abiData, err := spec.ABI()
+	require.NoError(err)
+	parsed, err := abi.JSON(strings.NewReader(abiData))
+	require.NoError(err)
+
+	// The allowlist functions and events are included.
+	for _, name := range []string{"sayHello", "setGreeting", "setAdmin", "setEnabled", "setManager", "setNone", "readAllowList"} {
+		require.Contains(parsed.Methods, name)
+	}
+	require.Contains(parsed.Events, "GreetingChanged")
+	require.Contains(parsed.Events, "RoleSet")
+	require.True(parsed.Methods["sayHello"].IsConstant())
+	require.False(parsed.Methods["setGreeting"].IsConstant())
+	require.True(parsed.Events["GreetingChanged"].Inputs[0].Indexed)
+
+	require.Equal(map[string]uint64{"sayHello": 5000, "setGreeting": 20000}, spec.GasCosts())
+	require.Equal(map[string]string{"resetGreeting": roleAdmin}, spec.RequiredRoles())
+}
+
+func TestPrecompileBindSpec(t *testing.T) {
+	require := require.New(t)
+
+	spec, err := ParsePrecompileSpec([]byte(testSpecYAML))
+	require.NoError(err)
+
+	_, err = PrecompileBindSpec(spec, "contract.abi", "", true)
+	require.ErrorContains(err, "import path is required") //nolint:forbidigo // error is not exported
+
+	files, err := PrecompileBindSpec(spec, "contract.abi", "example.com/precompile/helloworld", true)
+	require.NoError(err)
+
+	contents := make(map[string]string)
+	for _, file := range files {
+		contents[file.FileName] = file.Content
+	}
+
+	require.Contains(contents[ContractFileName], "SayHelloGasCost      uint64 = 5000")
+	require.Contains(contents[ContractFileName], "SetGreetingGasCost   uint64 = 20000 + allowlist.ReadAllowListGasCost")
+	require.Contains(contents[ModuleFileName], `common.HexToAddress("0x0300000000000000000000000000000000000000")`)
+
+	// State-changing functions require the enabled role unless a role is declared.
+	require.Contains(contents[ContractFileName], `ErrCannotSetGreeting = errors.New("non-enabled cannot call setGreeting")`)
+	require.Contains(contents[ContractFileName], `ErrCannotResetGreeting = errors.New("non-admin cannot call resetGreeting")`)
+	require.Contains(contents[ContractFileName], "if !callerStatus.IsAdmin() {")
+	require.NotContains(contents[ContractFileName], "ErrCannotSayHello")
+	require.Contains(contents[ContractTestFileName], `"calling resetGreeting from Manager should fail"`)
+	require.Contains(contents[ContractTestFileName], `"calling resetGreeting from Admin should succeed"`)
+
+	require.Equal(`//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.24;
+import "precompile/allowlist/IAllowList.sol";
+
+interface IHelloWorld is IAllowList {
+  event GreetingChanged(address indexed sender, string oldGreeting, string newGreeting);
+
+  function sayHello() external view returns (string memory result);
+
+  // Set the greeting to [response].
+  function setGreeting(string calldata response) external;
+
+  function resetGreeting() external;
+}
+`, contents["IHelloWorld.sol"])
+
+	bindings := contents[filepath.Join("helloworldtest", "bindings", "gen_ihelloworld_binding.go")]
+	require.Contains(bindings, "package bindings")
+	require.Contains(bindings, "func NewIHelloWorld(")
+	require.Contains(bindings, `"github.com/ava-labs/subnet-evm/accounts/abi/bind"`)
+
+	simulated := contents[SimulatedTestFileName]
+	require.Contains(simulated, "package helloworld_test")
+	require.Contains(simulated, `helloworldbindings "example.com/precompile/helloworld/helloworldtest/bindings"`)
+	require.Contains(simulated, "helloworld.ErrCannotSetGreeting")
+	require.Contains(simulated, `"Manager cannot call resetGreeting"`)
+	require.Contains(simulated, `"Admin can call resetGreeting"`)
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+	"github.com/ava-labs/subnet-evm/plugin/evm/feesim"
+)
+
+const (
+	feeConfigKey     = "fee-config"
+	profileKey       = "profile"
+	blocksKey        = "blocks"
+	gasPerBlockKey   = "gas-per-block"
+	blockIntervalKey = "block-interval-ms"
+	upgradeKey       = "upgrade"
+	outputKey        = "output"
+	formatKey        = "format"
+)
+
+// upgrades maps the names accepted by --upgrade to chain configs with every upgrade
+// up to and including the named one active at genesis.
+var upgrades = map[string]*extras.ChainConfig{
+	"subnetevm": extras.TestSubnetEVMChainConfig,
+	"durango":   extras.TestDurangoChainConfig,
+	"etna":      extras.TestEtnaChainConfig,
+	"fortuna":   extras.TestFortunaChainConfig,
+	"granite":   extras.TestGraniteChainConfig,
+	"helicon":   extras.TestHeliconChainConfig,
+}
+
+func main() {
+	fs := pflag.NewFlagSet("feesim", pflag.ContinueOnError)
+	fs.String(feeConfigKey, "", "Path to a JSON fee config, as in the feeConfig of the chain config. Defaults to the default fee config")
+	fs.String(profileKey, "", "Path to a recorded load profile (.csv or .json). If empty, a constant synthetic load is used")
+	fs.Int(blocksKey, 300, "Number of blocks of the synthetic load")
+	fs.Uint64(gasPerBlockKey, 4_000_000, "Gas used by each block of the synthetic load")
+	fs.Uint64(blockIntervalKey, 2000, "Milliseconds between the blocks of the synthetic load")
+	fs.String(upgradeKey, "fortuna", "Latest network upgrade active during the simulation")
+	fs.String(outputKey, "", "Output file. Defaults to stdout")
+	fs.String(formatKey, feesim.FormatCSV, "Output format (csv or json)")
+
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "couldn't parse flags: %s\n", err)
+		os.Exit(1)
+	}
+	if err := run(fs); err != nil {
+		fmt.Fprintf(os.Stderr, "fee simulation failed: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(fs *pflag.FlagSet) error {
+	customtypes.Register()
+
+	feeConfigPath, _ := fs.GetString(feeConfigKey)
+	feeConfig := extras.DefaultFeeConfig
+	if feeConfigPath != "" {
+		data, err := os.ReadFile(feeConfigPath)
+		if err != nil {
+			return err
+		}
+		feeConfig = commontype.FeeConfig{}
+		if err := json.Unmarshal(data, &feeConfig); err != nil {
+			return fmt.Errorf("failed to decode fee config: %w", err)
+		}
+	}
+
+	upgrade, _ := fs.GetString(upgradeKey)
+	config, ok := upgrades[strings.ToLower(upgrade)]
+	if !ok {
+		return fmt.Errorf("unknown upgrade %q", upgrade)
+	}
+
+	profile, err := loadProfile(fs)
+	if err != nil {
+		return err
+	}
+	samples, err := feesim.Simulate(config, feeConfig, profile)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if output, _ := fs.GetString(outputKey); output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	format, _ := fs.GetString(formatKey)
+	return feesim.WriteSamples(w, samples, format)
+}
+
+func loadProfile(fs *pflag.FlagSet) ([]feesim.Block, error) {
+	path, _ := fs.GetString(profileKey)
+	if path == "" {
+		blocks, _ := fs.GetInt(blocksKey)
+		gasPerBlock, _ := fs.GetUint64(gasPerBlockKey)
+		interval, _ := fs.GetUint64(blockIntervalKey)
+		return feesim.ConstantLoad(blocks, gasPerBlock, 0, interval), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return feesim.ReadProfile(f, strings.TrimPrefix(filepath.Ext(path), "."))
+}
@@ -28,9 +28,11 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -42,6 +44,7 @@ import (
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind/precompilebind"
 	"github.com/ava-labs/subnet-evm/internal/flags"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/mod/modfile"
 )
 
 //go:embed template-readme.md
@@ -61,6 +64,14 @@ var (
 		Name:  "pkg",
 		Usage: "Go package name to generate the precompile into (default = {type})",
 	}
+	specFlag = &cli.StringFlag{
+		Name:  "spec",
+		Usage: "Path to a YAML/JSON precompile spec to generate from, instead of an ABI. Also generates the Solidity interface, Go bindings and a simulated test",
+	}
+	regenerateFlag = &cli.BoolFlag{
+		Name:  "regenerate",
+		Usage: "Regenerate the precompile in the output folder, preserving the code in \"CUSTOM CODE STARTS HERE\" regions. Existing test files are kept as is",
+	}
 	outFlag = &cli.StringFlag{
 		Name:  "out",
 		Usage: "Output folder for the generated precompile files, - for STDOUT (default = ./precompile/contracts/{pkg}). Test files won't be generated if STDOUT is used",
@@ -73,6 +84,8 @@ func init() {
 	app.Name = "precompilegen"
 	app.Flags = []cli.Flag{
 		abiFlag,
+		specFlag,
+		regenerateFlag,
 		outFlag,
 		pkgFlag,
 		typeFlag,
@@ -87,6 +100,9 @@ func precompilegen(c *cli.Context) error {
 	if isOutStdout && !c.IsSet(typeFlag.Name) {
 		utils.Fatalf("type (--type) should be set explicitly for STDOUT ")
 	}
+	if isOutStdout && c.Bool(regenerateFlag.Name) {
+		utils.Fatalf("regenerate (--regenerate) cannot be used with STDOUT")
+	}
 	lang := bind.LangGo
 	// If the entire solidity code was specified, build and bind based on that
 	var (
@@ -96,6 +112,9 @@ func precompilegen(c *cli.Context) error {
 		libs    = make(map[string]string)
 		aliases = make(map[string]string)
 	)
+	if c.IsSet(specFlag.Name) {
+		return precompilegenSpec(c)
+	}
 	if c.String(abiFlag.Name) == "" {
 		utils.Fatalf("no abi path is specified (--abi)")
 	}
@@ -167,8 +186,113 @@ func precompilegen(c *cli.Context) error {
 		os.MkdirAll(outFlagStr, 0o700) // Create your file
 	}
 
-	for _, file := range bindedFiles {
-		outputPath := filepath.Join(outFlagStr, file.FileName)
+	if c.Bool(regenerateFlag.Name) {
+		bindedFiles = regenerate(outFlagStr, bindedFiles, abipath, string(abi))
+	}
+	writeFiles(outFlagStr, bindedFiles, abipath, abi)
+	return nil
+}
+
+// precompilegenSpec generates the precompile, its Solidity interface, Go bindings
+// and simulated test from the spec given with --spec.
+func precompilegenSpec(c *cli.Context) error {
+	if c.IsSet(abiFlag.Name) {
+		utils.Fatalf("abi (--abi) and spec (--spec) cannot be used together")
+	}
+	outFlagStr := c.String(outFlag.Name)
+	if outFlagStr == "-" {
+		utils.Fatalf("spec (--spec) generates multiple packages and cannot be written to STDOUT")
+	}
+
+	input := c.String(specFlag.Name)
+	data, err := os.ReadFile(input)
+	if err != nil {
+		utils.Fatalf("Failed to read input spec: %v", err)
+	}
+	spec, err := precompilebind.ParsePrecompileSpec(data)
+	if err != nil {
+		utils.Fatalf("Failed to parse spec: %v", err)
+	}
+	// Flags take precedence over the spec.
+	if c.IsSet(typeFlag.Name) {
+		spec.Type = c.String(typeFlag.Name)
+	}
+	if c.IsSet(pkgFlag.Name) {
+		spec.Package = c.String(pkgFlag.Name)
+	}
+	if outFlagStr == "" {
+		outFlagStr = filepath.Join("./precompile/contracts", spec.PackageName())
+	}
+	if err := os.MkdirAll(outFlagStr, 0o700); err != nil {
+		utils.Fatalf("Failed to create output folder: %v", err)
+	}
+	importPath, err := goImportPath(outFlagStr)
+	if err != nil {
+		utils.Fatalf("Failed to resolve Go import path of %s: %v", outFlagStr, err)
+	}
+
+	abi, err := spec.ABI()
+	if err != nil {
+		utils.Fatalf("Failed to generate ABI: %v", err)
+	}
+	abifilename := "contract.abi"
+	bindedFiles, err := precompilebind.PrecompileBindSpec(spec, abifilename, importPath, true)
+	if err != nil {
+		utils.Fatalf("Failed to generate precompile: %v", err)
+	}
+
+	abipath := filepath.Join(outFlagStr, abifilename)
+	if c.Bool(regenerateFlag.Name) {
+		bindedFiles = regenerate(outFlagStr, bindedFiles, abipath, abi)
+	}
+	writeFiles(outFlagStr, bindedFiles, abipath, []byte(abi))
+	return nil
+}
+
+// regenerate merges the user code of the precompile in [outDir] into [files]
+// and reports the functions removed from the ABI at [abipath].
+func regenerate(outDir string, files []precompilebind.PrecompileBindFile, abipath string, newABI string) []precompilebind.PrecompileBindFile {
+	existing := make(map[string][]byte)
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(outDir, file.FileName))
+		switch {
+		case err == nil:
+			existing[file.FileName] = content
+		case !os.IsNotExist(err):
+			utils.Fatalf("Failed to read existing file %s: %v", file.FileName, err)
+		}
+	}
+	oldABI, err := os.ReadFile(abipath)
+	if err != nil && !os.IsNotExist(err) {
+		utils.Fatalf("Failed to read existing ABI: %v", err)
+	}
+
+	merged, report, err := precompilebind.Regenerate(files, existing, string(oldABI), newABI)
+	if err != nil {
+		utils.Fatalf("Failed to regenerate precompile: %v", err)
+	}
+	for _, name := range report.RemovedFunctions {
+		log.Warn("Function removed from the ABI, its generated code was deleted", "function", name)
+	}
+	for _, name := range report.RemovedEvents {
+		log.Warn("Event removed from the ABI, its generated code was deleted", "event", name)
+	}
+	for _, region := range report.DroppedRegions {
+		log.Warn("Custom code could not be preserved", "declaration", region)
+	}
+	for _, name := range report.KeptFiles {
+		log.Info("Kept existing test file", "file", name)
+	}
+	return merged
+}
+
+// writeFiles writes the generated files, the ABI and the README to [outDir].
+func writeFiles(outDir string, files []precompilebind.PrecompileBindFile, abipath string, abi []byte) {
+	for _, file := range files {
+		outputPath := filepath.Join(outDir, file.FileName)
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0o700); err != nil {
+			utils.Fatalf("Failed to create folder for generated file %s: %v", file.FileName, err)
+		}
 		if err := os.WriteFile(outputPath, []byte(file.Content), 0o600); err != nil {
 			utils.Fatalf("Failed to write generated file %s: %v", file.FileName, err)
 		}
@@ -180,13 +304,40 @@ func precompilegen(c *cli.Context) error {
 	}
 
 	// Write the README to the output folder
-	readmeOut := filepath.Join(outFlagStr, "README.md")
+	readmeOut := filepath.Join(outDir, "README.md")
 	if err := os.WriteFile(readmeOut, []byte(readme), 0o600); err != nil {
 		utils.Fatalf("Failed to write README: %v", err)
 	}
 
-	fmt.Println("Precompile files generated successfully at: ", outFlagStr)
-	return nil
+	fmt.Println("Precompile files generated successfully at: ", outDir)
+}
+
+// goImportPath returns the Go import path of [dir] by finding the enclosing go.mod.
+func goImportPath(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for modDir := absDir; ; modDir = filepath.Dir(modDir) {
+		data, err := os.ReadFile(filepath.Join(modDir, "go.mod"))
+		if err == nil {
+			modulePath := modfile.ModulePath(data)
+			if modulePath == "" {
+				return "", fmt.Errorf("no module path found in %s", filepath.Join(modDir, "go.mod"))
+			}
+			rel, err := filepath.Rel(modDir, absDir)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(modulePath, filepath.ToSlash(rel)), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if parent := filepath.Dir(modDir); parent == modDir {
+			return "", errors.New("no go.mod found")
+		}
+	}
 }
 
 func main() {
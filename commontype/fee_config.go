@@ -39,6 +39,10 @@ var (
 	errMinBlockGasCostExceedsHashLength          = errors.New("minBlockGasCost exceeds hash length")
 	errMaxBlockGasCostExceedsHashLength          = errors.New("maxBlockGasCost exceeds hash length")
 	errBlockGasCostStepExceedsHashLength         = errors.New("blockGasCostStep exceeds hash length")
+	errMinGasLimitTooLow                         = errors.New("minGasLimit cannot be less than or equal to 0")
+	errMinGasLimitTooHigh                        = errors.New("minGasLimit cannot be greater than maxGasLimit")
+	errGasLimitChangeDenominatorTooLow           = errors.New("gasLimitChangeDenominator cannot be less than or equal to 0")
+	errGasLimitOutOfElasticBounds                = errors.New("gasLimit must be within minGasLimit and maxGasLimit")
 )
 
 // FeeConfig specifies the parameters for the dynamic fee algorithm, which determines the gas limit, base fee, and block gas cost of blocks
@@ -84,6 +88,49 @@ type FeeConfig struct {
 	//
 	// Ex: if a block is produced two seconds faster than the target block rate, the block gas cost will increase by 2 * BlockGasCostStep.
 	BlockGasCostStep *big.Int `json:"blockGasCostStep,omitempty"`
+
+	// ElasticGasLimit optionally lets the gas limit follow the utilization of the network, starting from [GasLimit].
+	// If it is nil, every block has the gas limit [GasLimit].
+	// It is not stored by the Fee Manager precompile, which keeps the elastic gas limit of the chain config and only accepts
+	// gas limits within its bounds. The gas limit set by the Fee Manager then only applies to the first Subnet-EVM block:
+	// the Fee Manager does not steer the gas limit of the following blocks.
+	ElasticGasLimit *ElasticGasLimitConfig `json:"elasticGasLimit,omitempty"`
+}
+
+// ElasticGasLimitConfig specifies the bounds of a gas limit that follows the utilization of the network.
+//
+// When the gas consumed within the rolling 10s window is above/below the [FeeConfig.TargetGas], the gas limit of the next
+// block increases/decreases proportionally to how far above/below the target the window is, by at most
+// parentGasLimit / GasLimitChangeDenominator.
+type ElasticGasLimitConfig struct {
+	// MinGasLimit sets the lower bound of the gas limit.
+	MinGasLimit uint64 `json:"minGasLimit"`
+	// MaxGasLimit sets the upper bound of the gas limit.
+	MaxGasLimit uint64 `json:"maxGasLimit"`
+	// GasLimitChangeDenominator divides the gas limit of the parent block to determine the maximum change of the gas limit
+	// between two blocks. A larger denominator indicates a slower changing gas limit.
+	GasLimitChangeDenominator uint64 `json:"gasLimitChangeDenominator"`
+}
+
+// Verify checks the bounds of the elastic gas limit.
+func (e *ElasticGasLimitConfig) Verify() error {
+	switch {
+	case e.MinGasLimit == 0:
+		return errMinGasLimitTooLow
+	case e.MinGasLimit > e.MaxGasLimit:
+		return fmt.Errorf("%w: minGasLimit = %d, maxGasLimit = %d", errMinGasLimitTooHigh, e.MinGasLimit, e.MaxGasLimit)
+	case e.GasLimitChangeDenominator == 0:
+		return errGasLimitChangeDenominatorTooLow
+	}
+	return nil
+}
+
+// Equal checks if given [other] is same with this ElasticGasLimitConfig.
+func (e *ElasticGasLimitConfig) Equal(other *ElasticGasLimitConfig) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+	return *e == *other
 }
 
 // represents an empty fee config without any field
@@ -128,6 +175,14 @@ func (f *FeeConfig) Verify() error {
 	case !f.MaxBlockGasCost.IsUint64():
 		return fmt.Errorf("%w: maxBlockGasCost = %d", errMaxBlockGasCostNotUint64, f.MaxBlockGasCost)
 	}
+	if f.ElasticGasLimit != nil {
+		if err := f.ElasticGasLimit.Verify(); err != nil {
+			return err
+		}
+		if !f.GasLimit.IsUint64() || f.GasLimit.Uint64() < f.ElasticGasLimit.MinGasLimit || f.GasLimit.Uint64() > f.ElasticGasLimit.MaxGasLimit {
+			return fmt.Errorf("%w: gasLimit = %d, minGasLimit = %d, maxGasLimit = %d", errGasLimitOutOfElasticBounds, f.GasLimit, f.ElasticGasLimit.MinGasLimit, f.ElasticGasLimit.MaxGasLimit)
+		}
+	}
 	return f.checkByteLens()
 }
 
@@ -144,7 +199,8 @@ func (f *FeeConfig) Equal(other *FeeConfig) bool {
 		utils.BigNumEqual(f.BaseFeeChangeDenominator, other.BaseFeeChangeDenominator) &&
 		utils.BigNumEqual(f.MinBlockGasCost, other.MinBlockGasCost) &&
 		utils.BigNumEqual(f.MaxBlockGasCost, other.MaxBlockGasCost) &&
-		utils.BigNumEqual(f.BlockGasCostStep, other.BlockGasCostStep)
+		utils.BigNumEqual(f.BlockGasCostStep, other.BlockGasCostStep) &&
+		f.ElasticGasLimit.Equal(other.ElasticGasLimit)
 }
 
 // checkByteLens checks byte lengths against common.HashLen (32 bytes) and returns error
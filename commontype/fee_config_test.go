@@ -82,6 +82,51 @@ func TestVerify(t *testing.T) {
 			config:        func() *FeeConfig { c := ValidTestFeeConfig; c.BlockGasCostStep = big.NewInt(-1); return &c }(),
 			expectedError: errBlockGasCostStepNegative,
 		},
+		{
+			name: "valid ElasticGasLimit in FeeConfig",
+			config: func() *FeeConfig {
+				c := ValidTestFeeConfig
+				c.ElasticGasLimit = &ElasticGasLimitConfig{MinGasLimit: 4_000_000, MaxGasLimit: 16_000_000, GasLimitChangeDenominator: 1024}
+				return &c
+			}(),
+			expectedError: nil,
+		},
+		{
+			name: "invalid MinGasLimit in FeeConfig",
+			config: func() *FeeConfig {
+				c := ValidTestFeeConfig
+				c.ElasticGasLimit = &ElasticGasLimitConfig{MinGasLimit: 0, MaxGasLimit: 16_000_000, GasLimitChangeDenominator: 1024}
+				return &c
+			}(),
+			expectedError: errMinGasLimitTooLow,
+		},
+		{
+			name: "MinGasLimit bigger than MaxGasLimit in FeeConfig",
+			config: func() *FeeConfig {
+				c := ValidTestFeeConfig
+				c.ElasticGasLimit = &ElasticGasLimitConfig{MinGasLimit: 16_000_001, MaxGasLimit: 16_000_000, GasLimitChangeDenominator: 1024}
+				return &c
+			}(),
+			expectedError: errMinGasLimitTooHigh,
+		},
+		{
+			name: "invalid GasLimitChangeDenominator in FeeConfig",
+			config: func() *FeeConfig {
+				c := ValidTestFeeConfig
+				c.ElasticGasLimit = &ElasticGasLimitConfig{MinGasLimit: 4_000_000, MaxGasLimit: 16_000_000, GasLimitChangeDenominator: 0}
+				return &c
+			}(),
+			expectedError: errGasLimitChangeDenominatorTooLow,
+		},
+		{
+			name: "GasLimit outside of ElasticGasLimit in FeeConfig",
+			config: func() *FeeConfig {
+				c := ValidTestFeeConfig
+				c.ElasticGasLimit = &ElasticGasLimitConfig{MinGasLimit: 10_000_000, MaxGasLimit: 16_000_000, GasLimitChangeDenominator: 1024}
+				return &c
+			}(),
+			expectedError: errGasLimitOutOfElasticBounds,
+		},
 	}
 
 	for _, test := range tests {
@@ -122,6 +167,16 @@ func TestEqual(t *testing.T) {
 			b:        func() *FeeConfig { c := ValidTestFeeConfig; c.GasLimit = big.NewInt(1); return &c }(),
 			expected: false,
 		},
+		{
+			name: "not equal elastic gas limit",
+			a:    &ValidTestFeeConfig,
+			b: func() *FeeConfig {
+				c := ValidTestFeeConfig
+				c.ElasticGasLimit = &ElasticGasLimitConfig{MinGasLimit: 4_000_000, MaxGasLimit: 16_000_000, GasLimitChangeDenominator: 1024}
+				return &c
+			}(),
+			expected: false,
+		},
 		{
 			name:     "not equal nil",
 			a:        &ValidTestFeeConfig,
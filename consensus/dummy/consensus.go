@@ -158,6 +158,13 @@ func verifyHeaderGasFields(config *extras.ChainConfig, header *types.Header, par
 		return fmt.Errorf("expected base fee (%d), found (%d)", expectedBaseFee, header.BaseFee)
 	}
 
+	// Verify the blob gas fields, which are set from Cancun.
+	if chain.Config().IsCancun(header.Number, header.Time) {
+		if err := customheader.VerifyBlobGas(config, parent, header); err != nil {
+			return err
+		}
+	}
+
 	// Enforce BlockGasCost constraints
 	expectedBlockGasCost := customheader.BlockGasCost(
 		config,
@@ -107,6 +107,10 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 		}
 	}
 
+	if err := VerifyEnvelopes(v.config, block); err != nil {
+		return err
+	}
+
 	// Ancestor block must be known.
 	if !v.bc.HasBlockAndState(block.ParentHash(), block.NumberU64()-1) {
 		if !v.bc.HasBlock(block.ParentHash(), block.NumberU64()-1) {
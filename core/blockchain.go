@@ -42,7 +42,6 @@ import (
 
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/common/lru"
-	"github.com/ava-labs/libevm/consensus/misc/eip4844"
 	"github.com/ava-labs/libevm/core/rawdb"
 	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/core/types"
@@ -59,6 +58,7 @@ import (
 	"github.com/ava-labs/subnet-evm/core/state/snapshot"
 	"github.com/ava-labs/subnet-evm/internal/version"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customheader"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
 	"github.com/ava-labs/subnet-evm/triedb/firewood"
@@ -211,6 +211,7 @@ type CacheConfig struct {
 	AcceptedCacheSize               int     // Depth of accepted headers cache and accepted logs cache at the accepted tip
 	TransactionHistory              uint64  // Number of recent blocks for which to maintain transaction lookup indices
 	SkipTxIndexing                  bool    // Whether to skip transaction indexing
+	FeeConfigHistory                bool    // Whether to index the fee config changes of accepted blocks
 	StateHistory                    uint64  // Number of blocks from head whose state histories are reserved.
 	StateScheme                     string  // Scheme used to store ethereum states and merkle tree nodes on top
 
@@ -509,6 +510,10 @@ func NewBlockChain(
 		bc.repairTxIndexTail(latestStateSynced)
 	}
 
+	if err := bc.initFeeConfigHistory(); err != nil {
+		return nil, fmt.Errorf("could not initialize fee config history: %w", err)
+	}
+
 	// Start processing accepted blocks effects in the background
 	go bc.startAcceptor()
 
@@ -528,6 +533,7 @@ func (bc *BlockChain) writeBlockAcceptedIndices(b *types.Block) error {
 	if err := bc.batchBlockAcceptedIndices(batch, b); err != nil {
 		return err
 	}
+	bc.batchFeeConfigChanges(batch, b)
 	if err := batch.Write(); err != nil {
 		return fmt.Errorf("%w: failed to write accepted indices entries batch", err)
 	}
@@ -1271,6 +1277,11 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, parentRoot common.
 	blockBatch := bc.db.NewBatch()
 	rawdb.WriteBlock(blockBatch, block)
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
+	if prices := discountedGasPrices(block, receipts); len(prices) > 0 {
+		if err := customrawdb.WriteDiscountedGasPrices(blockBatch, block.NumberU64(), block.Hash(), prices); err != nil {
+			return fmt.Errorf("failed to write discounted gas prices: %w", err)
+		}
+	}
 	rawdb.WritePreimages(blockBatch, state.Preimages())
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
@@ -1499,11 +1510,7 @@ func (bc *BlockChain) insertBlock(block *types.Block, writes bool) error {
 // collectUnflattenedLogs collects the logs that were generated or removed during
 // the processing of a block.
 func (bc *BlockChain) collectUnflattenedLogs(b *types.Block, removed bool) [][]*types.Log {
-	var blobGasPrice *big.Int
-	excessBlobGas := b.ExcessBlobGas()
-	if excessBlobGas != nil {
-		blobGasPrice = eip4844.CalcBlobFee(*excessBlobGas)
-	}
+	blobGasPrice := customheader.BlobBaseFee(params.GetExtra(bc.chainConfig), b.Header())
 	receipts := rawdb.ReadRawReceipts(bc.db, b.Hash(), b.NumberU64())
 	if err := receipts.DeriveFields(bc.chainConfig, b.Hash(), b.NumberU64(), b.Time(), b.BaseFee(), blobGasPrice, b.Transactions()); err != nil {
 		log.Error("Failed to derive block receipts fields", "hash", b.Hash(), "number", b.NumberU64(), "err", err)
@@ -2202,6 +2209,13 @@ func (bc *BlockChain) ResetToStateSyncedBlock(block *types.Block) error {
 	if err := customrawdb.WriteSyncPerformed(batch, block.NumberU64()); err != nil {
 		return err
 	}
+	// The states of the blocks preceding the synced block are not available to index
+	// their fee config changes.
+	if bc.cacheConfig.FeeConfigHistory {
+		if err := customrawdb.WriteFeeConfigHistoryTail(batch, block.NumberU64()+1); err != nil {
+			return err
+		}
+	}
 
 	if err := batch.Write(); err != nil {
 		return err
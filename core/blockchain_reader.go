@@ -36,12 +36,15 @@ import (
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/libevm/core/vm"
 	"github.com/ava-labs/libevm/event"
+	"github.com/ava-labs/libevm/log"
 	"github.com/ava-labs/libevm/triedb"
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/core/state/snapshot"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customheader"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
 )
@@ -195,6 +198,26 @@ func (bc *BlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
 	if receipts == nil {
 		return nil
 	}
+	// The receipts are derived with Ethereum's blob gas price, which differs from
+	// the price of the blob config of the chain.
+	if blobGasPrice := customheader.BlobBaseFee(params.GetExtra(bc.chainConfig), header); blobGasPrice != nil {
+		for _, receipt := range receipts {
+			if receipt.Type == types.BlobTxType {
+				receipt.BlobGasPrice = blobGasPrice
+			}
+		}
+	}
+	// The receipts are derived with the base fee of the block, which differs from
+	// the base fee charged to senders with a fee discount.
+	prices, err := customrawdb.ReadDiscountedGasPrices(bc.db, *number, hash)
+	if err != nil {
+		log.Error("Failed to read discounted gas prices", "hash", hash, "number", *number, "err", err)
+	}
+	for _, price := range prices {
+		if price.TxIndex < uint64(len(receipts)) {
+			receipts[price.TxIndex].EffectiveGasPrice = price.GasPrice
+		}
+	}
 	bc.receiptsCache.Add(hash, receipts)
 	return receipts
 }
@@ -393,6 +416,10 @@ func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig
 	if err := storedFeeConfig.Verify(); err != nil {
 		return commontype.EmptyFeeConfig, nil, err
 	}
+	// The fee manager does not store the elastic gas limit, so the one of the
+	// chain config applies. The fee manager only stores gas limits within its
+	// bounds.
+	storedFeeConfig.ElasticGasLimit = config.FeeConfig.ElasticGasLimit
 	lastChangedAt := feemanager.GetFeeConfigLastChangedAt(stateDB)
 	cacheable := &cacheableFeeConfig{feeConfig: storedFeeConfig, lastChangedAt: lastChangedAt}
 	// add it to the cache
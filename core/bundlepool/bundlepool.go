@@ -0,0 +1,305 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package bundlepool implements a pool of transaction bundles, sequences of
+// transactions the block builder includes back-to-back at the front of a block,
+// or not at all.
+package bundlepool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/ava-labs/libevm/metrics"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/params"
+)
+
+// txMaxSize is the maximum size of a bundle transaction, the same as in the
+// transaction pool.
+const txMaxSize = 128 * 1024
+
+var (
+	ErrEmptyBundle          = errors.New("bundle has no transactions")
+	ErrBundleTooLarge       = errors.New("bundle has too many transactions")
+	ErrInvalidTimestamps    = errors.New("bundle minimum timestamp is after its maximum timestamp")
+	ErrBundleExpired        = errors.New("bundle cannot be included in any future block")
+	ErrAlreadyKnown         = errors.New("bundle already known")
+	ErrBundlePoolFull       = errors.New("bundle pool is full")
+	ErrInvalidBundleSender  = errors.New("invalid bundle transaction sender")
+	ErrDuplicateTransaction = errors.New("bundle has duplicate transactions")
+)
+
+var (
+	addedMeter    = metrics.NewRegisteredMeter("bundlepool/added", nil)
+	rejectedMeter = metrics.NewRegisteredMeter("bundlepool/rejected", nil)
+	expiredMeter  = metrics.NewRegisteredMeter("bundlepool/expired", nil)
+	bundlesGauge  = metrics.NewRegisteredGauge("bundlepool/bundles", nil)
+)
+
+// Config are the configuration parameters of the bundle pool.
+type Config struct {
+	MaxBundles   int           // Maximum number of bundles in the pool
+	MaxBundleTxs int           // Maximum number of transactions in a bundle
+	Lifetime     time.Duration // Time bundles without a maximum timestamp stay in the pool
+}
+
+// DefaultConfig contains the default configurations for the bundle pool.
+var DefaultConfig = Config{
+	MaxBundles:   256,
+	MaxBundleTxs: 16,
+	Lifetime:     time.Minute,
+}
+
+// BlockChain defines the minimal set of methods needed to back a bundle pool
+// with a chain.
+type BlockChain interface {
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
+
+	// CurrentBlock returns the current head of the chain.
+	CurrentBlock() *types.Header
+
+	// StateAt returns a state database for a given root hash (generally the head).
+	StateAt(root common.Hash) (*state.StateDB, error)
+}
+
+// Bundle is a sequence of transactions to include back-to-back in a block, only
+// if all of them succeed.
+type Bundle struct {
+	Txs          []*types.Transaction
+	BlockNumber  uint64 // Number of the only block the bundle can be included in, or 0 for any block
+	MinTimestamp uint64 // Minimum timestamp of the block the bundle is included in, or 0
+	MaxTimestamp uint64 // Maximum timestamp of the block the bundle is included in, at most [Config.Lifetime] after it is added
+
+	hash common.Hash
+}
+
+// Hash returns the hash of the bundle, the hash of the concatenated hashes of
+// its transactions.
+func (b *Bundle) Hash() common.Hash {
+	if b.hash == (common.Hash{}) {
+		hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+		for _, tx := range b.Txs {
+			hashes = append(hashes, tx.Hash().Bytes()...)
+		}
+		b.hash = crypto.Keccak256Hash(hashes)
+	}
+	return b.hash
+}
+
+// expired returns whether the bundle cannot be included in the block with the
+// given number and time, nor in any later block.
+func (b *Bundle) expired(number uint64, time uint64) bool {
+	return (b.BlockNumber != 0 && number > b.BlockNumber) || (b.MaxTimestamp != 0 && time > b.MaxTimestamp)
+}
+
+// eligible returns whether the bundle can be included in the block with the
+// given number and time.
+func (b *Bundle) eligible(number uint64, time uint64) bool {
+	return !b.expired(number, time) && (b.BlockNumber == 0 || number == b.BlockNumber) && time >= b.MinTimestamp
+}
+
+// BundlePool holds the bundles submitted to this node until they are included
+// in a block built by this node or expire.
+type BundlePool struct {
+	config Config
+	chain  BlockChain
+
+	mu      sync.Mutex
+	bundles []*Bundle // Bundles in arrival order
+	known   map[common.Hash]struct{}
+}
+
+// New creates a new bundle pool backed by [chain].
+func New(config Config, chain BlockChain) *BundlePool {
+	return &BundlePool{
+		config: config,
+		chain:  chain,
+		known:  make(map[common.Hash]struct{}),
+	}
+}
+
+// Add validates [bundle] against the current head of the chain and its state,
+// and adds it to the pool. If the bundle has no maximum timestamp or a later one
+// than [Config.Lifetime] after [now], it is set to [Config.Lifetime] after [now].
+func (p *BundlePool) Add(bundle *Bundle, now time.Time) error {
+	if err := p.add(bundle, now); err != nil {
+		rejectedMeter.Mark(1)
+		return err
+	}
+	addedMeter.Mark(1)
+	return nil
+}
+
+func (p *BundlePool) add(bundle *Bundle, now time.Time) error {
+	if len(bundle.Txs) == 0 {
+		return ErrEmptyBundle
+	}
+	if len(bundle.Txs) > p.config.MaxBundleTxs {
+		return fmt.Errorf("%w: %d > %d", ErrBundleTooLarge, len(bundle.Txs), p.config.MaxBundleTxs)
+	}
+	// Bundles must not outlive the lifetime of the pool, so that bundles failing
+	// in every block do not hold their slot forever.
+	if maxTimestamp := uint64(now.Add(p.config.Lifetime).Unix()); bundle.MaxTimestamp == 0 || bundle.MaxTimestamp > maxTimestamp {
+		bundle.MaxTimestamp = maxTimestamp
+	}
+	if bundle.MinTimestamp > bundle.MaxTimestamp {
+		return fmt.Errorf("%w: %d > %d", ErrInvalidTimestamps, bundle.MinTimestamp, bundle.MaxTimestamp)
+	}
+	head := p.chain.CurrentBlock()
+	number := head.Number.Uint64() + 1
+	if bundle.expired(number, head.Time) {
+		return ErrBundleExpired
+	}
+	if err := p.validateTxs(bundle, head); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	hash := bundle.Hash()
+	if _, ok := p.known[hash]; ok {
+		return fmt.Errorf("%w: %s", ErrAlreadyKnown, hash)
+	}
+	p.prune(number, head.Time)
+	if len(p.bundles) >= p.config.MaxBundles {
+		return ErrBundlePoolFull
+	}
+	p.bundles = append(p.bundles, bundle)
+	p.known[hash] = struct{}{}
+	bundlesGauge.Update(int64(len(p.bundles)))
+	return nil
+}
+
+// validateTxs checks that the transactions of [bundle] are valid on top of [head]
+// and its state, taking the transactions of the same sender earlier in the bundle
+// into account. The fee cap of each transaction must cover the base fee of [head]
+// charged to its sender.
+func (p *BundlePool) validateTxs(bundle *Bundle, head *types.Header) error {
+	var (
+		config = p.chain.Config()
+		signer = types.MakeSigner(config, new(big.Int).Add(head.Number, common.Big1), head.Time)
+		opts   = &txpool.ValidationOptions{
+			Config:  config,
+			Accept:  1<<types.LegacyTxType | 1<<types.AccessListTxType | 1<<types.DynamicFeeTxType,
+			MaxSize: txMaxSize,
+			MinTip:  new(big.Int),
+		}
+		seen  = make(map[common.Hash]struct{}, len(bundle.Txs))
+		spent = make(map[common.Address]*big.Int)
+	)
+	for i, tx := range bundle.Txs {
+		if tx.Type() == types.BlobTxType {
+			return fmt.Errorf("transaction %d: %w", i, core.ErrTxTypeNotSupported)
+		}
+		if _, err := types.Sender(signer, tx); err != nil {
+			return fmt.Errorf("%w: transaction %d: %w", ErrInvalidBundleSender, i, err)
+		}
+		if _, ok := seen[tx.Hash()]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateTransaction, tx.Hash())
+		}
+		seen[tx.Hash()] = struct{}{}
+		if err := txpool.ValidateTransaction(tx, head, signer, opts); err != nil {
+			return fmt.Errorf("transaction %d: %w", i, err)
+		}
+	}
+
+	statedb, err := p.chain.StateAt(head.Root)
+	if err != nil {
+		return err
+	}
+	stateOpts := &txpool.ValidationOptionsWithState{
+		State:            statedb,
+		UsedAndLeftSlots: func(common.Address) (int, int) { return 0, 1 },
+		ExistingExpenditure: func(addr common.Address) *big.Int {
+			if cost := spent[addr]; cost != nil {
+				return cost
+			}
+			return new(big.Int)
+		},
+		ExistingCost: func(common.Address, uint64) *big.Int { return nil },
+		Rules:        config.Rules(head.Number, params.IsMergeTODO, head.Time),
+	}
+	for i, tx := range bundle.Txs {
+		from, _ := types.Sender(signer, tx) // already validated
+		if baseFee := core.SenderBaseFee(config, statedb, from, head.BaseFee, head.Time); baseFee != nil && tx.GasFeeCapIntCmp(baseFee) < 0 {
+			return fmt.Errorf("transaction %d: %w: address %v, maxFeePerGas: %v, baseFee: %v", i, core.ErrFeeCapTooLow, from.Hex(), tx.GasFeeCap(), baseFee)
+		}
+		if err := txpool.ValidateTransactionWithState(tx, signer, stateOpts); err != nil {
+			return fmt.Errorf("transaction %d: %w", i, err)
+		}
+		cost := stateOpts.ExistingExpenditure(from)
+		spent[from] = cost.Add(cost, core.TxNativeCost(tx))
+	}
+	return nil
+}
+
+// Pending drops the expired bundles and returns the bundles that can be
+// included in the block with the given number and time, in arrival order.
+func (p *BundlePool) Pending(number uint64, time uint64) []*Bundle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.prune(number, time)
+	var pending []*Bundle
+	for _, bundle := range p.bundles {
+		if bundle.eligible(number, time) {
+			pending = append(pending, bundle)
+		}
+	}
+	return pending
+}
+
+// Remove drops the bundle with the given hash from the pool.
+func (p *BundlePool) Remove(hash common.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.known[hash]; !ok {
+		return
+	}
+	delete(p.known, hash)
+	for i, bundle := range p.bundles {
+		if bundle.Hash() == hash {
+			p.bundles = append(p.bundles[:i], p.bundles[i+1:]...)
+			break
+		}
+	}
+	bundlesGauge.Update(int64(len(p.bundles)))
+}
+
+// Len returns the number of bundles in the pool.
+func (p *BundlePool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.bundles)
+}
+
+// prune drops the bundles that cannot be included in the block with the given
+// number and time, nor in any later block. Assumes the lock is held.
+func (p *BundlePool) prune(number uint64, time uint64) {
+	bundles := p.bundles[:0]
+	for _, bundle := range p.bundles {
+		if bundle.expired(number, time) {
+			delete(p.known, bundle.Hash())
+			expiredMeter.Mark(1)
+			continue
+		}
+		bundles = append(bundles, bundle)
+	}
+	clear(p.bundles[len(bundles):])
+	p.bundles = bundles
+	bundlesGauge.Update(int64(len(p.bundles)))
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bundlepool
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+func TestMain(m *testing.M) {
+	core.RegisterExtras()
+	customtypes.Register()
+	params.RegisterExtras()
+	os.Exit(m.Run())
+}
+
+type testChain struct {
+	head  *types.Header
+	state *state.StateDB
+}
+
+// newTestChain returns a chain whose head is at [number] and [time], where the
+// accounts of [keys] have [balance] and nonce [nonce].
+func newTestChain(t *testing.T, number uint64, time uint64, balance uint64, nonce uint64, keys ...*ecdsa.PrivateKey) *testChain {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	for _, key := range keys {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		statedb.SetBalance(addr, uint256.NewInt(balance))
+		statedb.SetNonce(addr, nonce)
+	}
+	return &testChain{
+		head: &types.Header{
+			Number:   new(big.Int).SetUint64(number),
+			Time:     time,
+			GasLimit: 8_000_000,
+			BaseFee:  big.NewInt(params.GWei),
+		},
+		state: statedb,
+	}
+}
+
+func (*testChain) Config() *params.ChainConfig   { return params.TestChainConfig }
+func (c *testChain) CurrentBlock() *types.Header { return c.head }
+
+func (c *testChain) StateAt(common.Hash) (*state.StateDB, error) {
+	return c.state.Copy(), nil
+}
+
+func newTestTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	return newTestTxWithFeeCap(t, key, nonce, big.NewInt(params.GWei))
+}
+
+func newTestTxWithFeeCap(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, feeCap *big.Int) *types.Transaction {
+	tx, err := types.SignNewTx(key, types.LatestSigner(params.TestChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     nonce,
+		To:        &common.Address{},
+		Gas:       ethparams.TxGas,
+		GasFeeCap: feeCap,
+		GasTipCap: common.Big0,
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+// txCost is the cost of a transaction returned by [newTestTx].
+const txCost = ethparams.TxGas * params.GWei
+
+func TestAdd(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx0, tx1 := newTestTx(t, key, 0), newTestTx(t, key, 1)
+	blobTx := types.NewTx(&types.BlobTx{})
+	now := time.Unix(1000, 0)
+
+	tests := []struct {
+		name    string
+		bundle  *Bundle
+		balance uint64 // Defaults to one ether
+		nonce   uint64
+		want    error
+	}{
+		{
+			name:   "valid",
+			bundle: &Bundle{Txs: []*types.Transaction{tx0, tx1}},
+		},
+		{
+			name:   "empty",
+			bundle: &Bundle{},
+			want:   ErrEmptyBundle,
+		},
+		{
+			name:   "too large",
+			bundle: &Bundle{Txs: []*types.Transaction{tx0, tx1, tx0}},
+			want:   ErrBundleTooLarge,
+		},
+		{
+			name:   "duplicate transaction",
+			bundle: &Bundle{Txs: []*types.Transaction{tx0, tx0}},
+			want:   ErrDuplicateTransaction,
+		},
+		{
+			name:   "blob transaction",
+			bundle: &Bundle{Txs: []*types.Transaction{blobTx}},
+			want:   core.ErrTxTypeNotSupported,
+		},
+		{
+			name:   "unsigned transaction",
+			bundle: &Bundle{Txs: []*types.Transaction{types.NewTx(&types.DynamicFeeTx{ChainID: params.TestChainConfig.ChainID})}},
+			want:   ErrInvalidBundleSender,
+		},
+		{
+			name:   "invalid timestamps",
+			bundle: &Bundle{Txs: []*types.Transaction{tx0}, MinTimestamp: 1100, MaxTimestamp: 1050},
+			want:   ErrInvalidTimestamps,
+		},
+		{
+			name:   "past block number",
+			bundle: &Bundle{Txs: []*types.Transaction{tx0}, BlockNumber: 10},
+			want:   ErrBundleExpired,
+		},
+		{
+			name:   "past max timestamp",
+			bundle: &Bundle{Txs: []*types.Transaction{tx0}, MaxTimestamp: 999},
+			want:   ErrBundleExpired,
+		},
+		{
+			name:   "max timestamp past lifetime",
+			bundle: &Bundle{Txs: []*types.Transaction{tx1}, MaxTimestamp: 5000},
+		},
+		{
+			name:   "nonce too low",
+			bundle: &Bundle{Txs: []*types.Transaction{newTestTx(t, key, 0), tx1}},
+			nonce:  1,
+			want:   core.ErrNonceTooLow,
+		},
+		{
+			name:    "insufficient funds for all transactions",
+			bundle:  &Bundle{Txs: []*types.Transaction{tx0, tx1}},
+			balance: 2*txCost - 1,
+			want:    core.ErrInsufficientFunds,
+		},
+		{
+			name:   "fee cap below base fee",
+			bundle: &Bundle{Txs: []*types.Transaction{newTestTxWithFeeCap(t, key, 0, big.NewInt(params.GWei-1))}},
+			want:   core.ErrFeeCapTooLow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			balance := test.balance
+			if balance == 0 {
+				balance = params.Ether
+			}
+			chain := newTestChain(t, 10, 1000, balance, test.nonce, key)
+			pool := New(Config{MaxBundles: 1, MaxBundleTxs: 2, Lifetime: time.Minute}, chain)
+			err := pool.Add(test.bundle, now)
+			require.ErrorIs(t, err, test.want)
+			if test.want != nil {
+				require.Zero(t, pool.Len())
+				return
+			}
+			require.Equal(t, 1, pool.Len())
+			require.Equal(t, uint64(1060), test.bundle.MaxTimestamp)
+			require.ErrorIs(t, pool.Add(test.bundle, now), ErrAlreadyKnown)
+			require.ErrorIs(t, pool.Add(&Bundle{Txs: []*types.Transaction{tx0}}, now), ErrBundlePoolFull)
+		})
+	}
+}
+
+func TestPending(t *testing.T) {
+	require := require.New(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+
+	chain := newTestChain(t, 10, 1000, params.Ether, 0, key)
+	pool := New(DefaultConfig, chain)
+	var (
+		anyBlock = &Bundle{Txs: []*types.Transaction{newTestTx(t, key, 0)}}
+		block12  = &Bundle{Txs: []*types.Transaction{newTestTx(t, key, 1)}, BlockNumber: 12}
+		later    = &Bundle{Txs: []*types.Transaction{newTestTx(t, key, 2)}, MinTimestamp: 1030, MaxTimestamp: 1040}
+	)
+	now := time.Unix(1000, 0)
+	for _, bundle := range []*Bundle{anyBlock, block12, later} {
+		require.NoError(pool.Add(bundle, now))
+	}
+
+	require.Equal([]*Bundle{anyBlock}, pool.Pending(11, 1010))
+	require.Equal([]*Bundle{anyBlock, block12}, pool.Pending(12, 1020))
+	require.Equal([]*Bundle{anyBlock, block12, later}, pool.Pending(12, 1030))
+
+	// Bundles past their block number or maximum timestamp are dropped.
+	require.Equal([]*Bundle{anyBlock}, pool.Pending(13, 1050))
+	require.Equal(1, pool.Len())
+
+	pool.Remove(anyBlock.Hash())
+	require.Zero(pool.Len())
+	require.Empty(pool.Pending(13, 1050))
+}
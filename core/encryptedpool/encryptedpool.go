@@ -0,0 +1,334 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package encryptedpool implements a pool of envelope transactions, carrying
+// the ciphertexts of transactions encrypted to the key held by the validator
+// set. The block builder fixes the order of the envelopes in a block without
+// knowing their contents, and they are only decrypted once the block is
+// accepted, so that the transactions they seal cannot be front-run.
+package encryptedpool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/event"
+	"github.com/ava-labs/libevm/metrics"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/params"
+)
+
+var (
+	ErrNotEnvelope         = errors.New("transaction is not an envelope")
+	ErrEnvelopesNotEnabled = errors.New("envelopes not enabled")
+	ErrEmptyEnvelope       = errors.New("envelope has no ciphertext")
+	ErrCiphertextTooLarge  = errors.New("envelope ciphertext too large")
+	ErrEnvelopeValue       = errors.New("envelope transfers value")
+	ErrAlreadyKnown        = errors.New("envelope already known")
+	ErrEncryptedPoolFull   = errors.New("encrypted pool is full")
+)
+
+var (
+	addedMeter     = metrics.NewRegisteredMeter("encryptedpool/added", nil)
+	rejectedMeter  = metrics.NewRegisteredMeter("encryptedpool/rejected", nil)
+	expiredMeter   = metrics.NewRegisteredMeter("encryptedpool/expired", nil)
+	acceptedMeter  = metrics.NewRegisteredMeter("encryptedpool/accepted", nil)
+	envelopesGauge = metrics.NewRegisteredGauge("encryptedpool/envelopes", nil)
+)
+
+// Config are the configuration parameters of the encrypted pool.
+type Config struct {
+	MaxEnvelopes         int           // Maximum number of envelopes in the pool
+	MaxEnvelopeSize      int           // Maximum size of an envelope transaction, in bytes
+	MaxEnvelopesPerBlock int           // Maximum number of envelopes included in a block
+	Lifetime             time.Duration // Time envelopes stay in the pool until their block is accepted
+}
+
+// DefaultConfig contains the default configurations for the encrypted pool.
+var DefaultConfig = Config{
+	MaxEnvelopes:         1024,
+	MaxEnvelopeSize:      128 * 1024,
+	MaxEnvelopesPerBlock: 256,
+	Lifetime:             10 * time.Minute,
+}
+
+// BlockChain defines the minimal set of methods needed to back an encrypted
+// pool with a chain.
+type BlockChain interface {
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
+
+	// CurrentBlock returns the current head of the chain.
+	CurrentBlock() *types.Header
+
+	// StateAt returns a state database for a given root hash (generally the head).
+	StateAt(root common.Hash) (*state.StateDB, error)
+
+	// SubscribeChainAcceptedEvent subscribes to the accepted blocks.
+	SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) event.Subscription
+}
+
+// NewEnvelopeEvent is posted when an envelope is added to the pool.
+type NewEnvelopeEvent struct {
+	Tx    *types.Transaction
+	Local bool // Whether the envelope was submitted to this node rather than gossiped
+}
+
+// EncryptedPool holds the envelope transactions known to this node until the
+// block including them is accepted, or they expire.
+type EncryptedPool struct {
+	config Config
+	chain  BlockChain
+
+	mu        sync.Mutex
+	envelopes []*types.Transaction // Envelopes in arrival order
+	added     map[common.Hash]time.Time
+
+	feed event.Feed
+
+	sub  event.Subscription
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// New creates a new encrypted pool backed by [chain]. The envelopes included
+// in the blocks accepted by [chain] are removed from the pool until [Close].
+func New(config Config, chain BlockChain) *EncryptedPool {
+	p := &EncryptedPool{
+		config: config,
+		chain:  chain,
+		added:  make(map[common.Hash]time.Time),
+		quit:   make(chan struct{}),
+	}
+	accepted := make(chan core.ChainEvent, 1)
+	p.sub = chain.SubscribeChainAcceptedEvent(accepted)
+	p.wg.Add(1)
+	go p.loop(accepted)
+	return p
+}
+
+// loop removes the envelopes included in the accepted blocks from the pool.
+func (p *EncryptedPool) loop(accepted <-chan core.ChainEvent) {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case event := <-accepted:
+			var hashes []common.Hash
+			for _, tx := range event.Block.Transactions() {
+				if core.IsEnvelope(tx) {
+					hashes = append(hashes, tx.Hash())
+				}
+			}
+			acceptedMeter.Mark(int64(p.Remove(hashes...)))
+		case <-p.sub.Err():
+			return
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// Close stops removing the envelopes of the accepted blocks from the pool.
+func (p *EncryptedPool) Close() {
+	p.sub.Unsubscribe()
+	close(p.quit)
+	p.wg.Wait()
+}
+
+// Add validates the envelope transaction [tx] against the current head of the
+// chain and its state, and adds it to the pool at [now]. [local] is whether it
+// was submitted to this node rather than gossiped.
+func (p *EncryptedPool) Add(tx *types.Transaction, local bool, now time.Time) error {
+	if err := p.add(tx, now); err != nil {
+		rejectedMeter.Mark(1)
+		return err
+	}
+	addedMeter.Mark(1)
+	p.feed.Send(NewEnvelopeEvent{Tx: tx, Local: local})
+	return nil
+}
+
+func (p *EncryptedPool) add(tx *types.Transaction, now time.Time) error {
+	if p.Has(tx.Hash()) {
+		return fmt.Errorf("%w: %s", ErrAlreadyKnown, tx.Hash())
+	}
+	if err := p.validateTx(tx, p.chain.CurrentBlock()); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	hash := tx.Hash()
+	if _, ok := p.added[hash]; ok {
+		return fmt.Errorf("%w: %s", ErrAlreadyKnown, hash)
+	}
+	p.prune(now)
+	if len(p.envelopes) >= p.config.MaxEnvelopes {
+		return ErrEncryptedPoolFull
+	}
+	p.envelopes = append(p.envelopes, tx)
+	p.added[hash] = now
+	envelopesGauge.Update(int64(len(p.envelopes)))
+	return nil
+}
+
+// validateTx checks that [tx] is an envelope transaction valid on top of [head]
+// and its state, under the envelope config of the chain. Its fee cap must cover
+// the base fee of [head] charged to its sender, as it pays for its gas whether
+// its ciphertext can be decrypted or not.
+func (p *EncryptedPool) validateTx(tx *types.Transaction, head *types.Header) error {
+	if !core.IsEnvelope(tx) {
+		return fmt.Errorf("%w: recipient %v, expected %v", ErrNotEnvelope, tx.To(), core.EnvelopeAddress)
+	}
+	config := p.chain.Config()
+	configExtra := params.GetExtra(config)
+	if !configExtra.IsEnvelopeEnabled(head.Time) {
+		return ErrEnvelopesNotEnabled
+	}
+	if len(tx.Data()) == 0 {
+		return ErrEmptyEnvelope
+	}
+	if size, maxSize := uint64(len(tx.Data())), configExtra.EnvelopeConfig.MaxCiphertextSize; size > maxSize {
+		return fmt.Errorf("%w: size %d > %d", ErrCiphertextTooLarge, size, maxSize)
+	}
+	if tx.Value().Sign() != 0 {
+		return ErrEnvelopeValue
+	}
+	var (
+		signer = types.MakeSigner(config, new(big.Int).Add(head.Number, common.Big1), head.Time)
+		opts   = &txpool.ValidationOptions{
+			Config:  config,
+			Accept:  1<<types.LegacyTxType | 1<<types.AccessListTxType | 1<<types.DynamicFeeTxType,
+			MaxSize: uint64(p.config.MaxEnvelopeSize),
+			MinTip:  new(big.Int),
+		}
+	)
+	if err := txpool.ValidateTransaction(tx, head, signer, opts); err != nil {
+		return err
+	}
+	from, _ := types.Sender(signer, tx) // already validated
+
+	statedb, err := p.chain.StateAt(head.Root)
+	if err != nil {
+		return err
+	}
+	if baseFee := core.SenderBaseFee(config, statedb, from, head.BaseFee, head.Time); baseFee != nil && tx.GasFeeCapIntCmp(baseFee) < 0 {
+		return fmt.Errorf("%w: address %v, maxFeePerGas: %v, baseFee: %v", core.ErrFeeCapTooLow, from.Hex(), tx.GasFeeCap(), baseFee)
+	}
+	return txpool.ValidateTransactionWithState(tx, signer, &txpool.ValidationOptionsWithState{
+		State:               statedb,
+		UsedAndLeftSlots:    func(common.Address) (int, int) { return 0, 1 },
+		ExistingExpenditure: func(common.Address) *big.Int { return new(big.Int) },
+		ExistingCost:        func(common.Address, uint64) *big.Int { return nil },
+		Rules:               config.Rules(head.Number, params.IsMergeTODO, head.Time),
+	})
+}
+
+// Has returns whether the envelope with the given hash is in the pool.
+func (p *EncryptedPool) Has(hash common.Hash) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, ok := p.added[hash]
+	return ok
+}
+
+// Iterate calls [f] on the envelopes of the pool in arrival order, until it
+// returns false.
+func (p *EncryptedPool) Iterate(f func(tx *types.Transaction) bool) {
+	p.mu.Lock()
+	envelopes := append([]*types.Transaction(nil), p.envelopes...)
+	p.mu.Unlock()
+
+	for _, tx := range envelopes {
+		if !f(tx) {
+			return
+		}
+	}
+}
+
+// Pending drops the expired envelopes and returns the envelopes to include in
+// the next block, in arrival order, up to the limit of the pool and of the
+// envelope config of the chain. They stay in the pool until the block
+// including them is accepted.
+func (p *EncryptedPool) Pending(now time.Time) []*types.Transaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.prune(now)
+	n := min(len(p.envelopes), p.config.MaxEnvelopesPerBlock)
+	if envelopeConfig := params.GetExtra(p.chain.Config()).EnvelopeConfig; envelopeConfig != nil && uint64(n) > envelopeConfig.MaxEnvelopesPerBlock {
+		n = int(envelopeConfig.MaxEnvelopesPerBlock)
+	}
+	return append([]*types.Transaction(nil), p.envelopes[:n]...)
+}
+
+// Remove drops the envelopes with the given hashes from the pool, returning the
+// number of envelopes dropped.
+func (p *EncryptedPool) Remove(hashes ...common.Hash) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	removed := 0
+	for _, hash := range hashes {
+		if _, ok := p.added[hash]; ok {
+			delete(p.added, hash)
+			removed++
+		}
+	}
+	if removed == 0 {
+		return 0
+	}
+	envelopes := p.envelopes[:0]
+	for _, tx := range p.envelopes {
+		if _, ok := p.added[tx.Hash()]; ok {
+			envelopes = append(envelopes, tx)
+		}
+	}
+	clear(p.envelopes[len(envelopes):])
+	p.envelopes = envelopes
+	envelopesGauge.Update(int64(len(p.envelopes)))
+	return removed
+}
+
+// Len returns the number of envelopes in the pool.
+func (p *EncryptedPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.envelopes)
+}
+
+// SubscribeEnvelopes registers a subscription for the envelopes added to the
+// pool.
+func (p *EncryptedPool) SubscribeEnvelopes(ch chan<- NewEnvelopeEvent) event.Subscription {
+	return p.feed.Subscribe(ch)
+}
+
+// prune drops the envelopes added more than [Config.Lifetime] before [now].
+// Assumes the lock is held.
+func (p *EncryptedPool) prune(now time.Time) {
+	envelopes := p.envelopes[:0]
+	for _, tx := range p.envelopes {
+		hash := tx.Hash()
+		if now.Sub(p.added[hash]) > p.config.Lifetime {
+			delete(p.added, hash)
+			expiredMeter.Mark(1)
+			continue
+		}
+		envelopes = append(envelopes, tx)
+	}
+	clear(p.envelopes[len(envelopes):])
+	p.envelopes = envelopes
+	envelopesGauge.Update(int64(len(p.envelopes)))
+}
@@ -0,0 +1,260 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encryptedpool
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/ava-labs/libevm/event"
+	"github.com/ava-labs/libevm/trie"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+)
+
+// testChainConfig enables envelopes with at most 2 envelopes per block.
+var testChainConfig *params.ChainConfig
+
+func TestMain(m *testing.M) {
+	params.RegisterExtras()
+	customtypes.Register()
+	testChainConfig = envelopeChainConfig(2, 1024)
+	os.Exit(m.Run())
+}
+
+// envelopeChainConfig returns a copy of [params.TestChainConfig] enabling
+// envelopes with the given limits.
+func envelopeChainConfig(maxEnvelopesPerBlock, maxCiphertextSize uint64) *params.ChainConfig {
+	config := params.Copy(params.TestChainConfig)
+	params.GetExtra(&config).EnvelopeConfig = &extras.EnvelopeConfig{
+		MaxEnvelopesPerBlock: maxEnvelopesPerBlock,
+		MaxCiphertextSize:    maxCiphertextSize,
+	}
+	return &config
+}
+
+type testBlockChain struct {
+	config       *params.ChainConfig
+	statedb      *state.StateDB
+	acceptedFeed event.Feed
+}
+
+func newTestBlockChain(t *testing.T, funded ...common.Address) *testBlockChain {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	for _, addr := range funded {
+		statedb.SetBalance(addr, uint256.NewInt(params.Ether))
+	}
+	return &testBlockChain{config: testChainConfig, statedb: statedb}
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig {
+	return bc.config
+}
+
+func (*testBlockChain) CurrentBlock() *types.Header {
+	header := &types.Header{
+		Number:   new(big.Int),
+		GasLimit: 8_000_000,
+		BaseFee:  big.NewInt(1),
+	}
+	customtypes.GetHeaderExtra(header).TimeMilliseconds = new(uint64)
+	return header
+}
+
+func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
+	return bc.statedb, nil
+}
+
+func (bc *testBlockChain) SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) event.Subscription {
+	return bc.acceptedFeed.Subscribe(ch)
+}
+
+// envelope returns an envelope transaction of [key] sealing [ciphertext].
+func envelope(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, ciphertext []byte) *types.Transaction {
+	tx, err := types.SignTx(
+		types.NewTransaction(nonce, core.EnvelopeAddress, common.Big0, 100_000, big.NewInt(1), ciphertext),
+		types.LatestSigner(params.TestChainConfig),
+		key,
+	)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestAdd(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	unfundedKey, _ := crypto.GenerateKey()
+	signer := types.LatestSigner(params.TestChainConfig)
+	sign := func(tx *types.Transaction) *types.Transaction {
+		signed, err := types.SignTx(tx, signer, key)
+		require.NoError(t, err)
+		return signed
+	}
+
+	now := time.Unix(1000, 0)
+	config := Config{MaxEnvelopes: 1, MaxEnvelopeSize: 256, MaxEnvelopesPerBlock: 1, Lifetime: time.Minute}
+
+	tests := []struct {
+		name   string
+		config *params.ChainConfig
+		tx     *types.Transaction
+		want   error
+	}{
+		{
+			name: "valid",
+			tx:   envelope(t, key, 0, []byte{1, 2, 3, 4}),
+		},
+		{
+			name: "not an envelope",
+			tx:   sign(types.NewTransaction(0, common.Address{}, common.Big0, 100_000, big.NewInt(1), []byte{1})),
+			want: ErrNotEnvelope,
+		},
+		{
+			name:   "not enabled",
+			config: params.TestChainConfig,
+			tx:     envelope(t, key, 0, []byte{1, 2, 3, 4}),
+			want:   ErrEnvelopesNotEnabled,
+		},
+		{
+			name: "empty",
+			tx:   envelope(t, key, 0, nil),
+			want: ErrEmptyEnvelope,
+		},
+		{
+			name: "value",
+			tx:   sign(types.NewTransaction(0, core.EnvelopeAddress, common.Big1, 100_000, big.NewInt(1), []byte{1})),
+			want: ErrEnvelopeValue,
+		},
+		{
+			name:   "ciphertext too large",
+			config: envelopeChainConfig(2, 64),
+			tx:     envelope(t, key, 0, make([]byte, 65)),
+			want:   ErrCiphertextTooLarge,
+		},
+		{
+			name: "too large",
+			tx:   envelope(t, key, 0, make([]byte, 256)),
+			want: txpool.ErrOversizedData,
+		},
+		{
+			name: "fee cap below base fee",
+			tx:   sign(types.NewTransaction(0, core.EnvelopeAddress, common.Big0, 100_000, common.Big0, []byte{1})),
+			want: core.ErrFeeCapTooLow,
+		},
+		{
+			name: "unfunded",
+			tx:   envelope(t, unfundedKey, 0, []byte{1}),
+			want: core.ErrInsufficientFunds,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain := newTestBlockChain(t, crypto.PubkeyToAddress(key.PublicKey))
+			if test.config != nil {
+				chain.config = test.config
+			}
+			pool := New(config, chain)
+			defer pool.Close()
+
+			err := pool.Add(test.tx, true, now)
+			require.ErrorIs(t, err, test.want)
+			if test.want != nil {
+				require.Zero(t, pool.Len())
+				return
+			}
+			require.Equal(t, 1, pool.Len())
+			require.True(t, pool.Has(test.tx.Hash()))
+			require.ErrorIs(t, pool.Add(test.tx, true, now), ErrAlreadyKnown)
+			next := envelope(t, key, 1, []byte{5})
+			require.ErrorIs(t, pool.Add(next, true, now), ErrEncryptedPoolFull)
+
+			// Expired envelopes make room for new ones.
+			require.NoError(t, pool.Add(next, true, now.Add(2*time.Minute)))
+			require.False(t, pool.Has(test.tx.Hash()))
+		})
+	}
+}
+
+func TestPending(t *testing.T) {
+	require := require.New(t)
+
+	key, _ := crypto.GenerateKey()
+	chain := newTestBlockChain(t, crypto.PubkeyToAddress(key.PublicKey))
+	pool := New(Config{MaxEnvelopes: 4, MaxEnvelopeSize: 256, MaxEnvelopesPerBlock: 3, Lifetime: time.Minute}, chain)
+	defer pool.Close()
+	events := make(chan NewEnvelopeEvent, 3)
+	sub := pool.SubscribeEnvelopes(events)
+	defer sub.Unsubscribe()
+
+	var (
+		now    = time.Unix(1000, 0)
+		first  = envelope(t, key, 0, []byte{1})
+		second = envelope(t, key, 1, []byte{2})
+		third  = envelope(t, key, 2, []byte{3})
+	)
+	require.NoError(pool.Add(first, true, now))
+	require.NoError(pool.Add(second, false, now))
+	require.NoError(pool.Add(third, false, now.Add(30*time.Second)))
+	require.Equal(NewEnvelopeEvent{Tx: first, Local: true}, <-events)
+	require.Equal(NewEnvelopeEvent{Tx: second}, <-events)
+	require.Equal(NewEnvelopeEvent{Tx: third}, <-events)
+
+	// Envelopes are returned in arrival order, up to the per-block limit of the
+	// chain config, and stay in the pool until their block is accepted.
+	require.Equal([]*types.Transaction{first, second}, pool.Pending(now))
+	require.Equal([]*types.Transaction{first, second}, pool.Pending(now))
+
+	chain.acceptedFeed.Send(core.ChainEvent{
+		Block: types.NewBlock(chain.CurrentBlock(), []*types.Transaction{first}, nil, nil, trie.NewStackTrie(nil)),
+	})
+	require.Eventually(func() bool {
+		return !pool.Has(first.Hash())
+	}, time.Second, 10*time.Millisecond)
+	require.Equal([]*types.Transaction{second, third}, pool.Pending(now))
+
+	// Expired envelopes are dropped.
+	require.Equal([]*types.Transaction{third}, pool.Pending(now.Add(time.Minute+time.Second)))
+	require.Equal(1, pool.Len())
+}
+
+func TestLocalKey(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewLocalKey(make([]byte, LocalKeySize-1))
+	require.ErrorIs(err, ErrInvalidLocalKey)
+
+	key, err := NewLocalKey(make([]byte, LocalKeySize))
+	require.NoError(err)
+	otherKey, err := NewLocalKey(common.Hash{1}.Bytes())
+	require.NoError(err)
+
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, To: &common.Address{}, Value: big.NewInt(1)})
+	ciphertext, err := key.Seal(tx)
+	require.NoError(err)
+	otherCiphertext, err := otherKey.Seal(tx)
+	require.NoError(err)
+	require.NotEqual(ciphertext, otherCiphertext)
+
+	ciphertexts := [][]byte{ciphertext, otherCiphertext, {1}}
+	txs, err := key.Decrypt(1, ciphertexts)
+	require.NoError(err)
+	require.Len(txs, len(ciphertexts))
+	require.Equal(tx.Hash(), txs[0].Hash())
+	require.Nil(txs[1])
+	require.Nil(txs[2])
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encryptedpool
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/libevm/core/types"
+
+	"github.com/ava-labs/subnet-evm/core"
+)
+
+// LocalKeySize is the size of the key of a [LocalKey], in bytes.
+const LocalKeySize = 32
+
+var _ core.EnvelopeDecrypter = (*LocalKey)(nil)
+
+var ErrInvalidLocalKey = errors.New("invalid local key")
+
+// LocalKey seals and decrypts the ciphertexts of envelopes with a single AES-GCM
+// key shared by the nodes. It stands in for the threshold key of the validator
+// set in tests and local networks, and provides no protection against the
+// holders of the key.
+type LocalKey struct {
+	aead cipher.AEAD
+}
+
+// NewLocalKey returns a [LocalKey] from [LocalKeySize] bytes.
+func NewLocalKey(key []byte) (*LocalKey, error) {
+	if len(key) != LocalKeySize {
+		return nil, fmt.Errorf("%w: %d bytes, expected %d", ErrInvalidLocalKey, len(key), LocalKeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLocalKey, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLocalKey, err)
+	}
+	return &LocalKey{aead: aead}, nil
+}
+
+// Seal encrypts [tx] into the ciphertext of an envelope.
+func (k *LocalKey) Seal(tx *types.Transaction) ([]byte, error) {
+	plaintext, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return k.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens each of [ciphertexts] with the key.
+func (k *LocalKey) Decrypt(_ uint64, ciphertexts [][]byte) ([]*types.Transaction, error) {
+	txs := make([]*types.Transaction, len(ciphertexts))
+	for i, ciphertext := range ciphertexts {
+		txs[i] = k.open(ciphertext)
+	}
+	return txs, nil
+}
+
+// open returns the transaction sealed in [sealed], or nil if it cannot be
+// decrypted.
+func (k *LocalKey) open(sealed []byte) *types.Transaction {
+	nonceSize := k.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil
+	}
+	nonce, ciphertext := sealed[:nonceSize], sealed[nonceSize:]
+	plaintext, err := k.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(plaintext); err != nil {
+		return nil
+	}
+	return tx
+}
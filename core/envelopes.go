@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/vms/evm/predicate"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+
+	"github.com/ava-labs/subnet-evm/params"
+)
+
+// EnvelopeAddress is the recipient of the envelope transactions, whose data is
+// the ciphertext of a transaction encrypted to the key held by the validator
+// set. Envelope transactions pay for their gas like any other transaction,
+// whether their ciphertext can be decrypted or not.
+var EnvelopeAddress = common.HexToAddress("0x0400000000000000000000000000000000000000")
+
+var (
+	ErrEnvelopesNotDecrypted = errors.New("envelopes not decrypted")
+	ErrTooManyEnvelopes      = errors.New("too many envelopes in block")
+	ErrInvalidEnvelope       = errors.New("invalid envelope")
+)
+
+// EnvelopeDecrypter decrypts the envelopes included in accepted blocks.
+type EnvelopeDecrypter interface {
+	// Decrypt returns the transactions sealed in [ciphertexts], the data of the
+	// envelope transactions included in the accepted block with the given number,
+	// in block order. The transaction of a ciphertext that cannot be decrypted
+	// is nil. An error means that none of them can be decrypted yet.
+	Decrypt(number uint64, ciphertexts [][]byte) ([]*types.Transaction, error)
+}
+
+// IsEnvelope returns whether [tx] is an envelope transaction.
+func IsEnvelope(tx *types.Transaction) bool {
+	to := tx.To()
+	return to != nil && *to == EnvelopeAddress
+}
+
+// HasEnvelopes returns whether [block] includes envelope transactions.
+func HasEnvelopes(block *types.Block) bool {
+	for _, tx := range block.Transactions() {
+		if IsEnvelope(tx) {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyEnvelopes checks the envelope transactions of [block] against the
+// envelope config of the chain, once enabled. Before, transactions sent to the
+// envelope address are not treated differently.
+func VerifyEnvelopes(config *params.ChainConfig, block *types.Block) error {
+	configExtra := params.GetExtra(config)
+	if !configExtra.IsEnvelopeEnabled(block.Time()) {
+		return nil
+	}
+	var (
+		envelopeConfig = configExtra.EnvelopeConfig
+		envelopes      uint64
+	)
+	for i, tx := range block.Transactions() {
+		if !IsEnvelope(tx) {
+			continue
+		}
+		envelopes++
+		switch size := uint64(len(tx.Data())); {
+		case tx.Value().Sign() != 0:
+			return fmt.Errorf("%w: tx %d transfers value", ErrInvalidEnvelope, i)
+		case size == 0:
+			return fmt.Errorf("%w: tx %d has no ciphertext", ErrInvalidEnvelope, i)
+		case size > envelopeConfig.MaxCiphertextSize:
+			return fmt.Errorf("%w: tx %d ciphertext size %d > %d", ErrInvalidEnvelope, i, size, envelopeConfig.MaxCiphertextSize)
+		}
+	}
+	if envelopes > envelopeConfig.MaxEnvelopesPerBlock {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyEnvelopes, envelopes, envelopeConfig.MaxEnvelopesPerBlock)
+	}
+	return nil
+}
+
+// DecryptEnvelopes decrypts the envelopes committed in the accepted [block] with
+// [decrypter], and returns the transactions sealed in them in block order. The
+// envelopes that cannot be decrypted are dropped, along with the transactions
+// that cannot be included in a block built on top of [block] as is: blob
+// transactions, whose sidecars are not sealed, and transactions with
+// predicates, which are verified against the context of the block builder.
+func DecryptEnvelopes(config *params.ChainConfig, decrypter EnvelopeDecrypter, block *types.Block) (types.Transactions, error) {
+	var ciphertexts [][]byte
+	for _, tx := range block.Transactions() {
+		if IsEnvelope(tx) {
+			ciphertexts = append(ciphertexts, tx.Data())
+		}
+	}
+	if len(ciphertexts) == 0 {
+		return nil, nil
+	}
+	decrypted, err := decrypter.Decrypt(block.NumberU64(), ciphertexts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEnvelopesNotDecrypted, err)
+	}
+	if len(decrypted) != len(ciphertexts) {
+		return nil, fmt.Errorf("%w: decrypted %d envelopes, expected %d", ErrEnvelopesNotDecrypted, len(decrypted), len(ciphertexts))
+	}
+	rulesExtra := params.GetRulesExtra(config.Rules(block.Number(), params.IsMergeTODO, block.Time()))
+	var txs types.Transactions
+	for _, tx := range decrypted {
+		if tx == nil || tx.Type() == types.BlobTxType || len(predicate.FromAccessList(rulesExtra, tx.AccessList())) > 0 {
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
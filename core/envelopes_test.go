@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+)
+
+type testDecrypter struct {
+	txs []*types.Transaction
+	err error
+}
+
+func (d *testDecrypter) Decrypt(uint64, [][]byte) ([]*types.Transaction, error) {
+	return d.txs, d.err
+}
+
+func envelopeChainConfig(t *testing.T) *params.ChainConfig {
+	t.Helper()
+	config := params.Copy(params.TestHeliconChainConfig)
+	envelopeConfig := extras.DefaultEnvelopeConfig
+	envelopeConfig.MaxEnvelopesPerBlock = 2
+	envelopeConfig.MaxCiphertextSize = 4
+	params.GetExtra(&config).EnvelopeConfig = &envelopeConfig
+	return &config
+}
+
+func newEnvelope(nonce uint64, value int64, ciphertext []byte) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{
+		Nonce: nonce,
+		To:    &EnvelopeAddress,
+		Value: big.NewInt(value),
+		Data:  ciphertext,
+	})
+}
+
+func blockWithTxs(txs ...*types.Transaction) *types.Block {
+	return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}).WithBody(types.Body{Transactions: txs})
+}
+
+func TestVerifyEnvelopes(t *testing.T) {
+	other := types.NewTx(&types.LegacyTx{To: &common.Address{}, Value: big.NewInt(1)})
+	tests := []struct {
+		name        string
+		config      *params.ChainConfig
+		txs         []*types.Transaction
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			config: envelopeChainConfig(t),
+			txs:    []*types.Transaction{other, newEnvelope(0, 0, []byte{1}), newEnvelope(1, 0, []byte{1, 2, 3, 4})},
+		},
+		{
+			name:        "value",
+			config:      envelopeChainConfig(t),
+			txs:         []*types.Transaction{newEnvelope(0, 1, []byte{1})},
+			expectedErr: ErrInvalidEnvelope,
+		},
+		{
+			name:        "empty_ciphertext",
+			config:      envelopeChainConfig(t),
+			txs:         []*types.Transaction{newEnvelope(0, 0, nil)},
+			expectedErr: ErrInvalidEnvelope,
+		},
+		{
+			name:        "ciphertext_too_large",
+			config:      envelopeChainConfig(t),
+			txs:         []*types.Transaction{newEnvelope(0, 0, []byte{1, 2, 3, 4, 5})},
+			expectedErr: ErrInvalidEnvelope,
+		},
+		{
+			name:        "too_many_envelopes",
+			config:      envelopeChainConfig(t),
+			txs:         []*types.Transaction{newEnvelope(0, 0, []byte{1}), newEnvelope(1, 0, []byte{1}), newEnvelope(2, 0, []byte{1})},
+			expectedErr: ErrTooManyEnvelopes,
+		},
+		{
+			name:   "not_enabled",
+			config: params.TestHeliconChainConfig,
+			txs:    []*types.Transaction{newEnvelope(0, 1, nil)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyEnvelopes(test.config, blockWithTxs(test.txs...))
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestDecryptEnvelopes(t *testing.T) {
+	var (
+		config    = envelopeChainConfig(t)
+		envelopes = []*types.Transaction{newEnvelope(0, 0, []byte{1}), newEnvelope(1, 0, []byte{2})}
+		sealed    = types.NewTx(&types.LegacyTx{To: &common.Address{}})
+		blobTx    = types.NewTx(&types.BlobTx{})
+	)
+	tests := []struct {
+		name        string
+		decrypter   *testDecrypter
+		want        types.Transactions
+		expectedErr error
+	}{
+		{
+			name:      "decrypted",
+			decrypter: &testDecrypter{txs: []*types.Transaction{nil, sealed}},
+			want:      types.Transactions{sealed},
+		},
+		{
+			name:      "blob_tx_dropped",
+			decrypter: &testDecrypter{txs: []*types.Transaction{blobTx, sealed}},
+			want:      types.Transactions{sealed},
+		},
+		{
+			name:        "not_decrypted",
+			decrypter:   &testDecrypter{err: errors.New("key not available")},
+			expectedErr: ErrEnvelopesNotDecrypted,
+		},
+		{
+			name:        "missing_txs",
+			decrypter:   &testDecrypter{txs: []*types.Transaction{sealed}},
+			expectedErr: ErrEnvelopesNotDecrypted,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := DecryptEnvelopes(config, test.decrypter, blockWithTxs(envelopes...))
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
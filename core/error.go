@@ -116,4 +116,12 @@ var (
 
 	// ErrBlobTxCreate is returned if a blob transaction has no explicit to field.
 	ErrBlobTxCreate = errors.New("blob transaction of type create")
+
+	// ErrFeeTokenNotApproved is returned if a transaction opts in to paying its
+	// fees in a token that is not approved by the fee token precompile.
+	ErrFeeTokenNotApproved = errors.New("fee token not approved")
+
+	// ErrInsufficientFeeToken is returned if the sender of a transaction paying its
+	// fees in a token cannot cover them with its balance or allowance of the token.
+	ErrInsufficientFeeToken = errors.New("insufficient fee token balance or allowance for gas * price")
 )
@@ -32,7 +32,6 @@ import (
 	"math/big"
 
 	"github.com/ava-labs/libevm/common"
-	"github.com/ava-labs/libevm/consensus/misc/eip4844"
 	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/libevm/core/vm"
@@ -108,8 +107,21 @@ type ChainContext interface {
 
 	// GetHeader returns the header corresponding to the hash/number argument pair.
 	GetHeader(common.Hash, uint64) *types.Header
+
+	// Config returns the chain's configuration, which prices the blob gas.
+	Config() *params.ChainConfig
+}
+
+// configChainContext is a [ChainContext] providing only the chain config, for
+// the EVM calls that neither look up block hashes nor derive the block author.
+type configChainContext struct {
+	config *params.ChainConfig
 }
 
+func (*configChainContext) Engine() consensus.Engine                    { return nil }
+func (*configChainContext) GetHeader(common.Hash, uint64) *types.Header { return nil }
+func (c *configChainContext) Config() *params.ChainConfig               { return c.config }
+
 // NewEVMBlockContext creates a new context for use in the EVM.
 func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common.Address) vm.BlockContext {
 	predicateBytes := customheader.PredicateBytesFromExtra(header.Extra)
@@ -162,7 +174,7 @@ func newEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		baseFee = new(big.Int).Set(header.BaseFee)
 	}
 	if header.ExcessBlobGas != nil {
-		blobBaseFee = eip4844.CalcBlobFee(*header.ExcessBlobGas)
+		blobBaseFee = customheader.BlobBaseFee(params.GetExtra(chain.Config()), header)
 	}
 	return vm.BlockContext{
 		CanTransfer: CanTransfer,
@@ -0,0 +1,169 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/ethdb"
+	"github.com/ava-labs/libevm/log"
+
+	"github.com/ava-labs/subnet-evm/consensus"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+)
+
+var ErrFeeConfigHistoryUnavailable = errors.New("fee config history unavailable")
+
+// initFeeConfigHistory records the first block indexed in the fee config history if
+// the index has not been initialized yet. Blocks accepted before the index existed are
+// not indexed, since indexing them would require their parent states.
+// If the index is disabled, its tail is removed so that the history is not served with
+// gaps and indexing starts from the next accepted block once it is enabled again.
+func (bc *BlockChain) initFeeConfigHistory() error {
+	if !bc.cacheConfig.FeeConfigHistory {
+		return customrawdb.DeleteFeeConfigHistoryTail(bc.db)
+	}
+	if _, ok, err := customrawdb.ReadFeeConfigHistoryTail(bc.db); err != nil || ok {
+		return err
+	}
+	var tail uint64
+	if lastAccepted := bc.lastAccepted.NumberU64(); lastAccepted > 0 {
+		tail = lastAccepted + 1
+	}
+	return customrawdb.WriteFeeConfigHistoryTail(bc.db, tail)
+}
+
+// batchFeeConfigChanges adds the fee config changes made in the accepted block [b] to [batch]
+// if the fee config history is enabled. The history is only served over RPC, so failing to
+// index a block is logged rather than failing the acceptance of the block.
+func (bc *BlockChain) batchFeeConfigChanges(batch ethdb.Batch, b *types.Block) {
+	if !bc.cacheConfig.FeeConfigHistory {
+		return
+	}
+	changes, err := bc.feeConfigChanges(b)
+	if err == nil && len(changes) > 0 {
+		err = customrawdb.WriteFeeConfigChanges(batch, b.NumberU64(), changes)
+	}
+	if err != nil {
+		log.Error("Failed to index fee config changes", "number", b.NumberU64(), "hash", b.Hash(), "err", err)
+	}
+}
+
+// feeConfigChanges returns the fee config changes made in [b]. A network upgrade
+// activating or deactivating the fee manager takes effect before the transactions
+// of the block, so it is reported before the changes made by transactions.
+//
+// Outside of the blocks activating a fee manager upgrade, the fee config only changes
+// through the fee manager, so the changes are read from its logs without reading state.
+func (bc *BlockChain) feeConfigChanges(b *types.Block) ([]customrawdb.FeeConfigChange, error) {
+	parent := bc.GetHeader(b.ParentHash(), b.NumberU64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	configExtra := params.GetExtra(bc.chainConfig)
+
+	var (
+		txChanges []customrawdb.FeeConfigChange
+		err       error
+	)
+	if configExtra.IsPrecompileEnabled(feemanager.ContractAddress, b.Time()) {
+		txChanges, err = bc.txFeeConfigChanges(b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	parentTime := parent.Time
+	if len(configExtra.GetActivatingPrecompileConfigs(feemanager.ContractAddress, &parentTime, b.Time(), configExtra.PrecompileUpgrades)) == 0 {
+		return txChanges, nil
+	}
+
+	oldConfig, _, err := bc.GetFeeConfigAt(parent)
+	if err != nil {
+		return nil, err
+	}
+	newConfig, _, err := bc.GetFeeConfigAt(b.Header())
+	if err != nil {
+		return nil, err
+	}
+	// The fee config the transactions started from is the one set by the upgrade, if any.
+	txStartConfig := newConfig
+	if len(txChanges) > 0 {
+		txStartConfig = txChanges[0].OldFeeConfig
+	}
+	var changes []customrawdb.FeeConfigChange
+	if !oldConfig.Equal(&txStartConfig) {
+		changes = append(changes, customrawdb.FeeConfigChange{
+			BlockNumber:  b.NumberU64(),
+			OldFeeConfig: oldConfig,
+			NewFeeConfig: txStartConfig,
+		})
+	}
+	return append(changes, txChanges...), nil
+}
+
+// txFeeConfigChanges returns the fee config changes made by the transactions of [b],
+// as recorded in the FeeConfigChanged events emitted by the fee manager.
+func (bc *BlockChain) txFeeConfigChanges(b *types.Block) ([]customrawdb.FeeConfigChange, error) {
+	receipts := bc.GetReceiptsByHash(b.Hash())
+	if receipts == nil && len(b.Transactions()) > 0 {
+		return nil, fmt.Errorf("missing receipts for block %s", b.Hash())
+	}
+	eventID := feemanager.FeeManagerABI.Events["FeeConfigChanged"].ID
+
+	var changes []customrawdb.FeeConfigChange
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			if log.Address != feemanager.ContractAddress || len(log.Topics) != 2 || log.Topics[0] != eventID {
+				continue
+			}
+			oldConfig, newConfig, err := feemanager.UnpackFeeConfigChangedEventData(log.Data)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, customrawdb.FeeConfigChange{
+				BlockNumber:  b.NumberU64(),
+				TxHash:       receipt.TxHash,
+				Sender:       common.BytesToAddress(log.Topics[1].Bytes()),
+				OldFeeConfig: oldConfig,
+				NewFeeConfig: newConfig,
+			})
+		}
+	}
+	return changes, nil
+}
+
+// GetFeeConfigHistory returns the fee config changes made in the accepted blocks
+// between [from] and [to] inclusive. Returns [ErrFeeConfigHistoryUnavailable] if
+// the history is not indexed or the range starts before the first block indexed by this node.
+func (bc *BlockChain) GetFeeConfigHistory(from, to uint64) ([]customrawdb.FeeConfigChange, error) {
+	tail, ok, err := customrawdb.ReadFeeConfigHistoryTail(bc.db)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: history is not indexed by this node", ErrFeeConfigHistoryUnavailable)
+	}
+	if from < tail {
+		return nil, fmt.Errorf("%w: history is indexed from block %d", ErrFeeConfigHistoryUnavailable, tail)
+	}
+	if lastAccepted := bc.LastAcceptedBlock().NumberU64(); to > lastAccepted {
+		to = lastAccepted
+	}
+	if from > to {
+		return []customrawdb.FeeConfigChange{}, nil
+	}
+	changes, err := customrawdb.ReadFeeConfigChanges(bc.db, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if changes == nil {
+		changes = []customrawdb.FeeConfigChange{}
+	}
+	return changes, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+func TestFeeConfigHistory(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		chainDB = rawdb.NewMemoryDatabase()
+	)
+
+	// Only the block gas cost parameters are changed, so that the generated blocks
+	// remain valid. The block gas cost is zero since Granite.
+	initialFeeConfig := params.DefaultFeeConfig
+	initialFeeConfig.MaxBlockGasCost = big.NewInt(2_000_000)
+	txFeeConfig := params.DefaultFeeConfig
+	txFeeConfig.MaxBlockGasCost = big.NewInt(3_000_000)
+
+	// Blocks are 10 seconds apart: the fee manager activates in block 2 and deactivates in block 5.
+	config := params.Copy(params.TestChainConfig)
+	params.GetExtra(&config).UpgradeConfig.PrecompileUpgrades = []extras.PrecompileUpgrade{
+		{Config: feemanager.NewConfig(utils.NewUint64(20), []common.Address{addr}, nil, nil, &initialFeeConfig)},
+		{Config: feemanager.NewDisableConfig(utils.NewUint64(50))},
+	}
+	gspec := &Genesis{
+		Config: &config,
+		Alloc:  types.GenesisAlloc{addr: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))}},
+	}
+
+	cacheConfig := *DefaultCacheConfig
+	cacheConfig.FeeConfigHistory = true
+	blockchain, err := createBlockChain(chainDB, &cacheConfig, gspec, common.Hash{})
+	require.NoError(t, err)
+	defer blockchain.Stop()
+
+	signer := types.LatestSigner(&config)
+	var setFeeConfigTx *types.Transaction
+	_, chain, _, err := GenerateChainWithGenesis(gspec, blockchain.engine, 6, 10, func(i int, gen *BlockGen) {
+		if i != 2 {
+			return
+		}
+		input, err := feemanager.PackSetFeeConfig(txFeeConfig)
+		require.NoError(t, err)
+		setFeeConfigTx, err = types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     gen.TxNonce(addr),
+			To:        &feemanager.ContractAddress,
+			Gas:       1_000_000,
+			GasFeeCap: new(big.Int).Mul(gen.BaseFee(), big.NewInt(2)),
+			Data:      input,
+		})
+		require.NoError(t, err)
+		gen.AddTx(setFeeConfigTx)
+	})
+	require.NoError(t, err)
+
+	_, err = blockchain.InsertChain(chain)
+	require.NoError(t, err)
+	for _, block := range chain {
+		require.NoError(t, blockchain.Accept(block))
+	}
+	blockchain.DrainAcceptorQueue()
+
+	history, err := blockchain.GetFeeConfigHistory(0, 100)
+	require.NoError(t, err)
+	require.Equal(t, []customrawdb.FeeConfigChange{
+		{
+			BlockNumber:  2,
+			OldFeeConfig: params.DefaultFeeConfig,
+			NewFeeConfig: initialFeeConfig,
+		},
+		{
+			BlockNumber:  3,
+			TxHash:       setFeeConfigTx.Hash(),
+			Sender:       addr,
+			OldFeeConfig: initialFeeConfig,
+			NewFeeConfig: txFeeConfig,
+		},
+		{
+			BlockNumber:  5,
+			OldFeeConfig: txFeeConfig,
+			NewFeeConfig: params.DefaultFeeConfig,
+		},
+	}, history)
+
+	history, err = blockchain.GetFeeConfigHistory(4, 4)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	// Restarting the chain must not move the start of the history.
+	lastAcceptedHash := blockchain.LastConsensusAcceptedBlock().Hash()
+	blockchain.Stop()
+	blockchain, err = createBlockChain(chainDB, &cacheConfig, gspec, lastAcceptedHash)
+	require.NoError(t, err)
+	history, err = blockchain.GetFeeConfigHistory(3, 3)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+
+	// Restarting the chain with the index disabled drops the history.
+	blockchain.Stop()
+	blockchain, err = createBlockChain(chainDB, DefaultCacheConfig, gspec, lastAcceptedHash)
+	require.NoError(t, err)
+	_, err = blockchain.GetFeeConfigHistory(3, 3)
+	require.ErrorIs(t, err, ErrFeeConfigHistoryUnavailable)
+
+	// Enabling it again indexes the blocks accepted from then on only.
+	blockchain.Stop()
+	blockchain, err = createBlockChain(chainDB, &cacheConfig, gspec, lastAcceptedHash)
+	require.NoError(t, err)
+	defer blockchain.Stop()
+	_, err = blockchain.GetFeeConfigHistory(3, 3)
+	require.ErrorIs(t, err, ErrFeeConfigHistoryUnavailable)
+	history, err = blockchain.GetFeeConfigHistory(7, 7)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+}
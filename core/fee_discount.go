@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	cmath "github.com/ava-labs/libevm/common/math"
+	"github.com/ava-labs/libevm/core/types"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+)
+
+// FeePercentage returns the percentage of the base fee charged to transactions sent
+// by [sender] in a block at [time], as set in the fee discount table of the fee
+// manager precompile in [state].
+//
+// Fee discounts can only be set after Helicon. The block gas cost is zero since Granite,
+// so discounted transactions never have to contribute to a block fee.
+func FeePercentage(config *params.ChainConfig, state contract.StateReader, sender common.Address, time uint64) uint64 {
+	configExtra := params.GetExtra(config)
+	if !configExtra.IsHelicon(time) || !configExtra.IsPrecompileEnabled(feemanager.ContractAddress, time) {
+		return feemanager.MaxFeePercentage
+	}
+	return feemanager.GetFeePercentage(state, sender)
+}
+
+// SenderBaseFee returns [baseFee] discounted to the percentage charged to
+// transactions sent by [sender] in a block at [time].
+func SenderBaseFee(config *params.ChainConfig, state contract.StateReader, sender common.Address, baseFee *big.Int, time uint64) *big.Int {
+	if baseFee == nil {
+		return nil
+	}
+	return feemanager.DiscountBaseFee(baseFee, FeePercentage(config, state, sender, time))
+}
+
+// discountedGasPrices returns the effective gas prices of the transactions of [block]
+// that were charged a discounted base fee, as recorded in their [receipts].
+// Receipts read from the database derive the effective gas price from the base fee of
+// the block, so these prices are stored next to the receipts to override it.
+func discountedGasPrices(block *types.Block, receipts types.Receipts) []customrawdb.DiscountedGasPrice {
+	baseFee := block.BaseFee()
+	if baseFee == nil {
+		return nil
+	}
+	var prices []customrawdb.DiscountedGasPrice
+	for i, tx := range block.Transactions() {
+		if i >= len(receipts) || receipts[i].EffectiveGasPrice == nil {
+			break
+		}
+		price := cmath.BigMin(new(big.Int).Add(tx.GasTipCap(), baseFee), tx.GasFeeCap())
+		if receipts[i].EffectiveGasPrice.Cmp(price) != 0 {
+			prices = append(prices, customrawdb.DiscountedGasPrice{
+				TxIndex:  uint64(i),
+				GasPrice: receipts[i].EffectiveGasPrice,
+			})
+		}
+	}
+	return prices
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+// TestFeeDiscountGasPrice checks that a sender with a fee discount sees the discounted
+// gas price from the GASPRICE opcode and in the effective gas price of its receipt.
+func TestFeeDiscountGasPrice(t *testing.T) {
+	var (
+		adminKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		admin        = crypto.PubkeyToAddress(adminKey.PublicKey)
+		senderKey, _ = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		sender       = crypto.PubkeyToAddress(senderKey.PublicKey)
+		// recorder stores the result of GASPRICE in slot 0.
+		recorder      = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		chainDB       = rawdb.NewMemoryDatabase()
+		funds         = new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+		feePercentage = uint64(50)
+	)
+
+	config := params.Copy(params.TestHeliconChainConfig)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		feemanager.ConfigKey: feemanager.NewConfig(utils.NewUint64(0), []common.Address{admin}, nil, nil, nil),
+	}
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			admin:    {Balance: funds},
+			sender:   {Balance: funds},
+			recorder: {Code: []byte{0x3a, 0x60, 0x00, 0x55, 0x00}}, // GASPRICE PUSH1 0 SSTORE STOP
+		},
+	}
+
+	blockchain, err := createBlockChain(chainDB, DefaultCacheConfig, gspec, common.Hash{})
+	require.NoError(t, err)
+	defer blockchain.Stop()
+
+	signer := types.LatestSigner(&config)
+	newTx := func(key *ecdsa.PrivateKey, gen *BlockGen, to common.Address, data []byte) *types.Transaction {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     gen.TxNonce(crypto.PubkeyToAddress(key.PublicKey)),
+			To:        &to,
+			Gas:       100_000,
+			GasFeeCap: new(big.Int).Mul(gen.BaseFee(), big.NewInt(2)),
+			GasTipCap: common.Big0,
+			Data:      data,
+		})
+		require.NoError(t, err)
+		return tx
+	}
+	setFeeDiscount, err := feemanager.PackSetFeeDiscount(sender, feePercentage)
+	require.NoError(t, err)
+
+	var wantGasPrice *big.Int
+	_, chain, _, err := GenerateChainWithGenesis(gspec, blockchain.engine, 2, 10, func(i int, gen *BlockGen) {
+		switch i {
+		case 0:
+			gen.AddTx(newTx(adminKey, gen, feemanager.ContractAddress, setFeeDiscount))
+		case 1:
+			wantGasPrice = feemanager.DiscountBaseFee(gen.BaseFee(), feePercentage)
+			gen.AddTx(newTx(senderKey, gen, recorder, nil))
+		}
+	})
+	require.NoError(t, err)
+	_, err = blockchain.InsertChain(chain)
+	require.NoError(t, err)
+
+	statedb, err := blockchain.StateAt(chain[1].Root())
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(wantGasPrice), statedb.GetState(recorder, common.Hash{}))
+
+	// The setter is charged the full base fee, the discounted sender only its share.
+	receipts := blockchain.GetReceiptsByHash(chain[0].Hash())
+	require.Len(t, receipts, 1)
+	require.Equal(t, chain[0].BaseFee(), receipts[0].EffectiveGasPrice)
+	receipts = blockchain.GetReceiptsByHash(chain[1].Hash())
+	require.Len(t, receipts, 1)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipts[0].Status)
+	require.Equal(t, wantGasPrice, receipts[0].EffectiveGasPrice)
+	require.Less(t, wantGasPrice.Cmp(chain[1].BaseFee()), 0)
+}
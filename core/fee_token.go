@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	cmath "github.com/ava-labs/libevm/common/math"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/ava-labs/libevm/log"
+	"github.com/holiman/uint256"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feetoken"
+)
+
+// FeeTokenOf returns the fee token a transaction with [accessList] pays its fees
+// in if it is included in a block at [time], or false if it pays in native coin.
+func FeeTokenOf(config *params.ChainConfig, accessList types.AccessList, time uint64) (common.Address, bool) {
+	if !params.GetExtra(config).IsPrecompileEnabled(feetoken.ContractAddress, time) {
+		return common.Address{}, false
+	}
+	return feetoken.FeeTokenFromAccessList(accessList)
+}
+
+// TxNativeCost returns the amount of native coin [tx] can cost its sender. It is
+// [types.Transaction.Cost] without the gas fees if [tx] pays them in a fee token.
+func TxNativeCost(tx *types.Transaction) *big.Int {
+	cost := tx.Cost()
+	if _, gasFee, ok := TxFeeTokenCost(tx); ok {
+		cost.Sub(cost, gasFee)
+	}
+	return cost
+}
+
+// TxFeeTokenCost returns the fee token [tx] pays its gas fees in and the amount of
+// gas fees in wei it can cost its sender, or false if it pays them in native coin.
+func TxFeeTokenCost(tx *types.Transaction) (common.Address, *big.Int, bool) {
+	token, ok := feetoken.FeeTokenFromAccessList(tx.AccessList())
+	if !ok {
+		return common.Address{}, nil, false
+	}
+	return token, new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(tx.Gas())), true
+}
+
+// FeeTokenSpendable returns the amount of [token] the fee token precompile can
+// escrow from [owner], which is the lesser of the balance of [owner] and its
+// allowance to the precompile, by calling the token contract in [statedb] on top
+// of [header]. [statedb] is modified by the calls, so callers should pass a copy.
+func FeeTokenSpendable(config *params.ChainConfig, header *types.Header, statedb vm.StateDB, token common.Address, owner common.Address) (*big.Int, error) {
+	blockContext := NewEVMBlockContext(header, &configChainContext{config: config}, &header.Coinbase)
+	// There is no chain to look up block hashes from, which balance and allowance
+	// lookups do not need.
+	blockContext.GetHash = func(uint64) common.Hash { return common.Hash{} }
+	evm := vm.NewEVM(blockContext, vm.TxContext{GasPrice: new(big.Int)}, statedb, config, vm.Config{NoBaseFee: true})
+
+	balanceInput, err := feetoken.PackBalanceOf(owner)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := staticCallFeeToken(evm, token, balanceInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fee token balance: %w", err)
+	}
+	allowanceInput, err := feetoken.PackAllowance(owner, feetoken.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := staticCallFeeToken(evm, token, allowanceInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fee token allowance: %w", err)
+	}
+	return cmath.BigMin(balance, allowance), nil
+}
+
+func staticCallFeeToken(evm *vm.EVM, token common.Address, input []byte) (*big.Int, error) {
+	ret, _, err := evm.StaticCall(vm.AccountRef(feetoken.ContractAddress), token, input, feetoken.TokenCallGasLimit)
+	if err != nil {
+		return nil, err
+	}
+	return feetoken.UnpackAmount(ret)
+}
+
+// feeTokenPayment is the payment of the gas fees of a transaction in a fee token
+// approved by the fee token precompile. The precompile escrows the token amount
+// worth the gas limit before execution, and settles it afterwards the same way
+// native gas fees are refunded and paid to the coinbase.
+type feeTokenPayment struct {
+	token    common.Address
+	rate     *big.Int
+	escrowed *big.Int
+}
+
+// newFeeTokenPayment returns the fee token payment of the message of [st], or nil
+// if it pays its gas fees in native coin.
+func (st *StateTransition) newFeeTokenPayment() (*feeTokenPayment, error) {
+	token, ok := FeeTokenOf(st.evm.ChainConfig(), st.msg.AccessList, st.evm.Context.Time)
+	if !ok {
+		return nil, nil
+	}
+	rate := feetoken.GetFeeTokenRate(st.state, token)
+	if rate.Sign() == 0 {
+		return nil, fmt.Errorf("%w: address %v, token %v", ErrFeeTokenNotApproved, st.msg.From.Hex(), token.Hex())
+	}
+	return &feeTokenPayment{token: token, rate: rate}, nil
+}
+
+// escrow transfers the token amount worth [fee] wei from the sender to the precompile.
+func (p *feeTokenPayment) escrow(st *StateTransition, fee *big.Int) error {
+	p.escrowed = feetoken.TokenAmount(fee, p.rate)
+	if p.escrowed.Sign() == 0 {
+		return nil
+	}
+	input, err := feetoken.PackTransferFrom(st.msg.From, feetoken.ContractAddress, p.escrowed)
+	if err != nil {
+		return err
+	}
+	if !st.callFeeToken(p.token, input) {
+		return fmt.Errorf("%w: address %v, token %v, want %v", ErrInsufficientFeeToken, st.msg.From.Hex(), p.token.Hex(), p.escrowed)
+	}
+	return nil
+}
+
+// settle pays the token amount worth [fee] wei to the coinbase and refunds the
+// rest of the escrow to the sender. The transaction has already been executed, so
+// a transfer the token contract rejects leaves the tokens with the precompile.
+func (p *feeTokenPayment) settle(st *StateTransition, fee *big.Int) {
+	paid := cmath.BigMin(feetoken.TokenAmount(fee, p.rate), p.escrowed)
+	p.transfer(st, st.msg.From, new(big.Int).Sub(p.escrowed, paid))
+	p.transfer(st, st.evm.Context.Coinbase, paid)
+}
+
+func (p *feeTokenPayment) transfer(st *StateTransition, to common.Address, amount *big.Int) {
+	if amount.Sign() == 0 {
+		return
+	}
+	input, err := feetoken.PackTransfer(to, amount)
+	if err != nil || !st.callFeeToken(p.token, input) {
+		log.Debug("Fee token transfer failed", "token", p.token, "to", to, "amount", amount, "err", err)
+	}
+}
+
+// callFeeToken calls the fee token contract [token] from the precompile with [input]
+// and returns whether the call succeeded. The call is not traced, since it is not
+// part of the execution of the message.
+func (st *StateTransition) callFeeToken(token common.Address, input []byte) bool {
+	if st.state.GetCodeSize(token) == 0 {
+		return false
+	}
+	tracer := st.evm.Config.Tracer
+	st.evm.Config.Tracer = nil
+	defer func() { st.evm.Config.Tracer = tracer }()
+
+	ret, _, err := st.evm.Call(vm.AccountRef(feetoken.ContractAddress), token, input, feetoken.TokenCallGasLimit, new(uint256.Int))
+	return err == nil && feetoken.TransferSucceeded(ret)
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/common/math"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feetoken"
+	"github.com/ava-labs/subnet-evm/utils"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+// feeTokenCode logs its calldata and returns 1<<128, so that every transfer
+// succeeds and every account has a large balance and allowance.
+var feeTokenCode = hexutil.MustDecode("0x366000600037366000a070010000000000000000000000000000000060005260206000f3")
+
+func TestFeeToken(t *testing.T) {
+	var (
+		key, _     = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr       = crypto.PubkeyToAddress(key.PublicKey)
+		token      = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		unapproved = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		coinbase   = common.HexToAddress("0x2000000000000000000000000000000000000001")
+		rate       = new(big.Int).Mul(big.NewInt(2), feetoken.RateDenominator)
+	)
+
+	config := params.Copy(params.TestChainConfig)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		feetoken.ConfigKey: feetoken.NewConfig(utils.NewUint64(0), nil, nil, nil, map[common.Address]*math.HexOrDecimal256{
+			token: (*math.HexOrDecimal256)(rate),
+		}),
+	}
+	// The sender has no native balance, so the fees can only be paid in the token.
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			token:      {Code: feeTokenCode, Balance: common.Big0},
+			unapproved: {Code: feeTokenCode, Balance: common.Big0},
+		},
+	}
+	signer := types.LatestSigner(&config)
+	newTx := func(nonce uint64, feeToken common.Address, baseFee *big.Int) *types.Transaction {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     nonce,
+			To:        &common.Address{},
+			Gas:       400_000,
+			GasFeeCap: new(big.Int).Mul(baseFee, common.Big2),
+			GasTipCap: common.Big0,
+			AccessList: types.AccessList{{
+				Address:     feetoken.ContractAddress,
+				StorageKeys: []common.Hash{common.BytesToHash(feeToken.Bytes())},
+			}},
+		})
+		require.NoError(t, err)
+		return tx
+	}
+
+	var tx *types.Transaction
+	_, chain, receipts, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 1, 10, func(_ int, gen *BlockGen) {
+		gen.SetCoinbase(coinbase)
+		tx = newTx(0, token, gen.BaseFee())
+		gen.AddTx(tx)
+		require.Zero(t, gen.GetBalance(addr).Sign())
+	})
+	require.NoError(t, err)
+	require.Len(t, receipts[0], 1)
+	receipt := receipts[0][0]
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+
+	// The access list and the token calls are charged as intrinsic gas.
+	wantGas := ethparams.TxGas + ethparams.TxAccessListAddressGas + ethparams.TxAccessListStorageKeyGas + feetoken.FeeTokenIntrinsicGas
+	require.Equal(t, wantGas, receipt.GasUsed)
+
+	// The token records the escrow of the gas limit, the refund of the unused
+	// gas to the sender and the payment of the used gas to the coinbase.
+	price := chain[0].BaseFee()
+	escrowed := feetoken.TokenAmount(new(big.Int).Mul(price, new(big.Int).SetUint64(tx.Gas())), rate)
+	paid := feetoken.TokenAmount(new(big.Int).Mul(price, new(big.Int).SetUint64(receipt.GasUsed)), rate)
+	wantCalls := [][]byte{
+		mustPack(t)(feetoken.PackTransferFrom(addr, feetoken.ContractAddress, escrowed)),
+		mustPack(t)(feetoken.PackTransfer(addr, new(big.Int).Sub(escrowed, paid))),
+		mustPack(t)(feetoken.PackTransfer(coinbase, paid)),
+	}
+	require.Len(t, receipt.Logs, len(wantCalls))
+	for i, log := range receipt.Logs {
+		require.Equal(t, token, log.Address)
+		require.Equal(t, wantCalls[i], log.Data)
+	}
+
+	// Transactions opting in to an unapproved token are invalid.
+	blockchain, err := createBlockChain(rawdb.NewMemoryDatabase(), DefaultCacheConfig, gspec, common.Hash{})
+	require.NoError(t, err)
+	defer blockchain.Stop()
+	genesis := blockchain.Genesis()
+	block := GenerateBadBlock(genesis, blockchain.engine, types.Transactions{newTx(0, unapproved, genesis.BaseFee())}, &config)
+	_, err = blockchain.InsertChain(types.Blocks{block})
+	require.ErrorIs(t, err, ErrFeeTokenNotApproved)
+}
+
+func mustPack(t *testing.T) func([]byte, error) []byte {
+	return func(input []byte, err error) []byte {
+		require.NoError(t, err)
+		return input
+	}
+}
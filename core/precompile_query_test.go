@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/ava-labs/libevm/triedb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+const querierConfigKey = "querierConfig"
+
+// querierAddress is the address of a test precompile that returns the gas limit of the
+// fee config it reads from the fee manager precompile through a query.
+var querierAddress = common.HexToAddress("0x03000000000000000000000000000000000000fe")
+
+type querierConfig struct {
+	precompileconfig.Upgrade
+}
+
+func (*querierConfig) Key() string { return querierConfigKey }
+
+func (c *querierConfig) Equal(cfg precompileconfig.Config) bool {
+	other, ok := cfg.(*querierConfig)
+	return ok && c.Upgrade.Equal(&other.Upgrade)
+}
+
+func (*querierConfig) Verify(precompileconfig.ChainConfig) error { return nil }
+
+type querierConfigurator struct{}
+
+func (querierConfigurator) MakeConfig() precompileconfig.Config { return new(querierConfig) }
+
+func (querierConfigurator) Configure(precompileconfig.ChainConfig, precompileconfig.Config, contract.StateDB, contract.ConfigurationBlockContext) error {
+	return nil
+}
+
+func init() {
+	querier, err := contract.NewStatefulPrecompileContract(
+		func(accessibleState contract.AccessibleState, _ common.Address, _ common.Address, _ []byte, suppliedGas uint64, _ bool) ([]byte, uint64, error) {
+			feeConfig, remainingGas, err := feemanager.GetFeeConfigQuery.Call(accessibleState, struct{}{}, suppliedGas)
+			if err != nil {
+				return nil, remainingGas, err
+			}
+			return common.BigToHash(feeConfig.GasLimit).Bytes(), remainingGas, nil
+		},
+		nil,
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err := modules.RegisterModule(modules.Module{
+		ConfigKey:    querierConfigKey,
+		Address:      querierAddress,
+		Contract:     querier,
+		Configurator: querierConfigurator{},
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// TestQueryPrecompile calls a precompile that queries the fee manager precompile through the
+// [contract.AccessibleState] provided by the params precompile hooks.
+func TestQueryPrecompile(t *testing.T) {
+	feeConfig := params.DefaultFeeConfig
+	feeConfig.GasLimit = big.NewInt(12_345_678)
+
+	tests := []struct {
+		name        string
+		precompiles extras.Precompiles
+		wantErr     error
+	}{
+		{
+			name: "queried precompile active",
+			precompiles: extras.Precompiles{
+				querierConfigKey:     &querierConfig{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)}},
+				feemanager.ConfigKey: feemanager.NewConfig(utils.NewUint64(0), []common.Address{{1}}, nil, nil, &feeConfig),
+			},
+		},
+		{
+			name: "queried precompile not active",
+			precompiles: extras.Precompiles{
+				querierConfigKey: &querierConfig{Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)}},
+			},
+			wantErr: contract.ErrPrecompileNotActive,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := params.Copy(params.TestChainConfig)
+			params.GetExtra(&config).GenesisPrecompiles = test.precompiles
+			gspec := &Genesis{Config: &config}
+
+			db := rawdb.NewMemoryDatabase()
+			block := gspec.MustCommit(db, triedb.NewDatabase(db, nil))
+			statedb, err := state.New(block.Root(), state.NewDatabase(db), nil)
+			require.NoError(err)
+
+			evm := vm.NewEVM(NewEVMBlockContext(block.Header(), &configChainContext{config: &config}, &common.Address{}), vm.TxContext{}, statedb, &config, vm.Config{})
+			const suppliedGas = 100_000
+			ret, remainingGas, err := evm.Call(vm.AccountRef(common.Address{1}), querierAddress, nil, suppliedGas, new(uint256.Int))
+			require.ErrorIs(err, test.wantErr)
+			if test.wantErr != nil {
+				return
+			}
+			require.Equal(common.BigToHash(feeConfig.GasLimit).Bytes(), ret)
+			require.Equal(uint64(suppliedGas)-feemanager.GetFeeConfigGasCost, remainingGas)
+		})
+	}
+}
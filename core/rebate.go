@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"github.com/ava-labs/libevm/common"
+	"github.com/holiman/uint256"
+
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rebate"
+)
+
+// rebateCall records the gas consumed by a message calling a contract that may
+// accrue a rebate. The gas consumed by the contracts it calls in turn is
+// included, as the EVM exposes no call frames to the state transition.
+type rebateCall struct {
+	target common.Address
+	gas    uint64
+}
+
+// newRebateCall returns the rebate hook of a message call to [to], or nil if
+// no rebate can accrue because the rebate precompile is not enabled or no
+// contract is registered.
+func newRebateCall(stateDB rebate.AccrualStateDB, enabled bool, to *common.Address) *rebateCall {
+	if !enabled || to == nil || rebate.RegisteredCount(stateDB) == 0 {
+		return nil
+	}
+	return &rebateCall{target: *to}
+}
+
+// enter records the gas available to the call.
+func (c *rebateCall) enter(gas uint64) {
+	c.gas = gas
+}
+
+// exit records the gas consumed by the call from the gas it left.
+func (c *rebateCall) exit(gasRemaining uint64) {
+	c.gas -= gasRemaining
+}
+
+// accrue accrues the rebate of the called contract on the gas it consumed at
+// [price], and returns the rebate to deduct from [fee]. The rebated gas is
+// bounded by [fee], as gas refunds may lower it below the gas consumed.
+func (c *rebateCall) accrue(stateDB rebate.AccrualStateDB, price *uint256.Int, fee *uint256.Int) *uint256.Int {
+	consumed := new(uint256.Int).SetUint64(c.gas)
+	consumed.Mul(consumed, price)
+	if consumed.Gt(fee) {
+		consumed.Set(fee)
+	}
+	return rebate.Accrue(stateDB, c.target, consumed)
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rebate"
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+func TestRebate(t *testing.T) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		target   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		other    = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		wrapper  = common.HexToAddress("0x1000000000000000000000000000000000000003")
+		coinbase = common.HexToAddress("0x2000000000000000000000000000000000000001")
+		rate     = uint64(2_500) // 25%
+		// code consumes [codeGas] and stops.
+		code    = []byte{byte(vm.PUSH1), 0, byte(vm.POP), byte(vm.PUSH1), 0, byte(vm.POP), byte(vm.STOP)}
+		codeGas = uint64(10)
+	)
+	// The wrapper calls [target] with all its gas.
+	wrapperCode := []byte{
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+		byte(vm.PUSH20),
+	}
+	wrapperCode = append(wrapperCode, target.Bytes()...)
+	wrapperCode = append(wrapperCode, byte(vm.GAS), byte(vm.CALL), byte(vm.POP), byte(vm.STOP))
+
+	config := params.Copy(params.TestChainConfig)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		rebate.ConfigKey: rebate.NewConfig(utils.NewUint64(0), nil, nil, nil, map[common.Address]*rebate.InitialRebate{
+			target: {Owner: addr, Rate: rate},
+		}),
+	}
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			addr:    {Balance: big.NewInt(params.Ether)},
+			target:  {Code: code},
+			other:   {Code: code},
+			wrapper: {Code: wrapperCode},
+		},
+	}
+	signer := types.LatestSigner(&config)
+	newTx := func(nonce uint64, to common.Address, data []byte, baseFee *big.Int) *types.Transaction {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     nonce,
+			To:        &to,
+			Gas:       100_000,
+			GasFeeCap: new(big.Int).Mul(baseFee, common.Big2),
+			GasTipCap: common.Big0,
+			Data:      data,
+		})
+		require.NoError(t, err)
+		return tx
+	}
+	claim, err := rebate.PackClaimRebate(target)
+
+	require.NoError(t, err)
+
+	var wantRebate *uint256.Int
+	_, _, receipts, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 2, 10, func(i int, gen *BlockGen) {
+		gen.SetCoinbase(coinbase)
+		switch i {
+		case 0:
+			// Only the fees paid for the gas consumed by transactions calling the
+			// registered contract accrue a rebate, not those of calls into it made
+			// by other contracts.
+			gen.AddTx(newTx(0, target, nil, gen.BaseFee()))
+			gen.AddTx(newTx(1, wrapper, nil, gen.BaseFee()))
+			gen.AddTx(newTx(2, other, nil, gen.BaseFee()))
+
+			fee := new(big.Int)
+			for _, receipt := range gen.receipts {
+				fee.Add(fee, new(big.Int).Mul(gen.BaseFee(), new(big.Int).SetUint64(receipt.GasUsed)))
+			}
+			callRebate := new(big.Int).Mul(gen.BaseFee(), new(big.Int).SetUint64(codeGas*rate))
+			callRebate.Div(callRebate, new(big.Int).SetUint64(rebate.MaxRebateRate))
+			wantRebate = uint256.MustFromBig(callRebate)
+			require.Equal(t, wantRebate.ToBig(), rebate.GetAccruedRebate(gen.statedb, target))
+			require.Zero(t, rebate.GetAccruedRebate(gen.statedb, wrapper).Sign())
+			require.Zero(t, rebate.GetAccruedRebate(gen.statedb, other).Sign())
+			require.Equal(t, wantRebate, gen.GetBalance(rebate.ContractAddress))
+			require.Equal(t, new(big.Int).Sub(fee, wantRebate.ToBig()), gen.GetBalance(coinbase).ToBig())
+		case 1:
+			require.Equal(t, uint64(1), rebate.RegisteredCount(gen.statedb))
+
+			// The owner claims the accrued rebate.
+			before := gen.GetBalance(addr)
+			tx := newTx(3, rebate.ContractAddress, claim, gen.BaseFee())
+			gen.AddTx(tx)
+
+			receipt := gen.receipts[len(gen.receipts)-1]
+			require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+			fee := new(big.Int).Mul(gen.BaseFee(), new(big.Int).SetUint64(receipt.GasUsed))
+			want := new(big.Int).Add(new(big.Int).Sub(before.ToBig(), fee), wantRebate.ToBig())
+			require.Equal(t, want, gen.GetBalance(addr).ToBig())
+			require.Zero(t, rebate.GetAccruedRebate(gen.statedb, target).Sign())
+			require.True(t, gen.GetBalance(rebate.ContractAddress).IsZero())
+		}
+	})
+	require.NoError(t, err)
+	require.Len(t, receipts[1], 1)
+	require.Len(t, receipts[1][0].Logs, 1)
+	require.Equal(t, rebate.RebateABI.Events["RebateClaimed"].ID, receipts[1][0].Logs[0].Topics[0])
+}
@@ -42,6 +42,8 @@ import (
 	ethparams "github.com/ava-labs/libevm/params"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/plugin/evm/vmerrors"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feetoken"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rebate"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/holiman/uint256"
 )
@@ -136,6 +138,14 @@ func IntrinsicGas(data []byte, accessList types.AccessList, isContractCreation b
 		}
 		gas = totalGas
 	}
+	// Transactions paying their gas fees in a fee token also pay for the calls to the token contract.
+	if _, ok := feetoken.FeeTokenFromAccessList(accessList); ok && params.GetRulesExtra(rules).IsPrecompileEnabled(feetoken.ContractAddress) {
+		totalGas, overflow := cmath.SafeAdd(gas, feetoken.FeeTokenIntrinsicGas)
+		if overflow {
+			return 0, ErrGasUintOverflow
+		}
+		gas = totalGas
+	}
 
 	return gas, nil
 }
@@ -275,6 +285,7 @@ type StateTransition struct {
 	initialGas   uint64
 	state        vm.StateDB
 	evm          *vm.EVM
+	feeToken     *feeTokenPayment
 }
 
 // NewStateTransition initialises and returns a new state transition object.
@@ -304,6 +315,21 @@ func (st *StateTransition) buyGas() error {
 		balanceCheck = balanceCheck.Mul(balanceCheck, st.msg.GasFeeCap)
 		balanceCheck.Add(balanceCheck, st.msg.Value)
 	}
+	feeToken, err := st.newFeeTokenPayment()
+	if err != nil {
+		return err
+	}
+	gasFee := new(big.Int).Set(mgval)
+	if feeToken != nil {
+		// The gas fees are paid in the fee token, so only the value and the
+		// blob fees are paid in native coin.
+		gasBalanceCheck := gasFee
+		if st.msg.GasFeeCap != nil {
+			gasBalanceCheck = new(big.Int).Mul(new(big.Int).SetUint64(st.msg.GasLimit), st.msg.GasFeeCap)
+		}
+		balanceCheck.Sub(balanceCheck, gasBalanceCheck)
+		mgval.SetUint64(0)
+	}
 	if st.evm.ChainConfig().IsCancun(st.evm.Context.BlockNumber, st.evm.Context.Time) {
 		if blobGas := st.blobGasUsed(); blobGas > 0 {
 			// Check that the user has enough funds to cover blobGasUsed * tx.BlobGasFeeCap
@@ -331,6 +357,12 @@ func (st *StateTransition) buyGas() error {
 	st.initialGas = st.msg.GasLimit
 	mgvalU256, _ := uint256.FromBig(mgval)
 	st.state.SubBalance(st.msg.From, mgvalU256)
+	if feeToken != nil {
+		if err := feeToken.escrow(st, gasFee); err != nil {
+			return err
+		}
+		st.feeToken = feeToken
+	}
 	return nil
 }
 
@@ -384,9 +416,17 @@ func (st *StateTransition) preCheck() error {
 			}
 			// This will panic if baseFee is nil, but basefee presence is verified
 			// as part of header validation.
-			if msg.GasFeeCap.Cmp(st.evm.Context.BaseFee) < 0 {
+			baseFee := SenderBaseFee(st.evm.ChainConfig(), st.state, msg.From, st.evm.Context.BaseFee, st.evm.Context.Time)
+			if msg.GasFeeCap.Cmp(baseFee) < 0 {
 				return fmt.Errorf("%w: address %v, maxFeePerGas: %s, baseFee: %s", ErrFeeCapTooLow,
-					msg.From.Hex(), msg.GasFeeCap, st.evm.Context.BaseFee)
+					msg.From.Hex(), msg.GasFeeCap, baseFee)
+			}
+			// Senders with a fee discount only pay their share of the base fee.
+			// The transaction context was built from the undiscounted price, so it is
+			// updated too before execution for the GASPRICE opcode to see the price paid.
+			if baseFee != st.evm.Context.BaseFee {
+				msg.GasPrice = cmath.BigMin(new(big.Int).Add(msg.GasTipCap, baseFee), msg.GasFeeCap)
+				st.evm.TxContext.GasPrice = new(big.Int).Set(msg.GasPrice)
 			}
 		}
 	}
@@ -497,6 +537,13 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	st.state.Prepare(rules, msg.From, st.evm.Context.Coinbase, msg.To, vm.ActivePrecompiles(rules), msg.AccessList)
 	snap := st.state.Snapshot() // store in case execution invalidated
 
+	// Rebates accrue on the native fees paid for the gas consumed by messages
+	// calling registered contracts. Fees paid in a fee token do not accrue rebates.
+	var rebates *rebateCall
+	if st.feeToken == nil && !contractCreation {
+		rebates = newRebateCall(st.state, rulesExtra.IsPrecompileEnabled(rebate.ContractAddress), msg.To)
+	}
+
 	var (
 		ret   []byte
 		vmerr error // vm errors do not effect consensus and are therefore not assigned to err
@@ -506,7 +553,13 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	} else {
 		// Increment the nonce for the next transaction
 		st.state.SetNonce(msg.From, st.state.GetNonce(sender.Address())+1)
+		if rebates != nil {
+			rebates.enter(st.gasRemaining)
+		}
 		ret, st.gasRemaining, vmerr = st.evm.Call(sender, st.to(), msg.Data, st.gasRemaining, value)
+		if rebates != nil {
+			rebates.exit(st.gasRemaining)
+		}
 	}
 	price, overflow := uint256.FromBig(msg.GasPrice)
 	if overflow {
@@ -515,7 +568,14 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	gasRefund := st.refundGas(rulesExtra.IsSubnetEVM)
 	fee := new(uint256.Int).SetUint64(st.gasUsed())
 	fee.Mul(fee, price)
-	st.state.AddBalance(st.evm.Context.Coinbase, fee)
+	if st.feeToken != nil {
+		st.feeToken.settle(st, fee.ToBig())
+	} else {
+		if rebates != nil {
+			fee.Sub(fee, rebates.accrue(st.state, price, fee))
+		}
+		st.state.AddBalance(st.evm.Context.Coinbase, fee)
+	}
 
 	if err := st.evm.ExecutionInvalidated(); err != nil {
 		log.Warn(
@@ -548,10 +608,13 @@ func (st *StateTransition) refundGas(subnetEVM bool) uint64 {
 		st.gasRemaining += refund
 	}
 
-	// Return ETH for remaining gas, exchanged at the original rate.
-	remaining := uint256.NewInt(st.gasRemaining)
-	remaining = remaining.Mul(remaining, uint256.MustFromBig(st.msg.GasPrice))
-	st.state.AddBalance(st.msg.From, remaining)
+	// Return ETH for remaining gas, exchanged at the original rate. Gas paid for
+	// in a fee token is refunded when the fee token payment is settled.
+	if st.feeToken == nil {
+		remaining := uint256.NewInt(st.gasRemaining)
+		remaining = remaining.Mul(remaining, uint256.MustFromBig(st.msg.GasPrice))
+		st.state.AddBalance(st.msg.From, remaining)
+	}
 
 	// Also return remaining gas to the block gas counter so it is
 	// available for the next transaction.
@@ -0,0 +1,197 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/libevm/common/lru"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/metrics"
+	"golang.org/x/time/rate"
+)
+
+// admissionLimiterCapacity is the number of senders and of origins the
+// admission rate limits are tracked for. The token buckets of the least
+// recently seen ones are dropped beyond it.
+const admissionLimiterCapacity = 16384
+
+var (
+	ErrSenderRateLimited = errors.New("sender exceeded transaction admission rate limit")
+	ErrOriginRateLimited = errors.New("origin exceeded transaction admission rate limit")
+)
+
+var (
+	senderRateLimitedMeter = metrics.NewRegisteredMeter("txpool/admission/sender/limited", nil)
+	originRateLimitedMeter = metrics.NewRegisteredMeter("txpool/admission/origin/limited", nil)
+)
+
+// AdmissionConfig are the token bucket rate limits on the transactions admitted
+// into the pool, set with [TxPool.SetAdmissionLimits].
+type AdmissionConfig struct {
+	SenderRate  float64 // Transactions admitted per second from each sender, or 0 for no limit
+	SenderBurst int     // Transactions admitted at once from each sender
+	OriginRate  float64 // Transactions admitted per second from each RPC client or gossip peer, or 0 for no limit
+	OriginBurst int     // Transactions admitted at once from each RPC client or gossip peer
+}
+
+// DefaultAdmissionConfig contains the default admission rate limits, which do
+// not limit admissions.
+var DefaultAdmissionConfig = AdmissionConfig{
+	SenderBurst: 16,
+	OriginBurst: 256,
+}
+
+// keyedLimiter is a token bucket rate limiter per key.
+type keyedLimiter struct {
+	limit rate.Limit
+	burst int
+
+	lock     sync.Mutex
+	limiters lru.BasicLRU[string, *rate.Limiter]
+}
+
+// newKeyedLimiter returns a limiter allowing [limit] events per second for each
+// key, with bursts of [burst] events, or nil if [limit] is 0.
+func newKeyedLimiter(limit float64, burst int) *keyedLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &keyedLimiter{
+		limit:    rate.Limit(limit),
+		burst:    burst,
+		limiters: lru.NewBasicLRU[string, *rate.Limiter](admissionLimiterCapacity),
+	}
+}
+
+// allow returns whether an event of [key] is allowed at [now], consuming a
+// token of its bucket if so.
+func (l *keyedLimiter) allow(key string, now time.Time) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.limiter(key).AllowN(now, 1)
+}
+
+// available returns the number of tokens available in the bucket of [key] at
+// [now], without consuming any.
+func (l *keyedLimiter) available(key string, now time.Time) float64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.limiter(key).TokensAt(now)
+}
+
+// charge consumes a token of the bucket of [key] at [now], even if it is empty,
+// in which case the bucket refills it before allowing further events.
+func (l *keyedLimiter) charge(key string, now time.Time) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.limiter(key).ReserveN(now, 1)
+}
+
+// limiter returns the bucket of [key]. Assumes the lock is held.
+func (l *keyedLimiter) limiter(key string) *rate.Limiter {
+	limiter, ok := l.limiters.Get(key)
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters.Add(key, limiter)
+	}
+	return limiter
+}
+
+// admissionLimiter enforces an [AdmissionConfig].
+type admissionLimiter struct {
+	config  AdmissionConfig
+	signer  types.Signer
+	senders *keyedLimiter // nil if the senders are not limited
+	origins *keyedLimiter // nil if the origins are not limited
+}
+
+// SetAdmissionLimits rate limits the transactions admitted into the pool from
+// each sender, and from each origin checked with [TxPool.AdmitOrigin].
+// [signer] recovers the senders of the transactions.
+func (p *TxPool) SetAdmissionLimits(config AdmissionConfig, signer types.Signer) {
+	p.admission.Store(&admissionLimiter{
+		config:  config,
+		signer:  signer,
+		senders: newKeyedLimiter(config.SenderRate, config.SenderBurst),
+		origins: newKeyedLimiter(config.OriginRate, config.OriginBurst),
+	})
+}
+
+// AdmitOrigin returns an error if [origin], identifying the RPC client or the
+// gossip peer a transaction was received from, exceeded its admission rate
+// limit, consuming one of its tokens otherwise.
+func (p *TxPool) AdmitOrigin(origin string) error {
+	limiter := p.admission.Load()
+	if limiter == nil || limiter.origins == nil {
+		return nil
+	}
+	if !limiter.origins.allow(origin, time.Now()) {
+		originRateLimitedMeter.Mark(1)
+		return fmt.Errorf("%w: %s sent more than %d transactions at once or %v per second", ErrOriginRateLimited, origin, limiter.config.OriginBurst, limiter.config.OriginRate)
+	}
+	return nil
+}
+
+// admitSenders returns, for each transaction of [txs], an error if its sender
+// exceeded its admission rate limit, along with the senders of the others.
+// Transactions already in the pool are not limited, as they are rejected anyway.
+//
+// No token is consumed: the senders are only charged with [chargeSenders] for
+// the transactions the pool accepts, so that replaying the invalid or already
+// included transactions of a sender does not drain its bucket.
+func (p *TxPool) admitSenders(txs []*types.Transaction) ([]error, []string) {
+	errs := make([]error, len(txs))
+	limiter := p.admission.Load()
+	if limiter == nil || limiter.senders == nil {
+		return errs, nil
+	}
+	var (
+		now     = time.Now()
+		senders = make([]string, len(txs))
+		pending = make(map[string]int)
+	)
+	for i, tx := range txs {
+		if p.Has(tx.Hash()) {
+			continue
+		}
+		from, err := types.Sender(limiter.signer, tx)
+		if err != nil {
+			errs[i] = fmt.Errorf("%w: %v", ErrInvalidSender, err)
+			continue
+		}
+		// The transactions of the batch admitted so far are accounted for, as
+		// they are only charged once added.
+		key := string(from.Bytes())
+		if limiter.senders.available(key, now) < float64(pending[key]+1) {
+			senderRateLimitedMeter.Mark(1)
+			errs[i] = fmt.Errorf("%w: %s sent more than %d transactions at once or %v per second", ErrSenderRateLimited, from, limiter.config.SenderBurst, limiter.config.SenderRate)
+			continue
+		}
+		pending[key]++
+		senders[i] = key
+	}
+	return errs, senders
+}
+
+// chargeSenders consumes a token of each of the [senders] returned by
+// [admitSenders] whose transaction was accepted, with a nil error in [errs].
+func (p *TxPool) chargeSenders(senders []string, errs []error) {
+	limiter := p.admission.Load()
+	if limiter == nil || limiter.senders == nil {
+		return
+	}
+	now := time.Now()
+	for i, sender := range senders {
+		if sender != "" && errs[i] == nil {
+			limiter.senders.charge(sender, now)
+		}
+	}
+}
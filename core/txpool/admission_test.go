@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/core"
+)
+
+func TestKeyedLimiter(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(newKeyedLimiter(0, 1))
+
+	limiter := newKeyedLimiter(1, 2)
+	now := time.Unix(1000, 0)
+	require.True(limiter.allow("a", now))
+	require.True(limiter.allow("a", now))
+	require.False(limiter.allow("a", now))
+
+	// Other keys have their own bucket.
+	require.True(limiter.allow("b", now))
+
+	// Tokens are refilled at the configured rate.
+	require.True(limiter.allow("a", now.Add(time.Second)))
+	require.False(limiter.allow("a", now.Add(time.Second)))
+}
+
+func TestAdmissionLimits(t *testing.T) {
+	require := require.New(t)
+
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	newTx := func(nonce uint64) *types.Transaction {
+		key, err := crypto.GenerateKey()
+		require.NoError(err)
+		tx, err := types.SignNewTx(key, signer, &types.LegacyTx{Nonce: nonce, To: &common.Address{}})
+		require.NoError(err)
+		return tx
+	}
+
+	pool := new(TxPool)
+	require.NoError(pool.AdmitOrigin("rpc:127.0.0.1"))
+	errs, _ := pool.admitSenders([]*types.Transaction{newTx(0), newTx(0)})
+	require.Equal([]error{nil, nil}, errs)
+
+	pool.SetAdmissionLimits(AdmissionConfig{SenderRate: 0.001, SenderBurst: 1, OriginRate: 0.001, OriginBurst: 1}, signer)
+	require.NoError(pool.AdmitOrigin("rpc:127.0.0.1"))
+	require.ErrorIs(pool.AdmitOrigin("rpc:127.0.0.1"), ErrOriginRateLimited)
+	require.NoError(pool.AdmitOrigin("peer:NodeID-1"))
+
+	tx := newTx(0)
+	errs, senders := pool.admitSenders([]*types.Transaction{tx, newTx(0), tx})
+	require.Len(errs, 3)
+	require.NoError(errs[0])
+	require.NoError(errs[1])
+	require.ErrorIs(errs[2], ErrSenderRateLimited)
+
+	// Senders are only charged for the transactions accepted.
+	pool.chargeSenders(senders, []error{nil, core.ErrNonceTooLow, errs[2]})
+	errs, _ = pool.admitSenders([]*types.Transaction{tx})
+	require.ErrorIs(errs[0], ErrSenderRateLimited)
+
+	unsigned := types.NewTx(&types.LegacyTx{})
+	errs, _ = pool.admitSenders([]*types.Transaction{unsigned})
+	require.ErrorIs(errs[0], ErrInvalidSender)
+}
+
+func TestAdmissionLimitsRejectedTxs(t *testing.T) {
+	require := require.New(t)
+
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	tx, err := types.SignNewTx(key, signer, &types.LegacyTx{To: &common.Address{}})
+	require.NoError(err)
+
+	pool := new(TxPool)
+	pool.SetAdmissionLimits(AdmissionConfig{SenderRate: 0.001, SenderBurst: 1}, signer)
+
+	// Replaying a transaction the pool rejects does not drain the bucket of its
+	// sender.
+	for i := 0; i < 3; i++ {
+		require.ErrorIs(pool.Add([]*types.Transaction{tx}, false, true)[0], core.ErrTxTypeNotSupported)
+		require.ErrorIs(pool.AddConditional(tx, &TransactionConditional{}), core.ErrTxTypeNotSupported)
+	}
+	errs, _ := pool.admitSenders([]*types.Transaction{tx})
+	require.NoError(errs[0])
+}
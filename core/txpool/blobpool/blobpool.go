@@ -41,7 +41,6 @@ import (
 	"time"
 
 	"github.com/ava-labs/libevm/common"
-	"github.com/ava-labs/libevm/consensus/misc/eip4844"
 	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/libevm/event"
@@ -433,7 +432,7 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserve txpool.Addres
 		blobfee = uint256.NewInt(ethparams.BlobTxMinBlobGasprice)
 	)
 	if p.head.ExcessBlobGas != nil {
-		blobfee = uint256.MustFromBig(eip4844.CalcBlobFee(*p.head.ExcessBlobGas))
+		blobfee = uint256.MustFromBig(customheader.BlobBaseFee(params.GetExtra(p.chain.Config()), p.head))
 	}
 	p.evict = newPriceHeap(basefee, blobfee, &p.index)
 
@@ -870,7 +869,7 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 		blobfee = uint256.MustFromBig(big.NewInt(ethparams.BlobTxMinBlobGasprice))
 	)
 	if newHead.ExcessBlobGas != nil {
-		blobfee = uint256.MustFromBig(eip4844.CalcBlobFee(*newHead.ExcessBlobGas))
+		blobfee = uint256.MustFromBig(customheader.BlobBaseFee(params.GetExtra(p.chain.Config()), newHead))
 	}
 	p.evict.reinit(basefee, blobfee, false)
 
@@ -1251,6 +1250,22 @@ func (p *BlobPool) Get(hash common.Hash) *types.Transaction {
 	return item
 }
 
+// AddConditional is not supported by the blob pool.
+func (p *BlobPool) AddConditional(tx *types.Transaction, conditional *txpool.TransactionConditional) error {
+	return txpool.ErrConditionalNotSupported
+}
+
+// Conditional returns nil, as the blob pool holds no conditional transactions.
+func (p *BlobPool) Conditional(hash common.Hash) *txpool.TransactionConditional {
+	return nil
+}
+
+// Remove is not supported by the blob pool, which only drops transactions on
+// inclusion, replacement or eviction.
+func (p *BlobPool) Remove(hash common.Hash) bool {
+	return false
+}
+
 // Add inserts a set of blob transactions into the pool if they pass validation (both
 // consensus validity and pool restrictions).
 func (p *BlobPool) Add(txs []*types.Transaction, local bool, sync bool) []error {
@@ -1720,3 +1735,12 @@ func (p *BlobPool) Status(hash common.Hash) txpool.TxStatus {
 	}
 	return txpool.TxStatusUnknown
 }
+
+// Explain only reports the status of blob transactions, which are always
+// pending in the blob pool.
+func (p *BlobPool) Explain(hash common.Hash) *txpool.TxExplanation {
+	if !p.Has(hash) {
+		return nil
+	}
+	return &txpool.TxExplanation{Status: txpool.TxStatusPending}
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/libevm/stateconf"
+)
+
+// MaxConditionalCost is the maximum number of storage roots and storage slots a
+// [TransactionConditional] may require to be checked.
+const MaxConditionalCost = 1000
+
+var (
+	ErrConditionalCost         = errors.New("transaction conditional exceeds maximum cost")
+	ErrConditionalBounds       = errors.New("transaction conditional minimum is above its maximum")
+	ErrConditionalFailed       = errors.New("transaction conditional failed")
+	ErrConditionalExpired      = errors.New("transaction conditional expired")
+	ErrConditionalNotSupported = errors.New("transaction conditionals not supported")
+)
+
+// ConditionalStateReader is the state a [TransactionConditional] is checked
+// against.
+type ConditionalStateReader interface {
+	GetStorageRoot(addr common.Address) common.Hash
+	GetState(addr common.Address, key common.Hash, opts ...stateconf.StateDBStateOption) common.Hash
+}
+
+// KnownAccount is the expected storage of an account, given either as the root
+// of its storage trie or as the values of some of its storage slots.
+type KnownAccount struct {
+	StorageRoot  *common.Hash
+	StorageSlots map[common.Hash]common.Hash
+}
+
+// MarshalJSON encodes the account as its storage root if set, or as an object
+// mapping storage slots to their values otherwise.
+func (a KnownAccount) MarshalJSON() ([]byte, error) {
+	if a.StorageRoot != nil {
+		return json.Marshal(a.StorageRoot)
+	}
+	return json.Marshal(a.StorageSlots)
+}
+
+// UnmarshalJSON decodes the account from either a storage root or an object
+// mapping storage slots to their values.
+func (a *KnownAccount) UnmarshalJSON(input []byte) error {
+	var root common.Hash
+	if err := json.Unmarshal(input, &root); err == nil {
+		a.StorageRoot, a.StorageSlots = &root, nil
+		return nil
+	}
+	var slots map[common.Hash]common.Hash
+	if err := json.Unmarshal(input, &slots); err != nil {
+		return fmt.Errorf("known account must be a storage root or an object of storage slots: %w", err)
+	}
+	a.StorageRoot, a.StorageSlots = nil, slots
+	return nil
+}
+
+// TransactionConditional is a set of conditions the state and the block must
+// meet for a transaction to be included, sent with
+// eth_sendRawTransactionConditional.
+type TransactionConditional struct {
+	KnownAccounts  map[common.Address]KnownAccount `json:"knownAccounts"`
+	BlockNumberMin *hexutil.Uint64                 `json:"blockNumberMin,omitempty"`
+	BlockNumberMax *hexutil.Uint64                 `json:"blockNumberMax,omitempty"`
+	TimestampMin   *hexutil.Uint64                 `json:"timestampMin,omitempty"`
+	TimestampMax   *hexutil.Uint64                 `json:"timestampMax,omitempty"`
+}
+
+// Cost returns the number of storage roots and storage slots to check.
+func (c *TransactionConditional) Cost() int {
+	cost := 0
+	for _, account := range c.KnownAccounts {
+		if account.StorageRoot != nil {
+			cost++
+		} else {
+			cost += len(account.StorageSlots)
+		}
+	}
+	return cost
+}
+
+// ChecksStorageRoots returns whether the conditions check the storage root of
+// any account.
+func (c *TransactionConditional) ChecksStorageRoots() bool {
+	for _, account := range c.KnownAccounts {
+		if account.StorageRoot != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error if the conditions can never be met, or are too
+// expensive to check.
+func (c *TransactionConditional) Validate() error {
+	if cost := c.Cost(); cost > MaxConditionalCost {
+		return fmt.Errorf("%w: %d > %d", ErrConditionalCost, cost, MaxConditionalCost)
+	}
+	if c.BlockNumberMin != nil && c.BlockNumberMax != nil && *c.BlockNumberMin > *c.BlockNumberMax {
+		return fmt.Errorf("%w: block number %d > %d", ErrConditionalBounds, *c.BlockNumberMin, *c.BlockNumberMax)
+	}
+	if c.TimestampMin != nil && c.TimestampMax != nil && *c.TimestampMin > *c.TimestampMax {
+		return fmt.Errorf("%w: timestamp %d > %d", ErrConditionalBounds, *c.TimestampMin, *c.TimestampMax)
+	}
+	return nil
+}
+
+// Expired returns whether the conditions cannot be met by the block with the
+// given number and time, nor by any later block.
+func (c *TransactionConditional) Expired(number uint64, time uint64) bool {
+	return (c.BlockNumberMax != nil && number > uint64(*c.BlockNumberMax)) ||
+		(c.TimestampMax != nil && time > uint64(*c.TimestampMax))
+}
+
+// CheckBlock returns an error if the block with the given number and time does
+// not meet the conditions.
+func (c *TransactionConditional) CheckBlock(number uint64, time uint64) error {
+	if c.Expired(number, time) {
+		return fmt.Errorf("%w: block %d at %d", ErrConditionalExpired, number, time)
+	}
+	if c.BlockNumberMin != nil && number < uint64(*c.BlockNumberMin) {
+		return fmt.Errorf("%w: block number %d < %d", ErrConditionalFailed, number, *c.BlockNumberMin)
+	}
+	if c.TimestampMin != nil && time < uint64(*c.TimestampMin) {
+		return fmt.Errorf("%w: timestamp %d < %d", ErrConditionalFailed, time, *c.TimestampMin)
+	}
+	return nil
+}
+
+// CheckState returns an error if [state] does not meet the known accounts of
+// the conditions.
+func (c *TransactionConditional) CheckState(state ConditionalStateReader) error {
+	for addr, account := range c.KnownAccounts {
+		if account.StorageRoot != nil {
+			if root := state.GetStorageRoot(addr); root != *account.StorageRoot {
+				return fmt.Errorf("%w: storage root of %s is %s, not %s", ErrConditionalFailed, addr, root, *account.StorageRoot)
+			}
+			continue
+		}
+		for slot, want := range account.StorageSlots {
+			if value := state.GetState(addr, slot); value != want {
+				return fmt.Errorf("%w: slot %s of %s is %s, not %s", ErrConditionalFailed, slot, addr, value, want)
+			}
+		}
+	}
+	return nil
+}
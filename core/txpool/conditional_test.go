@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/libevm/stateconf"
+	"github.com/stretchr/testify/require"
+)
+
+type testStateReader struct {
+	roots map[common.Address]common.Hash
+	slots map[common.Address]map[common.Hash]common.Hash
+}
+
+func (s *testStateReader) GetStorageRoot(addr common.Address) common.Hash {
+	return s.roots[addr]
+}
+
+func (s *testStateReader) GetState(addr common.Address, key common.Hash, _ ...stateconf.StateDBStateOption) common.Hash {
+	return s.slots[addr][key]
+}
+
+func TestTransactionConditionalJSON(t *testing.T) {
+	require := require.New(t)
+
+	input := `{
+		"knownAccounts": {
+			"0x0000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000011",
+			"0x0000000000000000000000000000000000000002": {
+				"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000022"
+			}
+		},
+		"blockNumberMin": "0x1",
+		"timestampMax": "0x64"
+	}`
+	var cond TransactionConditional
+	require.NoError(json.Unmarshal([]byte(input), &cond))
+
+	root := common.HexToHash("0x11")
+	require.Equal(map[common.Address]KnownAccount{
+		common.HexToAddress("0x01"): {StorageRoot: &root},
+		common.HexToAddress("0x02"): {StorageSlots: map[common.Hash]common.Hash{
+			common.HexToHash("0x01"): common.HexToHash("0x22"),
+		}},
+	}, cond.KnownAccounts)
+	require.Equal(hexutil.Uint64(1), *cond.BlockNumberMin)
+	require.Nil(cond.BlockNumberMax)
+	require.Nil(cond.TimestampMin)
+	require.Equal(hexutil.Uint64(100), *cond.TimestampMax)
+	require.Equal(2, cond.Cost())
+	require.True(cond.ChecksStorageRoots())
+
+	encoded, err := json.Marshal(&cond)
+	require.NoError(err)
+	var decoded TransactionConditional
+	require.NoError(json.Unmarshal(encoded, &decoded))
+	require.Equal(cond, decoded)
+
+	require.Error(json.Unmarshal([]byte(`{"knownAccounts": {"0x0000000000000000000000000000000000000001": 1}}`), &decoded))
+}
+
+func TestTransactionConditionalValidate(t *testing.T) {
+	uint64Ptr := func(n uint64) *hexutil.Uint64 {
+		u := hexutil.Uint64(n)
+		return &u
+	}
+	tooManySlots := make(map[common.Hash]common.Hash, MaxConditionalCost+1)
+	for i := range MaxConditionalCost + 1 {
+		tooManySlots[common.BigToHash(big.NewInt(int64(i)))] = common.Hash{}
+	}
+
+	tests := []struct {
+		name string
+		cond TransactionConditional
+		want error
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "valid bounds",
+			cond: TransactionConditional{BlockNumberMin: uint64Ptr(1), BlockNumberMax: uint64Ptr(1), TimestampMin: uint64Ptr(1), TimestampMax: uint64Ptr(2)},
+		},
+		{
+			name: "block number bounds",
+			cond: TransactionConditional{BlockNumberMin: uint64Ptr(2), BlockNumberMax: uint64Ptr(1)},
+			want: ErrConditionalBounds,
+		},
+		{
+			name: "timestamp bounds",
+			cond: TransactionConditional{TimestampMin: uint64Ptr(2), TimestampMax: uint64Ptr(1)},
+			want: ErrConditionalBounds,
+		},
+		{
+			name: "too expensive",
+			cond: TransactionConditional{KnownAccounts: map[common.Address]KnownAccount{
+				{}: {StorageSlots: tooManySlots},
+			}},
+			want: ErrConditionalCost,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.cond.Validate(), test.want)
+		})
+	}
+}
+
+func TestTransactionConditionalCheck(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		addr1 = common.HexToAddress("0x01")
+		addr2 = common.HexToAddress("0x02")
+		root  = common.HexToHash("0x11")
+		lo    = hexutil.Uint64(10)
+		hi    = hexutil.Uint64(20)
+	)
+	cond := &TransactionConditional{
+		KnownAccounts: map[common.Address]KnownAccount{
+			addr1: {StorageRoot: &root},
+			addr2: {StorageSlots: map[common.Hash]common.Hash{{}: common.HexToHash("0x22")}},
+		},
+		BlockNumberMin: &lo,
+		BlockNumberMax: &hi,
+		TimestampMin:   &lo,
+		TimestampMax:   &hi,
+	}
+
+	require.NoError(cond.CheckBlock(10, 20))
+	require.ErrorIs(cond.CheckBlock(9, 10), ErrConditionalFailed)
+	require.ErrorIs(cond.CheckBlock(10, 9), ErrConditionalFailed)
+	require.ErrorIs(cond.CheckBlock(21, 10), ErrConditionalExpired)
+	require.ErrorIs(cond.CheckBlock(10, 21), ErrConditionalExpired)
+	require.False(cond.Expired(20, 20))
+	require.True(cond.Expired(21, 20))
+
+	state := &testStateReader{
+		roots: map[common.Address]common.Hash{addr1: root},
+		slots: map[common.Address]map[common.Hash]common.Hash{addr2: {{}: common.HexToHash("0x22")}},
+	}
+	require.NoError(cond.CheckState(state))
+
+	state.slots[addr2][common.Hash{}] = common.HexToHash("0x23")
+	require.ErrorIs(cond.CheckState(state), ErrConditionalFailed)
+
+	state.slots[addr2][common.Hash{}] = common.HexToHash("0x22")
+	state.roots[addr1] = common.HexToHash("0x12")
+	require.ErrorIs(cond.CheckState(state), ErrConditionalFailed)
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+)
+
+// TxExplanation describes a pooled transaction and what may keep it from being
+// included in the next block.
+type TxExplanation struct {
+	Status TxStatus // Whether the transaction is pending or queued
+	Local  bool     // Whether the transaction is local, and thus never evicted for its price
+
+	Nonce     uint64 // Nonce of the transaction
+	NextNonce uint64 // Next nonce of the sender after its pending transactions
+	NonceGap  uint64 // Number of nonces missing before a queued transaction can be executed
+
+	GasFeeCap        *big.Int // Fee cap of the transaction, nil if unknown
+	BaseFee          *big.Int // Base fee of the current head charged to the sender, nil if unknown
+	EstimatedBaseFee *big.Int // Estimated base fee of the next block charged to the sender, nil if unknown
+
+	PriceRank int // Position of the transaction among the remote transactions, from the best paying, or 0 if local
+	PricedTxs int // Number of remote transactions ranked by price
+
+	NotAllowListed bool // Whether the sender is not enabled in the transaction allow list
+}
+
+// FeeCapBelowBaseFee returns whether the fee cap of the transaction is below the
+// base fee of the current head.
+func (e *TxExplanation) FeeCapBelowBaseFee() bool {
+	return e.GasFeeCap != nil && e.BaseFee != nil && e.GasFeeCap.Cmp(e.BaseFee) < 0
+}
+
+// FeeCapBelowEstimatedBaseFee returns whether the fee cap of the transaction is
+// below the estimated base fee of the next block.
+func (e *TxExplanation) FeeCapBelowEstimatedBaseFee() bool {
+	return e.GasFeeCap != nil && e.EstimatedBaseFee != nil && e.GasFeeCap.Cmp(e.EstimatedBaseFee) < 0
+}
+
+// Explain describes the pooled transaction with the given hash, or returns nil
+// if it is not in the pool.
+func (p *TxPool) Explain(hash common.Hash) *TxExplanation {
+	for _, subpool := range p.subpools {
+		if explanation := subpool.Explain(hash); explanation != nil {
+			return explanation
+		}
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ package legacypool
 
 import (
 	"errors"
+	"maps"
 	"math"
 	"math/big"
 	"sort"
@@ -50,6 +51,8 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customheader"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feetoken"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/holiman/uint256"
 
@@ -115,6 +118,9 @@ var (
 	// throttleTxMeter counts how many transactions are rejected due to too-many-changes between
 	// txpool reorgs.
 	throttleTxMeter = metrics.GetOrRegisterMeter("txpool/throttle", nil)
+	// conditionalEvictionMeter counts how many transactions are evicted because
+	// their conditions failed or expired.
+	conditionalEvictionMeter = metrics.NewRegisteredMeter("txpool/conditional/eviction", nil)
 	// reorgDurationTimer measures how long time a txpool reorg takes.
 	reorgDurationTimer = metrics.GetOrRegisterTimer("txpool/reorgtime", nil)
 	// dropBetweenReorgHistogram counts how many drops we experience between two reorg runs. It is expected
@@ -248,6 +254,18 @@ type LegacyPool struct {
 	currentState  *state.StateDB               // Current state in the blockchain head
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
 
+	// feePercentages caches the percentage of the base fee charged to each sender,
+	// as set in the fee manager precompile in [currentState]. The urgent price heap
+	// is ordered by these, so it must be rebuilt whenever the cache is cleared.
+	feePercentages map[common.Address]uint64
+
+	// feeTokenState is the copy of [currentState] the fee token contracts are called
+	// on, so that the calls leave no trace in [currentState]. feeTokenSpendable caches
+	// the amount of each fee token the fee token precompile can escrow from each
+	// sender in it. Both are reset along with [currentState].
+	feeTokenState     *state.StateDB
+	feeTokenSpendable map[feeTokenAccount]*big.Int
+
 	locals  *accountSet // Set of local transaction to exempt from eviction rules
 	journal *journal    // Journal of local transaction to back up to disk
 
@@ -258,6 +276,9 @@ type LegacyPool struct {
 	all     *lookup                      // All transactions to allow lookups
 	priced  *pricedList                  // All transactions sorted by price
 
+	conditionals     map[common.Hash]*txpool.TransactionConditional // Conditions of the transactions added with AddConditional
+	conditionalsLock sync.RWMutex                                   // Lock protecting conditionals, so they can be read while iterating the pool
+
 	reqResetCh      chan *txpoolResetRequest
 	reqPromoteCh    chan *accountSet
 	queueTxEventCh  chan *types.Transaction
@@ -289,6 +310,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		queue:               make(map[common.Address]*list),
 		beats:               make(map[common.Address]time.Time),
 		all:                 newLookup(),
+		conditionals:        make(map[common.Hash]*txpool.TransactionConditional),
 		reqResetCh:          make(chan *txpoolResetRequest),
 		reqPromoteCh:        make(chan *accountSet),
 		queueTxEventCh:      make(chan *types.Transaction),
@@ -302,7 +324,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		log.Info("Setting new local account", "address", addr)
 		pool.locals.add(addr)
 	}
-	pool.priced = newPricedList(pool.all)
+	pool.priced = newPricedList(pool.all, pool.txFeePercentage)
 
 	if !config.NoLocals && config.Journal != "" {
 		pool.journal = newTxJournal(config.Journal)
@@ -345,6 +367,9 @@ func (pool *LegacyPool) Init(gasTip uint64, head *types.Header, reserve txpool.A
 	pool.currentHead.Store(head)
 	pool.currentState = statedb
 	pool.pendingNonces = newNoncer(statedb)
+	pool.feePercentages = make(map[common.Address]uint64)
+	pool.feeTokenState = nil
+	pool.feeTokenSpendable = make(map[feeTokenAccount]*big.Int)
 
 	// Start the reorg loop early, so it can handle requests generated during
 	// journal loading.
@@ -602,8 +627,9 @@ func (pool *LegacyPool) Pending(filter txpool.PendingFilter) map[common.Address]
 
 		// If the miner requests tip enforcement, cap the lists now
 		if minTipBig != nil && !pool.locals.contains(addr) {
+			senderBaseFee := feemanager.DiscountBaseFee(baseFeeBig, pool.feePercentage(addr))
 			for i, tx := range txs {
-				if tx.EffectiveGasTipIntCmp(minTipBig, baseFeeBig) < 0 {
+				if tx.EffectiveGasTipIntCmp(minTipBig, senderBaseFee) < 0 {
 					txs = txs[:i]
 					break
 				}
@@ -613,14 +639,15 @@ func (pool *LegacyPool) Pending(filter txpool.PendingFilter) map[common.Address]
 			lazies := make([]*txpool.LazyTransaction, len(txs))
 			for i := 0; i < len(txs); i++ {
 				lazies[i] = &txpool.LazyTransaction{
-					Pool:      pool,
-					Hash:      txs[i].Hash(),
-					Tx:        txs[i].WithoutBlobTxSidecar(),
-					Time:      txs[i].Time(),
-					GasFeeCap: uint256.MustFromBig(txs[i].GasFeeCap()),
-					GasTipCap: uint256.MustFromBig(txs[i].GasTipCap()),
-					Gas:       txs[i].Gas(),
-					BlobGas:   txs[i].BlobGas(),
+					Pool:        pool,
+					Hash:        txs[i].Hash(),
+					Tx:          txs[i].WithoutBlobTxSidecar(),
+					Time:        txs[i].Time(),
+					GasFeeCap:   uint256.MustFromBig(txs[i].GasFeeCap()),
+					GasTipCap:   uint256.MustFromBig(txs[i].GasTipCap()),
+					Gas:         txs[i].Gas(),
+					BlobGas:     txs[i].BlobGas(),
+					Conditional: pool.Conditional(txs[i].Hash()),
 				}
 			}
 			pending[addr] = lazies
@@ -629,6 +656,75 @@ func (pool *LegacyPool) Pending(filter txpool.PendingFilter) map[common.Address]
 	return pending
 }
 
+// feePercentage returns the percentage of the base fee charged to transactions
+// sent by [addr] at the current head.
+// assumes lock is already held
+func (pool *LegacyPool) feePercentage(addr common.Address) uint64 {
+	if percentage, ok := pool.feePercentages[addr]; ok {
+		return percentage
+	}
+	head := pool.currentHead.Load()
+	if head == nil || pool.currentState == nil {
+		return feemanager.MaxFeePercentage
+	}
+	percentage := core.FeePercentage(pool.chainconfig, pool.currentState, addr, head.Time)
+	pool.feePercentages[addr] = percentage
+	return percentage
+}
+
+// feeTokenAccount identifies the balance of a sender in a fee token.
+type feeTokenAccount struct {
+	token common.Address
+	addr  common.Address
+}
+
+// feeTokenSpendableOf returns the amount of [token] the fee token precompile can
+// escrow from [addr] at the current head. The token contract is called at most
+// once per sender and head, on a copy of the head state shared by all the calls.
+// assumes lock is already held
+func (pool *LegacyPool) feeTokenSpendableOf(token common.Address, addr common.Address) (*big.Int, error) {
+	account := feeTokenAccount{token: token, addr: addr}
+	if spendable, ok := pool.feeTokenSpendable[account]; ok {
+		return spendable, nil
+	}
+	if pool.feeTokenState == nil {
+		pool.feeTokenState = pool.currentState.Copy()
+	}
+	spendable, err := core.FeeTokenSpendable(pool.chainconfig, pool.currentHead.Load(), pool.feeTokenState, token, addr)
+	if err != nil {
+		return nil, err
+	}
+	pool.feeTokenSpendable[account] = spendable
+	return spendable, nil
+}
+
+// feeTokenCovers returns whether [addr] can have the amount of a fee token worth
+// gas fees in wei escrowed by the fee token precompile at the current head.
+// assumes lock is already held
+func (pool *LegacyPool) feeTokenCovers(addr common.Address) func(token common.Address, gasFees *big.Int) bool {
+	return func(token common.Address, gasFees *big.Int) bool {
+		if !params.GetExtra(pool.chainconfig).IsPrecompileEnabled(feetoken.ContractAddress, pool.currentHead.Load().Time) {
+			return false
+		}
+		rate := feetoken.GetFeeTokenRate(pool.currentState, token)
+		if rate.Sign() == 0 {
+			return false
+		}
+		spendable, err := pool.feeTokenSpendableOf(token, addr)
+		return err == nil && spendable.Cmp(feetoken.TokenAmount(gasFees, rate)) >= 0
+	}
+}
+
+// txFeePercentage returns the percentage of the base fee charged to the sender of [tx].
+// assumes lock is already held
+func (pool *LegacyPool) txFeePercentage(tx *types.Transaction) uint64 {
+	from, err := types.Sender(pool.signer, tx)
+	if err != nil {
+		return feemanager.MaxFeePercentage
+	}
+	return pool.feePercentage(from)
+}
+
 // IteratePending iterates over [pool.pending] until [f] returns false.
 // The caller must not modify [tx]. Returns false if iteration was interrupted.
 func (pool *LegacyPool) IteratePending(f func(tx *types.Transaction) bool) bool {
@@ -695,6 +791,11 @@ func (pool *LegacyPool) validateTxBasics(tx *types.Transaction, local bool) erro
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (pool *LegacyPool) validateTx(tx *types.Transaction, local bool) error {
+	minimumFee := pool.minimumFee
+	if from, err := types.Sender(pool.signer, tx); err == nil {
+		// Senders with a fee discount only have to cover their share of the minimum fee.
+		minimumFee = feemanager.DiscountBaseFee(minimumFee, pool.feePercentage(from))
+	}
 	opts := &txpool.ValidationOptionsWithState{
 		State: pool.currentState,
 		Rules: pool.chainconfig.Rules(
@@ -702,7 +803,7 @@ func (pool *LegacyPool) validateTx(tx *types.Transaction, local bool) error {
 			params.IsMergeTODO,
 			pool.currentHead.Load().Time,
 		),
-		MinimumFee: pool.minimumFee,
+		MinimumFee: minimumFee,
 
 		FirstNonceGap: nil, // Pool allows arbitrary arrival order, don't invalidate nonce gaps
 		UsedAndLeftSlots: func(addr common.Address) (int, int) {
@@ -724,11 +825,28 @@ func (pool *LegacyPool) validateTx(tx *types.Transaction, local bool) error {
 		ExistingCost: func(addr common.Address, nonce uint64) *big.Int {
 			if list := pool.pending[addr]; list != nil {
 				if tx := list.txs.Get(nonce); tx != nil {
-					return tx.Cost()
+					return core.TxNativeCost(tx)
 				}
 			}
 			return nil
 		},
+		FeeTokenSpendable: pool.feeTokenSpendableOf,
+		ExistingFeeTokenExpenditure: func(addr common.Address, token common.Address) *big.Int {
+			if list := pool.pending[addr]; list != nil {
+				return list.FeeTokenCost(token).ToBig()
+			}
+			return new(big.Int)
+		},
+		ExistingFeeTokenCost: func(addr common.Address, nonce uint64) (common.Address, *big.Int) {
+			if list := pool.pending[addr]; list != nil {
+				if tx := list.txs.Get(nonce); tx != nil {
+					if token, gasFee, ok := core.TxFeeTokenCost(tx); ok {
+						return token, gasFee
+					}
+				}
+			}
+			return common.Address{}, nil
+		},
 	}
 	if err := txpool.ValidateTransactionWithState(tx, pool.signer, opts); err != nil {
 		return err
@@ -1138,6 +1256,89 @@ func (pool *LegacyPool) Status(hash common.Hash) txpool.TxStatus {
 	return txpool.TxStatusUnknown
 }
 
+// Explain describes the transaction with the given hash and what may keep it
+// from being included, or returns nil if the transaction is not in the pool.
+func (pool *LegacyPool) Explain(hash common.Hash) *txpool.TxExplanation {
+	tx := pool.get(hash)
+	if tx == nil {
+		return nil
+	}
+	from, _ := types.Sender(pool.signer, tx) // already validated
+
+	// The state of the pool is shared with the writers of the pool, so the
+	// uncached fee discounts and the allow list are read from a state of its own.
+	head := pool.currentHead.Load()
+	statedb, err := pool.chain.StateAt(head.Root)
+	if err != nil {
+		log.Warn("Failed to get state to explain transaction", "hash", hash, "err", err)
+		return nil
+	}
+
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	explanation := &txpool.TxExplanation{
+		Local:     pool.all.GetLocal(hash) != nil,
+		Nonce:     tx.Nonce(),
+		NextNonce: pool.pendingNonces.get(from),
+		GasFeeCap: tx.GasFeeCap(),
+	}
+	if list := pool.pending[from]; list != nil && list.txs.items[tx.Nonce()] != nil {
+		explanation.Status = txpool.TxStatusPending
+	} else if list := pool.queue[from]; list != nil && list.txs.items[tx.Nonce()] != nil {
+		explanation.Status = txpool.TxStatusQueued
+		if tx.Nonce() > explanation.NextNonce {
+			explanation.NonceGap = tx.Nonce() - explanation.NextNonce
+		}
+	} else {
+		return nil
+	}
+
+	// The cache of fee discounts is only updated with the lock held for writing.
+	feePercentage := func(addr common.Address) uint64 {
+		if percentage, ok := pool.feePercentages[addr]; ok {
+			return percentage
+		}
+		return core.FeePercentage(pool.chainconfig, statedb, addr, head.Time)
+	}
+	percentage := feePercentage(from)
+	if head.BaseFee != nil {
+		explanation.BaseFee = feemanager.DiscountBaseFee(head.BaseFee, percentage)
+	}
+	if baseFee := pool.priced.urgent.baseFee; baseFee != nil {
+		explanation.EstimatedBaseFee = feemanager.DiscountBaseFee(baseFee, percentage)
+	}
+
+	// Remote transactions are ranked by the effective tip they pay at the
+	// estimated base fee, as when evicting the cheapest transactions.
+	if !explanation.Local {
+		ranker := priceHeap{
+			baseFee: pool.priced.urgent.baseFee,
+			feePercentage: func(other *types.Transaction) uint64 {
+				if other == tx {
+					return percentage
+				}
+				sender, _ := types.Sender(pool.signer, other) // already validated
+				return feePercentage(sender)
+			},
+		}
+		explanation.PriceRank = 1
+		pool.all.Range(func(_ common.Hash, other *types.Transaction, _ bool) bool {
+			explanation.PricedTxs++
+			if ranker.cmp(other, tx) > 0 {
+				explanation.PriceRank++
+			}
+			return true
+		}, false, true)
+	}
+
+	rules := pool.chainconfig.Rules(head.Number, params.IsMergeTODO, head.Time)
+	if params.GetRulesExtra(rules).IsPrecompileEnabled(txallowlist.ContractAddress) {
+		explanation.NotAllowListed = !txallowlist.GetTxAllowListStatus(statedb, from).IsEnabled()
+	}
+	return explanation
+}
+
 // Get returns a transaction if it is contained in the pool and nil otherwise.
 func (pool *LegacyPool) Get(hash common.Hash) *types.Transaction {
 	tx := pool.get(hash)
@@ -1195,6 +1396,7 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 	}
 	// Remove it from the list of known transactions
 	pool.all.Remove(hash)
+	pool.setConditional(hash, nil)
 	if outofbound {
 		pool.priced.Removed(1)
 	}
@@ -1234,6 +1436,101 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 	return 0
 }
 
+// AddConditional enqueues a local transaction into the pool if it is valid and
+// [conditional] holds at the current head. The transaction is evicted once the
+// conditions fail or expire.
+func (pool *LegacyPool) AddConditional(tx *types.Transaction, conditional *txpool.TransactionConditional) error {
+	if err := conditional.Validate(); err != nil {
+		return err
+	}
+	hash := tx.Hash()
+	pool.mu.Lock()
+	if pool.all.Get(hash) != nil {
+		pool.mu.Unlock()
+		knownTxMeter.Mark(1)
+		return txpool.ErrAlreadyKnown
+	}
+	head := pool.currentHead.Load()
+	if conditional.Expired(head.Number.Uint64()+1, head.Time) {
+		pool.mu.Unlock()
+		return txpool.ErrConditionalExpired
+	}
+	if err := conditional.CheckState(pool.currentState); err != nil {
+		pool.mu.Unlock()
+		return err
+	}
+	pool.setConditional(hash, conditional)
+	pool.mu.Unlock()
+
+	if err := pool.Add([]*types.Transaction{tx}, true, false)[0]; err != nil {
+		pool.setConditional(hash, nil)
+		return err
+	}
+	return nil
+}
+
+// Conditional returns the conditions of the transaction with the given hash, or
+// nil if it has none.
+func (pool *LegacyPool) Conditional(hash common.Hash) *txpool.TransactionConditional {
+	pool.conditionalsLock.RLock()
+	defer pool.conditionalsLock.RUnlock()
+
+	return pool.conditionals[hash]
+}
+
+// setConditional sets the conditions of the transaction with the given hash,
+// removing them if [conditional] is nil.
+func (pool *LegacyPool) setConditional(hash common.Hash, conditional *txpool.TransactionConditional) {
+	pool.conditionalsLock.Lock()
+	defer pool.conditionalsLock.Unlock()
+
+	if conditional == nil {
+		delete(pool.conditionals, hash)
+		return
+	}
+	pool.conditionals[hash] = conditional
+}
+
+// evictConditionals removes the transactions whose conditions fail or expired at
+// the current head, and forgets the conditions of the transactions that left the
+// pool. Assumes the lock is held.
+func (pool *LegacyPool) evictConditionals() {
+	pool.conditionalsLock.RLock()
+	conditionals := maps.Clone(pool.conditionals)
+	pool.conditionalsLock.RUnlock()
+
+	head := pool.currentHead.Load()
+	for hash, conditional := range conditionals {
+		if pool.all.Get(hash) == nil {
+			pool.setConditional(hash, nil)
+			continue
+		}
+		err := conditional.CheckState(pool.currentState)
+		if err == nil && conditional.Expired(head.Number.Uint64()+1, head.Time) {
+			err = txpool.ErrConditionalExpired
+		}
+		if err != nil {
+			log.Trace("Evicting transaction with failed conditional", "hash", hash, "err", err)
+			pool.removeTx(hash, true, true)
+			conditionalEvictionMeter.Mark(1)
+		}
+	}
+}
+
+// Remove drops the transaction with the given hash from the pool, returning
+// whether it was found. Any pending transaction of the same account with a
+// higher nonce is moved back to the queue.
+func (pool *LegacyPool) Remove(hash common.Hash) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.all.Get(hash) == nil {
+		return false
+	}
+	pool.removeTx(hash, true, true)
+	return true
+}
+
 // requestReset requests a pool reset to the new head block.
 // The returned channel is closed when the reset has occurred.
 func (pool *LegacyPool) requestReset(oldHead *types.Header, newHead *types.Header) chan struct{} {
@@ -1376,15 +1673,20 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	// because of another transaction (e.g. higher gas price).
 	if reset != nil {
 		pool.demoteUnexecutables()
-		if reset.newHead != nil {
-			if pool.chainconfig.IsLondon(reset.newHead.Number) {
-				if err := pool.updateBaseFeeAt(reset.newHead); err != nil {
-					log.Error("error at updating base fee in tx pool", "error", err)
-				}
+		pool.evictConditionals()
+		// The urgent heap is ordered by the fee percentages of the senders, whose cache
+		// was cleared by the reset, so it is rebuilt even if the base fee is not updated.
+		reheaped := false
+		if reset.newHead != nil && pool.chainconfig.IsLondon(reset.newHead.Number) {
+			if err := pool.updateBaseFeeAt(reset.newHead); err != nil {
+				log.Error("error at updating base fee in tx pool", "error", err)
 			} else {
-				pool.priced.Reheap()
+				reheaped = true
 			}
 		}
+		if !reheaped {
+			pool.priced.Reheap()
+		}
 		// Update all accounts to the latest known pending nonce
 		nonces := make(map[common.Address]uint64, len(pool.pending))
 		for addr, list := range pool.pending {
@@ -1510,6 +1812,9 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	pool.currentHead.Store(newHead)
 	pool.currentState = statedb
 	pool.pendingNonces = newNoncer(statedb)
+	pool.feePercentages = make(map[common.Address]uint64)
+	pool.feeTokenState = nil
+	pool.feeTokenSpendable = make(map[feeTokenAccount]*big.Int)
 
 	// when we reset txPool we should explicitly check if fee struct for min base fee has changed
 	// so that we can correctly drop txs with < minBaseFee from tx pool.
@@ -1550,8 +1855,10 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 			pool.all.Remove(hash)
 		}
 		log.Trace("Removed old queued transactions", "count", len(forwards))
-		// Drop all transactions that are too costly (low balance or out of gas)
+		// Drop all transactions that are too costly (low balance, low fee token balance or out of gas)
 		drops, _ := list.Filter(pool.currentState.GetBalance(addr), gasLimit)
+		tokenDrops, _ := list.FilterFeeTokens(pool.feeTokenCovers(addr))
+		drops = append(drops, tokenDrops...)
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
@@ -1751,8 +2058,11 @@ func (pool *LegacyPool) demoteUnexecutables() {
 			pool.all.Remove(hash)
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
-		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
+		// Drop all transactions that are too costly (low balance, low fee token balance or out of gas), and queue any invalids back for later
 		drops, invalids := list.Filter(pool.currentState.GetBalance(addr), gasLimit)
+		tokenDrops, tokenInvalids := list.FilterFeeTokens(pool.feeTokenCovers(addr))
+		drops = append(drops, tokenDrops...)
+		invalids = append(invalids, tokenInvalids...)
 		for _, tx := range drops {
 			hash := tx.Hash()
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
@@ -49,9 +49,14 @@ import (
 	"github.com/ava-labs/libevm/trie"
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/extstate"
 	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feetoken"
+	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/holiman/uint256"
 )
 
@@ -113,8 +118,9 @@ func (bc *testBlockChain) CurrentBlock() *types.Header {
 	defer bc.lock.Unlock()
 
 	header := &types.Header{
-		Number:   new(big.Int),
-		GasLimit: bc.gasLimit.Load(),
+		Number:     new(big.Int),
+		Difficulty: new(big.Int),
+		GasLimit:   bc.gasLimit.Load(),
 	}
 	if params.GetExtra(bc.config).IsGranite(0) {
 		customtypes.GetHeaderExtra(header).TimeMilliseconds = new(uint64)
@@ -407,6 +413,151 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// Tests that conditional transactions are only accepted if their conditions are
+// met, and are evicted once the state no longer meets them.
+func TestConditionalTransactions(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	var (
+		from     = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xc0ffee")
+		slot     = common.HexToHash("0x01")
+		value    = common.HexToHash("0x02")
+	)
+	testAddBalance(pool, from, big.NewInt(params.Ether))
+	pool.mu.Lock()
+	pool.currentState.SetState(contract, slot, value)
+	pool.mu.Unlock()
+
+	conditional := func(value common.Hash) *txpool.TransactionConditional {
+		return &txpool.TransactionConditional{
+			KnownAccounts: map[common.Address]txpool.KnownAccount{
+				contract: {StorageSlots: map[common.Hash]common.Hash{slot: value}},
+			},
+		}
+	}
+	tx0, tx1 := transaction(0, 100000, key), transaction(1, 100000, key)
+	if err, want := pool.AddConditional(tx0, conditional(common.Hash{})), txpool.ErrConditionalFailed; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	if err := pool.AddConditional(tx0, conditional(value)); err != nil {
+		t.Fatalf("failed to add conditional transaction: %v", err)
+	}
+	if err, want := pool.AddConditional(tx0, conditional(value)), txpool.ErrAlreadyKnown; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	if err := pool.addLocal(tx1); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if pool.Conditional(tx0.Hash()) == nil {
+		t.Error("conditional of tx0 missing")
+	}
+	if pool.Conditional(tx1.Hash()) != nil {
+		t.Error("unexpected conditional of tx1")
+	}
+	pending := pool.Pending(txpool.PendingFilter{})[from]
+	if len(pending) != 2 || pending[0].Conditional == nil || pending[1].Conditional != nil {
+		t.Fatalf("unexpected pending transactions: %v", pending)
+	}
+
+	// Once the state no longer meets the conditions, the conditional transaction
+	// is evicted and the transactions depending on it are queued.
+	pool.mu.Lock()
+	pool.currentState.SetState(contract, slot, common.Hash{})
+	pool.mu.Unlock()
+	<-pool.requestReset(nil, nil)
+
+	if pool.Has(tx0.Hash()) {
+		t.Error("conditional transaction not evicted")
+	}
+	if pool.Conditional(tx0.Hash()) != nil {
+		t.Error("conditional of evicted transaction not dropped")
+	}
+	if pending, queued := pool.Stats(); pending != 0 || queued != 1 {
+		t.Errorf("pending/queued mismatch: have %d/%d, want 0/1", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that pooled transactions are explained with their status, nonce gap and
+// rank by price.
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupPool()
+	defer pool.Close()
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(params.Ether))
+	}
+	var (
+		pending = pricedTransaction(0, 100000, big.NewInt(2), keys[0])
+		queued  = pricedTransaction(3, 100000, big.NewInt(5), keys[0])
+		other   = pricedTransaction(0, 100000, big.NewInt(3), keys[1])
+		local   = pricedTransaction(0, 100000, big.NewInt(1), keys[2])
+	)
+	for _, err := range pool.addRemotesSync([]*types.Transaction{pending, queued, other}) {
+		if err != nil {
+			t.Fatalf("failed to add remote transaction: %v", err)
+		}
+	}
+	if err := pool.addLocal(local); err != nil {
+		t.Fatalf("failed to add local transaction: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		tx   *types.Transaction
+		want txpool.TxExplanation
+	}{
+		{
+			name: "pending",
+			tx:   pending,
+			want: txpool.TxExplanation{Status: txpool.TxStatusPending, Nonce: 0, NextNonce: 1, PriceRank: 3, PricedTxs: 3},
+		},
+		{
+			name: "queued",
+			tx:   queued,
+			want: txpool.TxExplanation{Status: txpool.TxStatusQueued, Nonce: 3, NextNonce: 1, NonceGap: 2, PriceRank: 1, PricedTxs: 3},
+		},
+		{
+			name: "other account",
+			tx:   other,
+			want: txpool.TxExplanation{Status: txpool.TxStatusPending, Nonce: 0, NextNonce: 1, PriceRank: 2, PricedTxs: 3},
+		},
+		{
+			name: "local",
+			tx:   local,
+			want: txpool.TxExplanation{Status: txpool.TxStatusPending, Local: true, Nonce: 0, NextNonce: 1},
+		},
+	}
+	for _, test := range tests {
+		got := pool.Explain(test.tx.Hash())
+		if got == nil {
+			t.Errorf("%s: transaction not explained", test.name)
+			continue
+		}
+		test.want.GasFeeCap = test.tx.GasFeeCap()
+		if got.Status != test.want.Status || got.Local != test.want.Local ||
+			got.Nonce != test.want.Nonce || got.NextNonce != test.want.NextNonce || got.NonceGap != test.want.NonceGap ||
+			got.GasFeeCap.Cmp(test.want.GasFeeCap) != 0 ||
+			got.PriceRank != test.want.PriceRank || got.PricedTxs != test.want.PricedTxs ||
+			got.NotAllowListed {
+			t.Errorf("%s: explanation mismatch: have %+v, want %+v", test.name, got, test.want)
+		}
+	}
+	if got := pool.Explain(common.Hash{1}); got != nil {
+		t.Errorf("unknown transaction explained: %+v", got)
+	}
+}
+
 func TestQueue(t *testing.T) {
 	t.Parallel()
 
@@ -2724,3 +2875,136 @@ func BenchmarkMultiAccountBatchInsert(b *testing.B) {
 		pool.addRemotesSync([]*types.Transaction{tx})
 	}
 }
+
+// Tests that senders with a fee discount only have to cover their share of the
+// minimum fee, and that the price heap orders transactions by the tips paid on top
+// of the base fee charged to their sender, including after the discounts change.
+func TestFeeDiscount(t *testing.T) {
+	t.Parallel()
+
+	config := params.Copy(params.TestHeliconChainConfig)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		feemanager.ConfigKey: feemanager.NewConfig(utils.NewUint64(0), nil, nil, nil, nil),
+	}
+	pool, discountedKey := setupPoolWithConfig(&config)
+	defer pool.Close()
+	fullKey, _ := crypto.GenerateKey()
+	discounted := crypto.PubkeyToAddress(discountedKey.PublicKey)
+	full := crypto.PubkeyToAddress(fullKey.PublicKey)
+
+	pool.mu.Lock()
+	feemanager.SetFeePercentage(extstate.New(pool.currentState), discounted, 50)
+	pool.mu.Unlock()
+	testAddBalance(pool, discounted, big.NewInt(params.Ether))
+	testAddBalance(pool, full, big.NewInt(params.Ether))
+
+	gwei := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.GWei)) }
+	pool.SetMinFee(gwei(25))
+	discountedTx := dynamicFeeTx(0, 100_000, gwei(20), gwei(20), discountedKey)
+	if err := pool.addRemoteSync(discountedTx); err != nil {
+		t.Fatalf("failed to add discounted transaction below the minimum fee: %v", err)
+	}
+	if err := pool.addRemoteSync(dynamicFeeTx(0, 100_000, gwei(20), gwei(20), fullKey)); !errors.Is(err, txpool.ErrUnderpriced) {
+		t.Fatalf("adding underpriced transaction error mismatch: have %v, want %v", err, txpool.ErrUnderpriced)
+	}
+	fullTx := dynamicFeeTx(0, 100_000, gwei(30), gwei(30), fullKey)
+	if err := pool.addRemoteSync(fullTx); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+
+	// With a base fee of 28 gwei, the discounted transaction tips 20-14 gwei and the
+	// other one 30-28 gwei, so the latter is the cheapest.
+	pool.mu.Lock()
+	pool.priced.SetBaseFee(gwei(28))
+	cheapest := pool.priced.urgent.list[0]
+	pool.mu.Unlock()
+	if cheapest.Hash() != fullTx.Hash() {
+		t.Fatalf("cheapest transaction mismatch: have %x, want %x", cheapest.Hash(), fullTx.Hash())
+	}
+
+	// Discounting the other sender more makes the discounted transaction the cheapest
+	// once the pool is reset to the new state.
+	pool.mu.Lock()
+	feemanager.SetFeePercentage(extstate.New(pool.currentState), full, 10)
+	pool.mu.Unlock()
+	<-pool.requestReset(nil, nil)
+	pool.mu.Lock()
+	cheapest = pool.priced.urgent.list[0]
+	pool.mu.Unlock()
+	if cheapest.Hash() != discountedTx.Hash() {
+		t.Fatalf("cheapest transaction mismatch after reset: have %x, want %x", cheapest.Hash(), discountedTx.Hash())
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+func TestFeeTokenExpenditure(t *testing.T) {
+	t.Parallel()
+
+	config := params.Copy(params.TestHeliconChainConfig)
+	params.GetExtra(&config).GenesisPrecompiles = extras.Precompiles{
+		feetoken.ConfigKey: feetoken.NewConfig(utils.NewUint64(0), nil, nil, nil, nil),
+	}
+	pool, key := setupPoolWithConfig(&config)
+	defer pool.Close()
+
+	var (
+		token = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		gwei  = big.NewInt(params.GWei)
+		// tokenCode returns the amount in its first storage slot as the balance and
+		// allowance of every account.
+		tokenCode = common.FromHex("0x60005460005260206000f3")
+		gasLimit  = uint64(400_000)
+		// The token has the same value as the native coin, so each transaction
+		// needs [txFee] of it.
+		txFee = new(big.Int).Mul(gwei, new(big.Int).SetUint64(gasLimit))
+	)
+	setSpendable := func(amount *big.Int) {
+		pool.mu.Lock()
+		pool.currentState.SetCode(token, tokenCode)
+		pool.currentState.SetState(token, common.Hash{}, common.BigToHash(amount))
+		feetoken.SetFeeTokenRate(extstate.New(pool.currentState), token, feetoken.RateDenominator)
+		pool.mu.Unlock()
+	}
+	newTx := func(nonce uint64) *types.Transaction {
+		tx, _ := types.SignNewTx(key, types.LatestSignerForChainID(config.ChainID), &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     nonce,
+			GasTipCap: gwei,
+			GasFeeCap: gwei,
+			Gas:       gasLimit,
+			To:        &common.Address{},
+			AccessList: types.AccessList{{
+				Address:     feetoken.ContractAddress,
+				StorageKeys: []common.Hash{common.BytesToHash(token.Bytes())},
+			}},
+		})
+		return tx
+	}
+	setSpendable(new(big.Int).Mul(txFee, big.NewInt(2)))
+	<-pool.requestReset(nil, nil)
+	pool.SetMinFee(common.Big1)
+
+	// The token covers the gas fees of two transactions.
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		if err := pool.addRemoteSync(newTx(nonce)); err != nil {
+			t.Fatalf("failed to add transaction %d: %v", nonce, err)
+		}
+	}
+	if err := pool.addRemoteSync(newTx(2)); !errors.Is(err, core.ErrInsufficientFeeToken) {
+		t.Fatalf("adding transaction over the fee token balance error mismatch: have %v, want %v", err, core.ErrInsufficientFeeToken)
+	}
+
+	// Once the token only covers the gas fees of one transaction, the other one is
+	// dropped.
+	setSpendable(txFee)
+	<-pool.requestReset(nil, nil)
+	pending, queued := pool.Stats()
+	if pending != 1 || queued != 0 {
+		t.Fatalf("pool stats mismatch: have %d pending and %d queued, want 1 and 0", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
@@ -38,6 +38,8 @@ import (
 
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
 	"github.com/holiman/uint256"
 	"golang.org/x/exp/slices"
 )
@@ -163,6 +165,74 @@ func (m *sortedMap) filter(filter func(*types.Transaction) bool) types.Transacti
 	return removed
 }
 
+// FilterFeeTokens removes all transactions from the list paying their gas fees in
+// a fee token that the account cannot cover along with the transactions with lower
+// nonces paying them in the same token, as reported by [covers] for the cumulative
+// gas fees in wei. Every removed transaction is returned for any post-removal
+// maintenance. Strict-mode invalidated transactions are also returned.
+func (l *list) FilterFeeTokens(covers func(token common.Address, gasFees *big.Int) bool) (types.Transactions, types.Transactions) {
+	// If no transaction pays its gas fees in a fee token, short circuit
+	if len(l.tokencosts) == 0 {
+		return nil, nil
+	}
+	var (
+		spent   = make(map[common.Address]*big.Int)
+		uncover = make(map[uint64]struct{})
+	)
+	for _, tx := range l.txs.Flatten() {
+		token, gasFee, ok := core.TxFeeTokenCost(tx)
+		if !ok {
+			continue
+		}
+		total := new(big.Int).Set(gasFee)
+		if prev, ok := spent[token]; ok {
+			total.Add(total, prev)
+		}
+		if !covers(token, total) {
+			uncover[tx.Nonce()] = struct{}{}
+			continue
+		}
+		spent[token] = total
+	}
+	if len(uncover) == 0 {
+		return nil, nil
+	}
+	removed := l.txs.Filter(func(tx *types.Transaction) bool {
+		_, ok := uncover[tx.Nonce()]
+		return ok
+	})
+	invalids := l.invalidated(removed)
+	// Reset total cost
+	l.subTotalCost(removed)
+	l.subTotalCost(invalids)
+	l.txs.reheap()
+	return removed, invalids
+}
+
+// invalidated removes and returns the transactions invalidated by the removal of
+// [removed] if the list is strict, that is all of those above the lowest nonce.
+func (l *list) invalidated(removed types.Transactions) types.Transactions {
+	if !l.strict {
+		return nil
+	}
+	lowest := uint64(math.MaxUint64)
+	for _, tx := range removed {
+		if nonce := tx.Nonce(); lowest > nonce {
+			lowest = nonce
+		}
+	}
+	return l.txs.filter(func(tx *types.Transaction) bool { return tx.Nonce() > lowest })
+}
+
+// FeeTokenCost returns the total gas fees in wei of the transactions in the list
+// paying them in [token].
+func (l *list) FeeTokenCost(token common.Address) *uint256.Int {
+	if total, ok := l.tokencosts[token]; ok {
+		return new(uint256.Int).Set(total)
+	}
+	return new(uint256.Int)
+}
+
 // Cap places a hard limit on the number of items, returning all transactions
 // exceeding that limit.
 func (m *sortedMap) Cap(threshold int) types.Transactions {
@@ -287,16 +357,21 @@ type list struct {
 	costcap   *uint256.Int // Price of the highest costing transaction (reset only if exceeds balance)
 	gascap    uint64       // Gas limit of the highest spending transaction (reset only if exceeds block limit)
 	totalcost *uint256.Int // Total cost of all transactions in the list
+
+	// tokencosts holds the total gas fees in wei of the transactions in the list
+	// paying them in each fee token, which their native cost excludes.
+	tokencosts map[common.Address]*uint256.Int
 }
 
 // newList creates a new transaction list for maintaining nonce-indexable fast,
 // gapped, sortable transaction lists.
 func newList(strict bool) *list {
 	return &list{
-		strict:    strict,
-		txs:       newSortedMap(),
-		costcap:   new(uint256.Int),
-		totalcost: new(uint256.Int),
+		strict:     strict,
+		txs:        newSortedMap(),
+		costcap:    new(uint256.Int),
+		totalcost:  new(uint256.Int),
+		tokencosts: make(map[common.Address]*uint256.Int),
 	}
 }
 
@@ -338,11 +413,25 @@ func (l *list) Add(tx *types.Transaction, priceBump uint64) (bool, *types.Transa
 		l.subTotalCost([]*types.Transaction{old})
 	}
 	// Add new tx cost to totalcost
-	cost, overflow := uint256.FromBig(tx.Cost())
+	cost, overflow := uint256.FromBig(core.TxNativeCost(tx))
 	if overflow {
 		return false, nil
 	}
+	var tokenCost *uint256.Int
+	token, gasFee, paysInToken := core.TxFeeTokenCost(tx)
+	if paysInToken {
+		if tokenCost, overflow = uint256.FromBig(gasFee); overflow {
+			return false, nil
+		}
+	}
 	l.totalcost.Add(l.totalcost, cost)
+	if tokenCost != nil && !tokenCost.IsZero() {
+		if total, ok := l.tokencosts[token]; ok {
+			total.Add(total, tokenCost)
+		} else {
+			l.tokencosts[token] = tokenCost
+		}
+	}
 
 	// Otherwise overwrite the old transaction with the current one
 	l.txs.Put(tx)
@@ -383,23 +472,13 @@ func (l *list) Filter(costLimit *uint256.Int, gasLimit uint64) (types.Transactio
 
 	// Filter out all the transactions above the account's funds
 	removed := l.txs.Filter(func(tx *types.Transaction) bool {
-		return tx.Gas() > gasLimit || tx.Cost().Cmp(costLimit.ToBig()) > 0
+		return tx.Gas() > gasLimit || core.TxNativeCost(tx).Cmp(costLimit.ToBig()) > 0
 	})
 
 	if len(removed) == 0 {
 		return nil, nil
 	}
-	var invalids types.Transactions
-	// If the list was strict, filter anything above the lowest nonce
-	if l.strict {
-		lowest := uint64(math.MaxUint64)
-		for _, tx := range removed {
-			if nonce := tx.Nonce(); lowest > nonce {
-				lowest = nonce
-			}
-		}
-		invalids = l.txs.filter(func(tx *types.Transaction) bool { return tx.Nonce() > lowest })
-	}
+	invalids := l.invalidated(removed)
 	// Reset total cost
 	l.subTotalCost(removed)
 	l.subTotalCost(invalids)
@@ -474,10 +553,24 @@ func (l *list) LastElement() *types.Transaction {
 // total cost of all transactions.
 func (l *list) subTotalCost(txs []*types.Transaction) {
 	for _, tx := range txs {
-		_, underflow := l.totalcost.SubOverflow(l.totalcost, uint256.MustFromBig(tx.Cost()))
+		_, underflow := l.totalcost.SubOverflow(l.totalcost, uint256.MustFromBig(core.TxNativeCost(tx)))
 		if underflow {
 			panic("totalcost underflow")
 		}
+		token, gasFee, ok := core.TxFeeTokenCost(tx)
+		if !ok || gasFee.Sign() == 0 {
+			continue
+		}
+		total := l.tokencosts[token]
+		if total == nil {
+			panic("tokencost underflow")
+		}
+		if _, underflow := total.SubOverflow(total, uint256.MustFromBig(gasFee)); underflow {
+			panic("tokencost underflow")
+		}
+		if total.IsZero() {
+			delete(l.tokencosts, token)
+		}
 	}
 }
 
@@ -485,9 +578,13 @@ func (l *list) subTotalCost(txs []*types.Transaction) {
 // price-sorted transactions to discard when the pool fills up. If baseFee is set
 // then the heap is sorted based on the effective tip based on the given base fee.
 // If baseFee is nil then the sorting is based on gasFeeCap.
+//
+// If feePercentage is set, the effective tip of each transaction is based on the
+// share of the base fee charged to its sender.
 type priceHeap struct {
-	baseFee *big.Int // heap should always be re-sorted after baseFee is changed
-	list    []*types.Transaction
+	baseFee       *big.Int // heap should always be re-sorted after baseFee is changed
+	feePercentage func(tx *types.Transaction) uint64
+	list          []*types.Transaction
 }
 
 func (h *priceHeap) Len() int      { return len(h.list) }
@@ -507,7 +604,13 @@ func (h *priceHeap) Less(i, j int) bool {
 func (h *priceHeap) cmp(a, b *types.Transaction) int {
 	if h.baseFee != nil {
 		// Compare effective tips if baseFee is specified
-		if c := a.EffectiveGasTipCmp(b, h.baseFee); c != 0 {
+		if h.feePercentage != nil {
+			tipA := a.EffectiveGasTipValue(feemanager.DiscountBaseFee(h.baseFee, h.feePercentage(a)))
+			tipB := b.EffectiveGasTipValue(feemanager.DiscountBaseFee(h.baseFee, h.feePercentage(b)))
+			if c := tipA.Cmp(tipB); c != 0 {
+				return c
+			}
+		} else if c := a.EffectiveGasTipCmp(b, h.baseFee); c != 0 {
 			return c
 		}
 	}
@@ -560,10 +663,14 @@ const (
 )
 
 // newPricedList creates a new price-sorted transaction heap.
-func newPricedList(all *lookup) *pricedList {
-	return &pricedList{
+// If [feePercentage] is not nil, the urgent heap accounts for the fee discount
+// of the sender of each transaction.
+func newPricedList(all *lookup, feePercentage func(tx *types.Transaction) uint64) *pricedList {
+	l := &pricedList{
 		all: all,
 	}
+	l.urgent.feePercentage = feePercentage
+	return l
 }
 
 // Put inserts a new transaction into the heap.
@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+
+	"github.com/ava-labs/subnet-evm/core"
+)
+
+// privateTx is the private mark of a transaction added with [TxPool.AddPrivate].
+type privateTx struct {
+	expiry time.Time
+	// adding is set while the transaction is being added to the pool, so that it
+	// is not forgotten by [TxPool.ExpirePrivate] before it is in the pool.
+	adding bool
+}
+
+// AddPrivate enqueues a local transaction into the pool, marking it private
+// until [expiry]. Private transactions are included in the blocks built by this
+// node like any other transaction, but must never be gossiped to peers, which
+// callers check with [TxPool.IsPrivate].
+func (p *TxPool) AddPrivate(tx *types.Transaction, expiry time.Time) error {
+	// Blob transactions cannot be removed from the blob pool on expiry.
+	if tx.Type() == types.BlobTxType {
+		return core.ErrTxTypeNotSupported
+	}
+	// Mark the transaction before adding it, so that it is never seen in the pool
+	// before being marked private. The lock must not be held while adding it, as
+	// the gossiper checks the mark while holding the locks of the subpools.
+	hash := tx.Hash()
+	mark := &privateTx{expiry: expiry, adding: true}
+	p.privateLock.Lock()
+	prev, known := p.private[hash]
+	p.private[hash] = mark
+	p.privateLock.Unlock()
+
+	err := p.Add([]*types.Transaction{tx}, true, false)[0]
+
+	p.privateLock.Lock()
+	defer p.privateLock.Unlock()
+
+	// Leave the mark alone if a concurrent call replaced it.
+	if p.private[hash] != mark {
+		return err
+	}
+	switch {
+	case err == nil:
+		mark.adding = false
+	case known:
+		p.private[hash] = prev
+	default:
+		delete(p.private, hash)
+	}
+	return err
+}
+
+// IsPrivate returns whether the transaction with the given hash was added with
+// [TxPool.AddPrivate] and has not been returned by [TxPool.ExpirePrivate] yet.
+func (p *TxPool) IsPrivate(hash common.Hash) bool {
+	p.privateLock.RLock()
+	defer p.privateLock.RUnlock()
+
+	_, ok := p.private[hash]
+	return ok
+}
+
+// ExpirePrivate unmarks the private transactions expired at [now] and returns
+// the ones that were still in the pool. If [release] is set, they stay in the
+// pool and the caller must release them to public gossip. Otherwise, they are
+// removed from the pool before being unmarked, so that they are never gossiped.
+// Private transactions that left the pool are forgotten.
+func (p *TxPool) ExpirePrivate(now time.Time, release bool) []*types.Transaction {
+	// The pool is looked up without holding the lock, for the same reason as in
+	// [TxPool.AddPrivate].
+	p.privateLock.RLock()
+	marks := make(map[common.Hash]*privateTx, len(p.private))
+	for hash, mark := range p.private {
+		if !mark.adding {
+			marks[hash] = mark
+		}
+	}
+	p.privateLock.RUnlock()
+
+	txs := make(map[common.Hash]*types.Transaction, len(marks))
+	for hash := range marks {
+		txs[hash] = p.Get(hash)
+	}
+
+	var expired []*types.Transaction
+	for hash, mark := range marks {
+		if tx := txs[hash]; tx != nil && !now.Before(mark.expiry) {
+			expired = append(expired, tx)
+		}
+	}
+	// The expired transactions are still marked while they are removed, and the
+	// lock must not be held, for the same reason as in [TxPool.AddPrivate].
+	if !release {
+		for _, tx := range expired {
+			p.Remove(tx.Hash())
+		}
+	}
+
+	p.privateLock.Lock()
+	defer p.privateLock.Unlock()
+
+	for hash, mark := range marks {
+		// Skip the transactions marked again since they were looked up.
+		if p.private[hash] != mark {
+			continue
+		}
+		if txs[hash] == nil || !now.Before(mark.expiry) {
+			delete(p.private, hash)
+		}
+	}
+	return expired
+}
+
+// Remove drops the transaction with the given hash from the pool, returning
+// whether it was found.
+func (p *TxPool) Remove(hash common.Hash) bool {
+	for _, subpool := range p.subpools {
+		if subpool.Remove(hash) {
+			return true
+		}
+	}
+	return false
+}
@@ -50,6 +50,8 @@ type LazyTransaction struct {
 	GasFeeCap *uint256.Int // Maximum fee per gas the transaction may consume
 	GasTipCap *uint256.Int // Maximum miner tip per gas the transaction can pay
 
+	Conditional *TransactionConditional // Conditions for including the transaction, if any
+
 	Gas     uint64 // Amount of gas required by the transaction
 	BlobGas uint64 // Amount of blob gas required by the transaction
 }
@@ -141,6 +143,18 @@ type SubPool interface {
 	// to a later point to batch multiple ones together.
 	Add(txs []*types.Transaction, local bool, sync bool) []error
 
+	// AddConditional enqueues a local transaction into the subpool, to be
+	// included only while [conditional] holds.
+	AddConditional(tx *types.Transaction, conditional *TransactionConditional) error
+
+	// Conditional returns the conditions of the transaction with the given hash,
+	// or nil if it has none.
+	Conditional(hash common.Hash) *TransactionConditional
+
+	// Remove drops the transaction with the given hash from the subpool,
+	// returning whether it was found.
+	Remove(hash common.Hash) bool
+
 	// Pending retrieves all currently processable transactions, grouped by origin
 	// account and sorted by nonce.
 	//
@@ -176,4 +190,8 @@ type SubPool interface {
 	// Status returns the known status (unknown/pending/queued) of a transaction
 	// identified by their hashes.
 	Status(hash common.Hash) TxStatus
+
+	// Explain describes the transaction with the given hash and what may keep it
+	// from being included, or returns nil if the transaction is not in the pool.
+	Explain(hash common.Hash) *TxExplanation
 }
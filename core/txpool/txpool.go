@@ -95,6 +95,11 @@ type TxPool struct {
 
 	gasTip    atomic.Pointer[big.Int] // Remember last value set so it can be retrieved
 	reorgFeed event.Feed
+
+	private     map[common.Hash]*privateTx // Marks of the private transactions, see [TxPool.AddPrivate]
+	privateLock sync.RWMutex               // Lock protecting the private transactions
+
+	admission atomic.Pointer[admissionLimiter] // Admission rate limits, see [TxPool.SetAdmissionLimits]
 }
 
 // New creates a new transaction pool to gather, sort and filter inbound
@@ -111,6 +116,7 @@ func New(gasTip uint64, chain BlockChain, subpools []SubPool) (*TxPool, error) {
 		quit:         make(chan chan error),
 		term:         make(chan struct{}),
 		sync:         make(chan chan error),
+		private:      make(map[common.Hash]*privateTx),
 	}
 	pool.gasTip.Store(new(big.Int).SetUint64(gasTip))
 
@@ -371,9 +377,15 @@ func (p *TxPool) Add(txs []*types.Transaction, local bool, sync bool) []error {
 	txsets := make([][]*types.Transaction, len(p.subpools))
 	splits := make([]int, len(txs))
 
+	// Reject the transactions of the senders exceeding their admission rate
+	// limit before handing any of them to the subpools.
+	errs, senders := p.admitSenders(txs)
 	for i, tx := range txs {
 		// Mark this transaction belonging to no-subpool
 		splits[i] = -1
+		if errs[i] != nil {
+			continue
+		}
 
 		// Try to find a subpool that accepts the transaction
 		for j, subpool := range p.subpools {
@@ -390,8 +402,10 @@ func (p *TxPool) Add(txs []*types.Transaction, local bool, sync bool) []error {
 	for i := 0; i < len(p.subpools); i++ {
 		errsets[i] = p.subpools[i].Add(txsets[i], local, sync)
 	}
-	errs := make([]error, len(txs))
 	for i, split := range splits {
+		if errs[i] != nil {
+			continue
+		}
 		// If the transaction was rejected by all subpools, mark it unsupported
 		if split == -1 {
 			errs[i] = core.ErrTxTypeNotSupported
@@ -401,9 +415,39 @@ func (p *TxPool) Add(txs []*types.Transaction, local bool, sync bool) []error {
 		errs[i] = errsets[split][0]
 		errsets[split] = errsets[split][1:]
 	}
+	p.chargeSenders(senders, errs)
 	return errs
 }
 
+// AddConditional enqueues a local transaction into the subpool accepting it, to
+// be included only while [conditional] holds.
+func (p *TxPool) AddConditional(tx *types.Transaction, conditional *TransactionConditional) error {
+	errs, senders := p.admitSenders([]*types.Transaction{tx})
+	if errs[0] != nil {
+		return errs[0]
+	}
+	errs[0] = core.ErrTxTypeNotSupported
+	for _, subpool := range p.subpools {
+		if subpool.Filter(tx) {
+			errs[0] = subpool.AddConditional(tx, conditional)
+			break
+		}
+	}
+	p.chargeSenders(senders, errs)
+	return errs[0]
+}
+
+// Conditional returns the conditions of the transaction with the given hash, or
+// nil if it has none.
+func (p *TxPool) Conditional(hash common.Hash) *TransactionConditional {
+	for _, subpool := range p.subpools {
+		if conditional := subpool.Conditional(hash); conditional != nil {
+			return conditional
+		}
+	}
+	return nil
+}
+
 func (p *TxPool) AddRemotesSync(txs []*types.Transaction) []error {
 	return p.Add(txs, false, true)
 }
@@ -42,15 +42,10 @@ import (
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/plugin/evm/vmerrors"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feetoken"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 )
 
-var (
-	// blobTxMinBlobGasPrice is the big.Int version of the configured protocol
-	// parameter to avoid constucting a new big integer for every transaction.
-	blobTxMinBlobGasPrice = big.NewInt(ethparams.BlobTxMinBlobGasprice)
-)
-
 // ValidationOptions define certain differences between transaction validation
 // across the different pools without having to duplicate those checks.
 type ValidationOptions struct {
@@ -87,6 +82,9 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if !opts.Config.IsCancun(head.Number, head.Time) && tx.Type() == types.BlobTxType {
 		return fmt.Errorf("%w: type %d rejected, pool not yet in Cancun", core.ErrTxTypeNotSupported, tx.Type())
 	}
+	if !params.GetExtra(opts.Config).IsBlobEnabled(head.Time) && tx.Type() == types.BlobTxType {
+		return fmt.Errorf("%w: type %d rejected, blob transactions not enabled", core.ErrTxTypeNotSupported, tx.Type())
+	}
 	// Check whether the init code size has been exceeded
 	if opts.Config.IsShanghai(head.Number, head.Time) && tx.To() == nil && len(tx.Data()) > ethparams.MaxInitCodeSize {
 		return fmt.Errorf("%w: code size %v, limit %v", vm.ErrMaxInitCodeSizeExceeded, len(tx.Data()), ethparams.MaxInitCodeSize)
@@ -136,8 +134,10 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	}
 	if tx.Type() == types.BlobTxType {
 		// Ensure the blob fee cap satisfies the minimum blob gas price
-		if tx.BlobGasFeeCapIntCmp(blobTxMinBlobGasPrice) < 0 {
-			return fmt.Errorf("%w: blob fee cap %v, minimum needed %v", ErrUnderpriced, tx.BlobGasFeeCap(), blobTxMinBlobGasPrice)
+		blobConfig := params.GetExtra(opts.Config).BlobConfig
+		minBlobGasPrice := new(big.Int).SetUint64(blobConfig.MinBlobGasPrice)
+		if tx.BlobGasFeeCapIntCmp(minBlobGasPrice) < 0 {
+			return fmt.Errorf("%w: blob fee cap %v, minimum needed %v", ErrUnderpriced, tx.BlobGasFeeCap(), minBlobGasPrice)
 		}
 		sidecar := tx.BlobTxSidecar()
 		if sidecar == nil {
@@ -149,18 +149,20 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 		if len(hashes) == 0 {
 			return fmt.Errorf("blobless blob transaction")
 		}
-		if len(hashes) > ethparams.MaxBlobGasPerBlock/ethparams.BlobTxBlobGasPerBlob {
-			return fmt.Errorf("too many blobs in transaction: have %d, permitted %d", len(hashes), ethparams.MaxBlobGasPerBlock/ethparams.BlobTxBlobGasPerBlob)
+		if maxBlobs := blobConfig.MaxBlobGasPerBlock / ethparams.BlobTxBlobGasPerBlob; uint64(len(hashes)) > maxBlobs {
+			return fmt.Errorf("too many blobs in transaction: have %d, permitted %d", len(hashes), maxBlobs)
 		}
 		// Ensure commitments, proofs and hashes are valid
-		if err := validateBlobSidecar(hashes, sidecar); err != nil {
+		if err := ValidateBlobSidecar(hashes, sidecar); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func validateBlobSidecar(hashes []common.Hash, sidecar *types.BlobTxSidecar) error {
+// ValidateBlobSidecar checks that [sidecar] holds one blob for each of the
+// versioned [hashes], committed to by its commitments and proofs.
+func ValidateBlobSidecar(hashes []common.Hash, sidecar *types.BlobTxSidecar) error {
 	if len(sidecar.Blobs) != len(hashes) {
 		return fmt.Errorf("invalid number of %d blobs compared to %d blob hashes", len(sidecar.Blobs), len(hashes))
 	}
@@ -213,6 +215,22 @@ type ValidationOptionsWithState struct {
 	// transaction's cost with the given nonce to check for overdrafts.
 	ExistingCost func(addr common.Address, nonce uint64) *big.Int
 
+	// FeeTokenSpendable is an optional callback to retrieve the amount of a fee
+	// token the fee token precompile can escrow from an account. If this method
+	// is not set, the fee token balances of senders will not be checked.
+	FeeTokenSpendable func(token common.Address, addr common.Address) (*big.Int, error)
+
+	// ExistingFeeTokenExpenditure is an optional callback to retrieve the cumulative
+	// gas fees in wei the already pooled transactions of an account pay in a fee
+	// token, to check for overdrafts of the fee token. If this method is set,
+	// ExistingFeeTokenCost must be set too.
+	ExistingFeeTokenExpenditure func(addr common.Address, token common.Address) *big.Int
+
+	// ExistingFeeTokenCost is an optional callback to retrieve the fee token an
+	// already pooled transaction with the given nonce pays its gas fees in, and
+	// their amount in wei, or nil if there is none paying them in a fee token.
+	ExistingFeeTokenCost func(addr common.Address, nonce uint64) (common.Address, *big.Int)
+
 	Rules      params.Rules
 	MinimumFee *big.Int
 }
@@ -249,8 +267,11 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 	// Ensure the transactor has enough funds to cover the transaction costs
 	var (
 		balance = opts.State.GetBalance(from).ToBig()
-		cost    = tx.Cost()
+		cost    = core.TxNativeCost(tx)
 	)
+	if err := validateFeeToken(tx, from, opts); err != nil {
+		return err
+	}
 	if balance.Cmp(cost) < 0 {
 		return fmt.Errorf("%w: balance %v, tx cost %v, overshot %v", core.ErrInsufficientFunds, balance, cost, new(big.Int).Sub(cost, balance))
 	}
@@ -286,3 +307,42 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 
 	return nil
 }
+
+// validateFeeToken checks that the sender of [tx] can pay its gas fees in the fee
+// token it opts in to, if any, along with the gas fees its other pooled transactions
+// pay in the same token.
+func validateFeeToken(tx *types.Transaction, from common.Address, opts *ValidationOptionsWithState) error {
+	token, gasFee, ok := core.TxFeeTokenCost(tx)
+	if !ok {
+		return nil
+	}
+	// The native cost of the transaction excludes its gas fees, so it cannot be
+	// pooled unless it pays them in the fee token.
+	if !params.GetRulesExtra(opts.Rules).IsPrecompileEnabled(feetoken.ContractAddress) {
+		return fmt.Errorf("%w: fee token precompile is not enabled", core.ErrFeeTokenNotApproved)
+	}
+	rate := feetoken.GetFeeTokenRate(opts.State, token)
+	if rate.Sign() == 0 {
+		return fmt.Errorf("%w: token %v", core.ErrFeeTokenNotApproved, token.Hex())
+	}
+	if opts.FeeTokenSpendable == nil {
+		return nil
+	}
+	spendable, err := opts.FeeTokenSpendable(token, from)
+	if err != nil {
+		return fmt.Errorf("%w: token %v: %w", core.ErrInsufficientFeeToken, token.Hex(), err)
+	}
+	spent := new(big.Int)
+	if opts.ExistingFeeTokenExpenditure != nil {
+		spent.Set(opts.ExistingFeeTokenExpenditure(from, token))
+		// A replaced transaction paying its gas fees in the same token does not
+		// add to the gas fees of the new one.
+		if prevToken, prev := opts.ExistingFeeTokenCost(from, tx.Nonce()); prev != nil && prevToken == token {
+			spent.Sub(spent, prev)
+		}
+	}
+	if want := feetoken.TokenAmount(new(big.Int).Add(spent, gasFee), rate); spendable.Cmp(want) < 0 {
+		return fmt.Errorf("%w: token %v, have %v, want %v, pooled gas fees %v", core.ErrInsufficientFeeToken, token.Hex(), spendable, want, spent)
+	}
+	return nil
+}
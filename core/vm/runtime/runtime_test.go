@@ -250,6 +250,11 @@ func (d *dummyChain) Engine() consensus.Engine {
 	return nil
 }
 
+// Config returns nil, as the chain config is not needed by the tests.
+func (d *dummyChain) Config() *params.ChainConfig {
+	return nil
+}
+
 // GetHeader returns the hash corresponding to their hash.
 func (d *dummyChain) GetHeader(h common.Hash, n uint64) *types.Header {
 	d.counter++
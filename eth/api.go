@@ -28,7 +28,22 @@
 package eth
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto/kzg4844"
+	"github.com/ava-labs/libevm/log"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/core/bundlepool"
+	"github.com/ava-labs/subnet-evm/eth/gasprice"
+	"github.com/ava-labs/subnet-evm/internal/ethapi"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
+	"github.com/ava-labs/subnet-evm/rpc"
 )
 
 // EthereumAPI provides an API to access Ethereum full node-related information.
@@ -50,3 +65,272 @@ func (api *EthereumAPI) Etherbase() (common.Address, error) {
 func (api *EthereumAPI) Coinbase() (common.Address, error) {
 	return api.Etherbase()
 }
+
+// SendPrivateRawTransaction adds the signed transaction to the transaction pool
+// for inclusion only by the blocks built by this node. The transaction is never
+// gossiped to peers until it expires, after which it is dropped from the pool,
+// or released to public gossip if enabled in the node config.
+func (api *EthereumAPI) SendPrivateRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	return ethapi.SubmitTransaction(ctx, privateTxBackend{api.e.APIBackend}, tx)
+}
+
+// SendBundleArgs are the arguments of eth_sendBundle.
+type SendBundleArgs struct {
+	Txs          []hexutil.Bytes `json:"txs"`
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	MinTimestamp hexutil.Uint64  `json:"minTimestamp"`
+	MaxTimestamp hexutil.Uint64  `json:"maxTimestamp"`
+}
+
+type sendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// SendBundle adds a bundle of signed transactions to the bundle pool. The blocks
+// built by this node include the transactions of the bundle back-to-back at the
+// front of the block if all of them succeed, and none of them otherwise. If set,
+// the bundle can only be included in the block with the given number, and in the
+// blocks with a timestamp within the given bounds.
+func (api *EthereumAPI) SendBundle(ctx context.Context, args SendBundleArgs) (*sendBundleResult, error) {
+	bundle := &bundlepool.Bundle{
+		Txs:          make([]*types.Transaction, len(args.Txs)),
+		BlockNumber:  uint64(args.BlockNumber),
+		MinTimestamp: uint64(args.MinTimestamp),
+		MaxTimestamp: uint64(args.MaxTimestamp),
+	}
+	for i, input := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		bundle.Txs[i] = tx
+	}
+	if err := api.e.bundlePool.Add(bundle, api.e.clock.Time()); err != nil {
+		return nil, err
+	}
+	log.Info("Submitted bundle", "hash", bundle.Hash(), "txs", len(bundle.Txs), "blockNumber", bundle.BlockNumber, "minTimestamp", bundle.MinTimestamp, "maxTimestamp", bundle.MaxTimestamp)
+	return &sendBundleResult{BundleHash: bundle.Hash()}, nil
+}
+
+var errEncryptedPoolDisabled = errors.New("encrypted mempool is disabled")
+
+// SendEncryptedTransaction adds a signed envelope transaction to the encrypted
+// pool, to be gossiped to the validators. An envelope transaction is sent to
+// [core.EnvelopeAddress] and carries the ciphertext of a transaction encrypted
+// to the key of the validator set as its data, paying for its gas whether the
+// ciphertext can be decrypted or not. The block builder orders envelopes
+// without knowing their contents. Once the block including an envelope is
+// accepted, the envelope is decrypted and the block builder includes its
+// transaction first in the next block. Envelopes are only accepted once the
+// chain config enables them. It returns the hash of the envelope transaction.
+func (api *EthereumAPI) SendEncryptedTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	pool := api.e.encryptedPool
+	if pool == nil {
+		return common.Hash{}, errEncryptedPoolDisabled
+	}
+	if err := api.e.APIBackend.admitOrigin(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	if err := pool.Add(tx, true, api.e.clock.Time()); err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Submitted encrypted transaction", "hash", tx.Hash(), "size", len(tx.Data()))
+	return tx.Hash(), nil
+}
+
+// BaseFeeForecastArgs are the arguments of eth_baseFeeForecast.
+type BaseFeeForecastArgs struct {
+	Blocks                    hexutil.Uint64 `json:"blocks"`
+	Seconds                   hexutil.Uint64 `json:"seconds"`
+	BlockIntervalMilliseconds hexutil.Uint64 `json:"blockIntervalMilliseconds"`
+	Utilization               *float64       `json:"utilization"`
+}
+
+type baseFeeForecastResult struct {
+	Utilization               float64               `json:"utilization"`
+	UtilizationLow            float64               `json:"utilizationLow"`
+	UtilizationHigh           float64               `json:"utilizationHigh"`
+	BlockIntervalMilliseconds hexutil.Uint64        `json:"blockIntervalMilliseconds"`
+	Blocks                    []forecastBlockResult `json:"blocks"`
+}
+
+type forecastBlockResult struct {
+	Number           hexutil.Uint64 `json:"number"`
+	TimeMilliseconds hexutil.Uint64 `json:"timeMilliseconds"`
+	BaseFee          *hexutil.Big   `json:"baseFeePerGas"`
+	BaseFeeLow       *hexutil.Big   `json:"baseFeePerGasLow"`
+	BaseFeeHigh      *hexutil.Big   `json:"baseFeePerGasHigh"`
+	BlockGasCost     *hexutil.Big   `json:"blockGasCost"`
+}
+
+// BaseFeeForecast returns a projection of the base fee and block gas cost of the
+// blocks following the latest block, assuming each block consumes the given
+// fraction of the gas limit. The low and high base fees bound the forecast by
+// the variability of the utilization of recent blocks.
+func (api *EthereumAPI) BaseFeeForecast(ctx context.Context, args BaseFeeForecastArgs) (*baseFeeForecastResult, error) {
+	forecast, err := api.e.APIBackend.gpo.BaseFeeForecast(ctx, gasprice.ForecastRequest{
+		Blocks:                    uint64(args.Blocks),
+		Seconds:                   uint64(args.Seconds),
+		BlockIntervalMilliseconds: uint64(args.BlockIntervalMilliseconds),
+		Utilization:               args.Utilization,
+	})
+	if err != nil || forecast == nil {
+		return nil, err
+	}
+	result := &baseFeeForecastResult{
+		Utilization:               forecast.Utilization,
+		UtilizationLow:            forecast.UtilizationLow,
+		UtilizationHigh:           forecast.UtilizationHigh,
+		BlockIntervalMilliseconds: hexutil.Uint64(forecast.BlockIntervalMilliseconds),
+		Blocks:                    make([]forecastBlockResult, len(forecast.Blocks)),
+	}
+	for i, block := range forecast.Blocks {
+		result.Blocks[i] = forecastBlockResult{
+			Number:           hexutil.Uint64(block.Number),
+			TimeMilliseconds: hexutil.Uint64(block.TimeMilliseconds),
+			BaseFee:          (*hexutil.Big)(block.BaseFee),
+			BaseFeeLow:       (*hexutil.Big)(block.BaseFeeLow),
+			BaseFeeHigh:      (*hexutil.Big)(block.BaseFeeHigh),
+			BlockGasCost:     (*hexutil.Big)(block.BlockGasCost),
+		}
+	}
+	return result, nil
+}
+
+type feeSuggestionResult struct {
+	MaxPriorityFeePerGas          *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	MaxFeePerGas                  *hexutil.Big   `json:"maxFeePerGas"`
+	ExpectedInclusionBlocks       hexutil.Uint64 `json:"expectedInclusionBlocks"`
+	ExpectedInclusionMilliseconds hexutil.Uint64 `json:"expectedInclusionMilliseconds"`
+}
+
+type suggestFeesResult struct {
+	BaseFee  *hexutil.Big        `json:"baseFeePerGas"`
+	Slow     feeSuggestionResult `json:"slow"`
+	Standard feeSuggestionResult `json:"standard"`
+	Fast     feeSuggestionResult `json:"fast"`
+}
+
+// SuggestFees returns suggested fees for slow, standard and fast transactions,
+// with the expected time until they are included. The standard tip is the tip
+// returned by eth_maxPriorityFeePerGas.
+func (api *EthereumAPI) SuggestFees(ctx context.Context) (*suggestFeesResult, error) {
+	fees, err := api.e.APIBackend.gpo.SuggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	toResult := func(fee gasprice.FeeSuggestion) feeSuggestionResult {
+		return feeSuggestionResult{
+			MaxPriorityFeePerGas:          (*hexutil.Big)(fee.MaxPriorityFeePerGas),
+			MaxFeePerGas:                  (*hexutil.Big)(fee.MaxFeePerGas),
+			ExpectedInclusionBlocks:       hexutil.Uint64(fee.ExpectedInclusionBlocks),
+			ExpectedInclusionMilliseconds: hexutil.Uint64(fee.ExpectedInclusionMilliseconds),
+		}
+	}
+	return &suggestFeesResult{
+		BaseFee:  (*hexutil.Big)(fees.BaseFee),
+		Slow:     toResult(fees.Slow),
+		Standard: toResult(fees.Standard),
+		Fast:     toResult(fees.Fast),
+	}, nil
+}
+
+type feeConfigChangeResult struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	// TxHash is nil if the change was made by a network upgrade.
+	TxHash       *common.Hash         `json:"transactionHash"`
+	Sender       common.Address       `json:"sender"`
+	OldFeeConfig commontype.FeeConfig `json:"oldFeeConfig"`
+	NewFeeConfig commontype.FeeConfig `json:"newFeeConfig"`
+}
+
+// FeeConfigHistory returns every change of the fee config made in the accepted blocks
+// between fromBlock and toBlock inclusive, either by a transaction calling the fee
+// manager precompile or by a network upgrade activating or deactivating it.
+func (api *EthereumAPI) FeeConfigHistory(ctx context.Context, fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) ([]feeConfigChangeResult, error) {
+	from, err := api.e.APIBackend.HeaderByNumber(ctx, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := api.e.APIBackend.HeaderByNumber(ctx, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from == nil || to == nil {
+		return nil, errors.New("block not found")
+	}
+	changes, err := api.e.blockchain.GetFeeConfigHistory(from.Number.Uint64(), to.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	results := make([]feeConfigChangeResult, len(changes))
+	for i, change := range changes {
+		results[i] = feeConfigChangeResult{
+			BlockNumber:  hexutil.Uint64(change.BlockNumber),
+			Sender:       change.Sender,
+			OldFeeConfig: change.OldFeeConfig,
+			NewFeeConfig: change.NewFeeConfig,
+		}
+		if change.TxHash != (common.Hash{}) {
+			results[i].TxHash = &change.TxHash
+		}
+	}
+	return results, nil
+}
+
+var errBlobSidecarsUnavailable = errors.New("blob sidecars unavailable")
+
+type blobSidecarResult struct {
+	TxHash      common.Hash          `json:"transactionHash"`
+	Blobs       []kzg4844.Blob       `json:"blobs"`
+	Commitments []kzg4844.Commitment `json:"commitments"`
+	Proofs      []kzg4844.Proof      `json:"proofs"`
+}
+
+// GetBlobSidecars returns the sidecars of the blob transactions of the accepted
+// block, in the order of the transactions. The sidecars are only available on
+// the nodes that received the transactions, and until they are pruned.
+func (api *EthereumAPI) GetBlobSidecars(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]blobSidecarResult, error) {
+	block, err := api.e.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+	hasBlobs := false
+	for _, tx := range block.Transactions() {
+		if tx.Type() == types.BlobTxType {
+			hasBlobs = true
+			break
+		}
+	}
+	if !hasBlobs {
+		return []blobSidecarResult{}, nil
+	}
+	sidecars, ok, err := customrawdb.ReadBlobSidecars(api.e.chainDb, block.NumberU64(), block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: block %d", errBlobSidecarsUnavailable, block.NumberU64())
+	}
+	results := make([]blobSidecarResult, len(sidecars))
+	for i, sidecar := range sidecars {
+		results[i] = blobSidecarResult{
+			TxHash:      sidecar.TxHash,
+			Blobs:       sidecar.Sidecar.Blobs,
+			Commitments: sidecar.Sidecar.Commitments,
+			Proofs:      sidecar.Sidecar.Proofs,
+		}
+	}
+	return results, nil
+}
@@ -31,6 +31,7 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"net"
 	"time"
 
 	"github.com/ava-labs/libevm/accounts"
@@ -357,6 +358,9 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	if err := ctx.Err(); err != nil {
 		return err
 	}
+	if err := b.admitOrigin(ctx); err != nil {
+		return err
+	}
 	if err := b.eth.txPool.Add([]*types.Transaction{signedTx}, true, false)[0]; err != nil {
 		return err
 	}
@@ -367,6 +371,33 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return nil
 }
 
+// SendConditionalTx adds [signedTx] to the transaction pool with [conditional].
+// Conditional transactions are not pushed to peers, as peers cannot check the
+// conditions.
+func (b *EthAPIBackend) SendConditionalTx(ctx context.Context, signedTx *types.Transaction, conditional *txpool.TransactionConditional) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := b.admitOrigin(ctx); err != nil {
+		return err
+	}
+	return b.eth.txPool.AddConditional(signedTx, conditional)
+}
+
+// admitOrigin returns an error if the RPC client sending a transaction with
+// [ctx] exceeded its admission rate limit. The clients are identified by their
+// host, and in-process clients are not limited.
+func (b *EthAPIBackend) admitOrigin(ctx context.Context) error {
+	addr := rpc.PeerInfoFromContext(ctx).RemoteAddr
+	if addr == "" {
+		return nil
+	}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return b.eth.txPool.AdmitOrigin("rpc:" + addr)
+}
+
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending := b.eth.txPool.Pending(txpool.PendingFilter{})
 	var txs types.Transactions
@@ -423,6 +454,14 @@ func (b *EthAPIBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transac
 	return b.eth.txPool.ContentFrom(addr)
 }
 
+func (b *EthAPIBackend) TxPoolExplain(txHash common.Hash) *txpool.TxExplanation {
+	return b.eth.txPool.Explain(txHash)
+}
+
+func (b *EthAPIBackend) TxInclusionError(txHash common.Hash) error {
+	return b.eth.miner.InclusionError(txHash)
+}
+
 func (b *EthAPIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
 	return b.eth.txPool.SubscribeTransactions(ch, true)
 }
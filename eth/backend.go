@@ -47,8 +47,11 @@ import (
 	"github.com/ava-labs/libevm/log"
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/bundlepool"
+	"github.com/ava-labs/subnet-evm/core/encryptedpool"
 	"github.com/ava-labs/subnet-evm/core/state/pruner"
 	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/txpool/blobpool"
 	"github.com/ava-labs/subnet-evm/core/txpool/legacypool"
 	"github.com/ava-labs/subnet-evm/eth/ethconfig"
 	"github.com/ava-labs/subnet-evm/eth/filters"
@@ -84,7 +87,10 @@ type Ethereum struct {
 	config *Config
 
 	// Handlers
-	txPool *txpool.TxPool
+	txPool            *txpool.TxPool
+	bundlePool        *bundlepool.BundlePool
+	encryptedPool     *encryptedpool.EncryptedPool // nil if the encrypted mempool is disabled
+	envelopeDecrypter core.EnvelopeDecrypter       // nil if the encrypted mempool is disabled
 
 	blockchain *core.BlockChain
 	gossiper   PushGossiper
@@ -100,6 +106,10 @@ type Ethereum struct {
 	bloomIndexer      *core.ChainIndexer             // Bloom indexer operating during block imports
 	closeBloomHandler chan struct{}
 
+	closePrivateTxs chan struct{}  // Channel stopping the expiry of the private transactions
+	closeTxPersist  chan struct{}  // Channel stopping the periodic saving of the transaction pool
+	txPersistWg     sync.WaitGroup // Wait group for the periodic saving of the transaction pool
+
 	APIBackend *EthAPIBackend
 
 	miner     *miner.Miner
@@ -115,6 +125,8 @@ type Ethereum struct {
 	stackRPCs []rpc.API
 
 	settings Settings // Settings for Ethereum API
+
+	clock *mockable.Clock
 }
 
 // roundUpCacheSize returns [input] rounded up to the next multiple of [allocSize]
@@ -180,12 +192,15 @@ func New(
 		accountManager:    stack.AccountManager(),
 		engine:            engine,
 		closeBloomHandler: make(chan struct{}),
+		closePrivateTxs:   make(chan struct{}),
+		closeTxPersist:    make(chan struct{}),
 		networkID:         networkID,
 		etherbase:         config.Miner.Etherbase,
 		bloomRequests:     make(chan chan *bloombits.Retrieval),
 		bloomIndexer:      core.NewBloomIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms),
 		settings:          settings,
 		shutdownTracker:   shutdowncheck.NewShutdownTracker(chainDb),
+		clock:             clock,
 	}
 	bcVersion := rawdb.ReadDatabaseVersion(chainDb)
 	dbVer := "<nil>"
@@ -233,6 +248,7 @@ func New(
 			AcceptedCacheSize:               config.AcceptedCacheSize,
 			TransactionHistory:              config.TransactionHistory,
 			SkipTxIndexing:                  config.SkipTxIndexing,
+			FeeConfigHistory:                config.FeeConfigHistory,
 			StateHistory:                    config.StateHistory,
 			StateScheme:                     scheme,
 			ChainDataDir:                    chainDataDir,
@@ -258,17 +274,37 @@ func New(
 
 	eth.bloomIndexer.Start(eth.blockchain)
 
-	// config.BlobPool.Datadir = ""
-	// blobPool := blobpool.New(config.BlobPool, &chainWithFinalBlock{eth.blockchain})
-
 	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
+	subpools := []txpool.SubPool{legacyPool}
+	// Blob transactions are only pooled on chains that enable them. The blobs
+	// are held in memory, and stored by the VM once included in accepted blocks.
+	if params.GetExtra(eth.blockchain.Config()).BlobConfig != nil {
+		config.BlobPool.Datadir = ""
+		subpools = append(subpools, blobpool.New(config.BlobPool, &chainWithFinalBlock{eth.blockchain}))
+	}
 
-	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, []txpool.SubPool{legacyPool}) //, blobPool})
+	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, subpools)
 	if err != nil {
 		return nil, err
 	}
 
-	eth.miner = miner.New(eth, &config.Miner, eth.blockchain.Config(), eth.EventMux(), eth.engine, clock)
+	if config.PersistTxPool {
+		eth.loadTxPool()
+	}
+	// Limit the admissions once the saved transactions are loaded, which would
+	// otherwise consume the tokens of their senders.
+	eth.txPool.SetAdmissionLimits(config.TxPoolAdmission, types.LatestSigner(eth.blockchain.Config()))
+
+	eth.bundlePool = bundlepool.New(config.BundlePool, eth.blockchain)
+	if config.EnvelopeDecrypter != nil {
+		eth.envelopeDecrypter = config.EnvelopeDecrypter
+		eth.encryptedPool = encryptedpool.New(config.EncryptedPool, eth.blockchain)
+	}
+
+	eth.miner, err = miner.New(eth, &config.Miner, eth.blockchain.Config(), eth.EventMux(), eth.engine, clock)
+	if err != nil {
+		return nil, err
+	}
 
 	allowUnprotectedTxHashes := make(map[common.Hash]struct{})
 	for _, txHash := range config.AllowUnprotectedTxHashes {
@@ -371,12 +407,21 @@ func (s *Ethereum) SetEtherbase(etherbase common.Address) {
 
 func (s *Ethereum) Miner() *miner.Miner { return s.miner }
 
-func (s *Ethereum) AccountManager() *accounts.Manager { return s.accountManager }
-func (s *Ethereum) BlockChain() *core.BlockChain      { return s.blockchain }
-func (s *Ethereum) TxPool() *txpool.TxPool            { return s.txPool }
-func (s *Ethereum) EventMux() *event.TypeMux          { return s.eventMux }
-func (s *Ethereum) Engine() consensus.Engine          { return s.engine }
-func (s *Ethereum) ChainDb() ethdb.Database           { return s.chainDb }
+func (s *Ethereum) AccountManager() *accounts.Manager  { return s.accountManager }
+func (s *Ethereum) BlockChain() *core.BlockChain       { return s.blockchain }
+func (s *Ethereum) TxPool() *txpool.TxPool             { return s.txPool }
+func (s *Ethereum) BundlePool() *bundlepool.BundlePool { return s.bundlePool }
+func (s *Ethereum) EventMux() *event.TypeMux           { return s.eventMux }
+func (s *Ethereum) Engine() consensus.Engine           { return s.engine }
+func (s *Ethereum) ChainDb() ethdb.Database            { return s.chainDb }
+
+// EncryptedPool returns the pool of the envelopes sent with
+// eth_sendEncryptedTransaction, or nil if the encrypted mempool is disabled.
+func (s *Ethereum) EncryptedPool() *encryptedpool.EncryptedPool { return s.encryptedPool }
+
+// EnvelopeDecrypter returns the decrypter of the envelopes included in accepted
+// blocks, or nil if the encrypted mempool is disabled.
+func (s *Ethereum) EnvelopeDecrypter() core.EnvelopeDecrypter { return s.envelopeDecrypter }
 
 func (s *Ethereum) NetVersion() uint64               { return s.networkID }
 func (s *Ethereum) ArchiveMode() bool                { return !s.config.Pruning }
@@ -388,6 +433,15 @@ func (s *Ethereum) Start() {
 	// Start the bloom bits servicing goroutines
 	s.startBloomHandlers(params.BloomBitsBlocks)
 
+	// Start expiring the private transactions
+	go s.expirePrivateTxs()
+
+	// Start saving the transaction pool
+	if s.config.PersistTxPool {
+		s.txPersistWg.Add(1)
+		go s.persistTxPool()
+	}
+
 	// Regularly update shutdown marker
 	s.shutdownTracker.Start()
 }
@@ -398,7 +452,18 @@ func (s *Ethereum) Start() {
 func (s *Ethereum) Stop() error {
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
+	close(s.closePrivateTxs)
+	// Wait for a periodic save in progress, so that it neither interleaves with
+	// the final save nor runs after the pool is closed.
+	close(s.closeTxPersist)
+	s.txPersistWg.Wait()
+	if s.config.PersistTxPool {
+		s.saveTxPool()
+	}
 	s.txPool.Close()
+	if s.encryptedPool != nil {
+		s.encryptedPool.Close()
+	}
 	s.blockchain.Stop()
 	s.engine.Close()
 
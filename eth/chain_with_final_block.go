@@ -1,7 +1,6 @@
 // Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
 // See the file LICENSE for licensing terms.
 
-//nolint:unused
 package eth
 
 import (
@@ -10,19 +9,13 @@ import (
 	"github.com/ava-labs/subnet-evm/core"
 )
 
-const blocksToKeep = 604_800 // Approx. 2 weeks worth of blocks assuming 2s block time
-
 type chainWithFinalBlock struct {
 	*core.BlockChain
 }
 
 // CurrentFinalBlock returns the current block below which blobs should not
-// be maintained anymore for reorg purposes.
+// be maintained anymore for reorg purposes. Accepted blocks are final, and the
+// VM stores the blobs they include.
 func (c *chainWithFinalBlock) CurrentFinalBlock() *types.Header {
-	lastAccepted := c.LastAcceptedBlock().Header().Number.Uint64()
-	if lastAccepted <= blocksToKeep {
-		return nil
-	}
-
-	return c.GetHeaderByNumber(lastAccepted - blocksToKeep)
+	return c.LastAcceptedBlock().Header()
 }
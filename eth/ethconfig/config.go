@@ -32,6 +32,9 @@ import (
 
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/bundlepool"
+	"github.com/ava-labs/subnet-evm/core/encryptedpool"
+	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/core/txpool/blobpool"
 	"github.com/ava-labs/subnet-evm/core/txpool/legacypool"
 	"github.com/ava-labs/subnet-evm/eth/gasprice"
@@ -67,6 +70,10 @@ func NewDefaultConfig() Config {
 		Miner:                     miner.Config{},
 		TxPool:                    legacypool.DefaultConfig,
 		BlobPool:                  blobpool.DefaultConfig,
+		TxPoolAdmission:           txpool.DefaultAdmissionConfig,
+		BundlePool:                bundlepool.DefaultConfig,
+		EncryptedPool:             encryptedpool.DefaultConfig,
+		PrivateTxLifetime:         5 * time.Minute,
 		RPCGasCap:                 25000000,
 		RPCEVMTimeout:             5 * time.Second,
 		GPO:                       DefaultFullGPOConfig,
@@ -119,6 +126,31 @@ type Config struct {
 	TxPool   legacypool.Config
 	BlobPool blobpool.Config
 
+	// TxPoolAdmission are the rate limits on the transactions admitted into
+	// the pool from each sender and from each RPC client or gossip peer.
+	TxPoolAdmission txpool.AdmissionConfig
+
+	// PersistTxPool enables saving the pending and queued transactions of the
+	// pool to the database, to add them back to the pool on restart.
+	PersistTxPool bool
+
+	// Bundle pool options
+	BundlePool bundlepool.Config
+
+	// Encrypted pool options
+	EncryptedPool encryptedpool.Config
+	// EnvelopeDecrypter enables the encrypted mempool if set, decrypting the
+	// envelopes sent with eth_sendEncryptedTransaction once the block including
+	// them is accepted.
+	EnvelopeDecrypter core.EnvelopeDecrypter `toml:"-"`
+
+	// PrivateTxLifetime is how long transactions sent with
+	// eth_sendPrivateRawTransaction stay private.
+	PrivateTxLifetime time.Duration
+	// PrivateTxRelease enables releasing expired private transactions to
+	// public gossip instead of dropping them.
+	PrivateTxRelease bool
+
 	// Gas Price Oracle options
 	GPO gasprice.Config
 
@@ -179,4 +211,8 @@ type Config struct {
 	// This is useful for validators that don't need to index transactions.
 	// TransactionHistory can be still used to control unindexing old transactions.
 	SkipTxIndexing bool
+
+	// FeeConfigHistory indexes the fee config changes of accepted blocks to serve
+	// eth_feeConfigHistory.
+	FeeConfigHistory bool
 }
@@ -7,6 +7,9 @@ import (
 
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/bundlepool"
+	"github.com/ava-labs/subnet-evm/core/encryptedpool"
+	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/core/txpool/blobpool"
 	"github.com/ava-labs/subnet-evm/core/txpool/legacypool"
 	"github.com/ava-labs/subnet-evm/eth/gasprice"
@@ -39,6 +42,13 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Miner                           miner.Config
 		TxPool                          legacypool.Config
 		BlobPool                        blobpool.Config
+		TxPoolAdmission                 txpool.AdmissionConfig
+		PersistTxPool                   bool
+		BundlePool                      bundlepool.Config
+		EncryptedPool                   encryptedpool.Config
+		EnvelopeDecrypter               core.EnvelopeDecrypter `toml:"-"`
+		PrivateTxLifetime               time.Duration
+		PrivateTxRelease                bool
 		GPO                             gasprice.Config
 		EnablePreimageRecording         bool
 		RPCGasCap                       uint64 `toml:",omitempty"`
@@ -56,6 +66,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		StateHistory                    uint64 `toml:",omitempty"`
 		StateScheme                     string `toml:",omitempty"`
 		SkipTxIndexing                  bool
+		FeeConfigHistory                bool
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -81,6 +92,13 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
+	enc.TxPoolAdmission = c.TxPoolAdmission
+	enc.PersistTxPool = c.PersistTxPool
+	enc.BundlePool = c.BundlePool
+	enc.EncryptedPool = c.EncryptedPool
+	enc.EnvelopeDecrypter = c.EnvelopeDecrypter
+	enc.PrivateTxLifetime = c.PrivateTxLifetime
+	enc.PrivateTxRelease = c.PrivateTxRelease
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.RPCGasCap = c.RPCGasCap
@@ -98,6 +116,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.StateHistory = c.StateHistory
 	enc.StateScheme = c.StateScheme
 	enc.SkipTxIndexing = c.SkipTxIndexing
+	enc.FeeConfigHistory = c.FeeConfigHistory
 	return &enc, nil
 }
 
@@ -127,6 +146,13 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Miner                           *miner.Config
 		TxPool                          *legacypool.Config
 		BlobPool                        *blobpool.Config
+		TxPoolAdmission                 *txpool.AdmissionConfig
+		PersistTxPool                   *bool
+		BundlePool                      *bundlepool.Config
+		EncryptedPool                   *encryptedpool.Config
+		EnvelopeDecrypter               core.EnvelopeDecrypter `toml:"-"`
+		PrivateTxLifetime               *time.Duration
+		PrivateTxRelease                *bool
 		GPO                             *gasprice.Config
 		EnablePreimageRecording         *bool
 		RPCGasCap                       *uint64 `toml:",omitempty"`
@@ -144,6 +170,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		StateHistory                    *uint64 `toml:",omitempty"`
 		StateScheme                     *string `toml:",omitempty"`
 		SkipTxIndexing                  *bool
+		FeeConfigHistory                *bool
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -218,6 +245,27 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.BlobPool != nil {
 		c.BlobPool = *dec.BlobPool
 	}
+	if dec.TxPoolAdmission != nil {
+		c.TxPoolAdmission = *dec.TxPoolAdmission
+	}
+	if dec.PersistTxPool != nil {
+		c.PersistTxPool = *dec.PersistTxPool
+	}
+	if dec.BundlePool != nil {
+		c.BundlePool = *dec.BundlePool
+	}
+	if dec.EncryptedPool != nil {
+		c.EncryptedPool = *dec.EncryptedPool
+	}
+	if dec.EnvelopeDecrypter != nil {
+		c.EnvelopeDecrypter = dec.EnvelopeDecrypter
+	}
+	if dec.PrivateTxLifetime != nil {
+		c.PrivateTxLifetime = *dec.PrivateTxLifetime
+	}
+	if dec.PrivateTxRelease != nil {
+		c.PrivateTxRelease = *dec.PrivateTxRelease
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
@@ -269,5 +317,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SkipTxIndexing != nil {
 		c.SkipTxIndexing = *dec.SkipTxIndexing
 	}
+	if dec.FeeConfigHistory != nil {
+		c.FeeConfigHistory = *dec.FeeConfigHistory
+	}
 	return nil
 }
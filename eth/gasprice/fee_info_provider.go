@@ -33,6 +33,7 @@ import (
 
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
 	"github.com/ava-labs/subnet-evm/rpc"
 	lru "github.com/hashicorp/golang-lru"
 )
@@ -50,9 +51,13 @@ type feeInfoProvider struct {
 
 // feeInfo is the type of data stored in feeInfoProvider's cache.
 type feeInfo struct {
-	baseFee   *big.Int   // base fee of the block
-	tips      []*big.Int // tips for txs to be included in the block
-	timestamp uint64     // timestamp of the block header
+	number           uint64     // number of the block
+	baseFee          *big.Int   // base fee of the block
+	tips             []*big.Int // tips for txs to be included in the block
+	timestamp        uint64     // timestamp of the block header
+	timeMilliseconds uint64     // timestamp of the block header in milliseconds
+	gasUsed          uint64     // gas used by the block
+	gasLimit         uint64     // gas limit of the block
 }
 
 // newFeeInfoProvider returns a bounded buffer with [size] slots to
@@ -96,9 +101,13 @@ func (f *feeInfoProvider) addHeader(ctx context.Context, header *types.Header, t
 	}
 
 	feeInfo := &feeInfo{
-		timestamp: header.Time,
-		baseFee:   header.BaseFee,
-		tips:      tips,
+		number:           header.Number.Uint64(),
+		timestamp:        header.Time,
+		timeMilliseconds: customtypes.HeaderTimeMilliseconds(header),
+		baseFee:          header.BaseFee,
+		tips:             tips,
+		gasUsed:          header.GasUsed,
+		gasLimit:         header.GasLimit,
 	}
 	f.cache.Add(header.Number.Uint64(), feeInfo)
 	return feeInfo, nil
@@ -0,0 +1,228 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ava-labs/libevm/core/types"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+	"github.com/ava-labs/subnet-evm/plugin/evm/feesim"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/rpc"
+)
+
+// MaxForecastBlocks is the maximum number of blocks that can be forecast in a
+// single call to eth_baseFeeForecast.
+const MaxForecastBlocks = 1024
+
+var (
+	errInvalidUtilization   = errors.New("utilization must be between 0 and 1")
+	errNoForecastHorizon    = errors.New("number of blocks or seconds to forecast must be specified")
+	errTooManyForecastBlock = fmt.Errorf("cannot forecast more than %d blocks", MaxForecastBlocks)
+)
+
+// ForecastRequest describes the future blocks to forecast.
+type ForecastRequest struct {
+	// Blocks is the number of blocks to forecast. If zero, it is derived from [Seconds].
+	Blocks uint64
+	// Seconds is the time span to forecast, used if [Blocks] is zero.
+	Seconds uint64
+	// BlockIntervalMilliseconds is the assumed time between blocks. If zero, the average
+	// interval of recent blocks is used, or the target block rate if there are none.
+	BlockIntervalMilliseconds uint64
+	// Utilization is the assumed fraction of the gas limit consumed by each block.
+	// If nil, the average utilization of recent blocks is used.
+	Utilization *float64
+}
+
+// BaseFeeForecast is a projection of the fees of the blocks following the latest block.
+type BaseFeeForecast struct {
+	// Utilization is the fraction of the gas limit assumed to be consumed by each block.
+	Utilization float64
+	// UtilizationLow and UtilizationHigh are [Utilization] minus and plus the standard
+	// deviation of the utilization of recent blocks. They bound the confidence range of
+	// the forecast base fees.
+	UtilizationLow  float64
+	UtilizationHigh float64
+	// BlockIntervalMilliseconds is the time between forecast blocks.
+	BlockIntervalMilliseconds uint64
+	Blocks                    []ForecastBlock
+}
+
+// ForecastBlock is the forecast fee state of a future block.
+type ForecastBlock struct {
+	Number           uint64
+	TimeMilliseconds uint64
+	// BaseFee is the base fee if every block consumes [BaseFeeForecast.Utilization].
+	BaseFee *big.Int
+	// BaseFeeLow and BaseFeeHigh are the base fees at the low and high bounds of the utilization.
+	BaseFeeLow  *big.Int
+	BaseFeeHigh *big.Int
+	// BlockGasCost does not depend on the utilization.
+	BlockGasCost *big.Int
+}
+
+// utilizationStats returns the mean and standard deviation of the fraction of the gas
+// limit consumed by the blocks up to [head], and their average interval in milliseconds.
+// It returns a zero interval if there are fewer than two blocks to compare.
+func (oracle *Oracle) utilizationStats(ctx context.Context, head *types.Header) (mean float64, stddev float64, intervalMS uint64, err error) {
+	var (
+		headNumber = head.Number.Uint64()
+		first      = headNumber + 1 - min(uint64(oracle.checkBlocks), headNumber)
+		ratios     = make([]float64, 0, headNumber+1-first)
+		firstTime  uint64
+	)
+	for number := first; number <= headNumber; number++ {
+		header := head
+		if number != headNumber {
+			header, err = oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+			if err != nil {
+				return 0, 0, 0, err
+			}
+		}
+		if number == first {
+			firstTime = customtypes.HeaderTimeMilliseconds(header)
+		}
+		if header.GasLimit > 0 {
+			ratios = append(ratios, float64(header.GasUsed)/float64(header.GasLimit))
+		}
+	}
+	if len(ratios) == 0 {
+		return 0, 0, 0, nil
+	}
+	for _, r := range ratios {
+		mean += r
+	}
+	mean /= float64(len(ratios))
+	for _, r := range ratios {
+		stddev += (r - mean) * (r - mean)
+	}
+	stddev = math.Sqrt(stddev / float64(len(ratios)))
+	if headNumber > first {
+		intervalMS = (customtypes.HeaderTimeMilliseconds(head) - firstTime) / (headNumber - first)
+	}
+	return mean, stddev, intervalMS, nil
+}
+
+// BaseFeeForecast projects the base fee and block gas cost of the blocks following
+// the latest block as described by [req].
+//
+// The forecast uses the fee config in the state of the latest block, so a fee config
+// change made by the fee manager precompile in that block is taken into account, as well
+// as fee manager upgrades scheduled within the forecast period.
+// If SubnetEVM has not been activated, it returns a nil forecast and a nil error.
+func (oracle *Oracle) BaseFeeForecast(ctx context.Context, req ForecastRequest) (*BaseFeeForecast, error) {
+	if req.Utilization != nil && (*req.Utilization < 0 || *req.Utilization > 1) {
+		return nil, fmt.Errorf("%w: %f", errInvalidUtilization, *req.Utilization)
+	}
+	if req.Blocks == 0 && req.Seconds == 0 {
+		return nil, errNoForecastHorizon
+	}
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if head.BaseFee == nil {
+		return nil, nil
+	}
+	feeConfig, _, err := oracle.backend.GetFeeConfigAt(head)
+	if err != nil {
+		return nil, err
+	}
+
+	mean, stddev, intervalMS, err := oracle.utilizationStats(ctx, head)
+	if err != nil {
+		return nil, err
+	}
+	forecast := &BaseFeeForecast{
+		Utilization:               mean,
+		BlockIntervalMilliseconds: req.BlockIntervalMilliseconds,
+	}
+	if req.Utilization != nil {
+		forecast.Utilization = *req.Utilization
+	}
+	forecast.UtilizationLow = max(forecast.Utilization-stddev, 0)
+	forecast.UtilizationHigh = min(forecast.Utilization+stddev, 1)
+	if forecast.BlockIntervalMilliseconds == 0 {
+		forecast.BlockIntervalMilliseconds = intervalMS
+	}
+	if forecast.BlockIntervalMilliseconds == 0 {
+		forecast.BlockIntervalMilliseconds = max(feeConfig.TargetBlockRate*1000, 1)
+	}
+
+	blocks := req.Blocks
+	if blocks == 0 {
+		blocks = (req.Seconds*1000 + forecast.BlockIntervalMilliseconds - 1) / forecast.BlockIntervalMilliseconds
+	}
+	if blocks > MaxForecastBlocks {
+		return nil, fmt.Errorf("%w: requested %d", errTooManyForecastBlock, blocks)
+	}
+
+	var (
+		chainConfig = params.GetExtra(oracle.backend.ChainConfig())
+		headTime    = head.Time
+		startMS     = max(uint64(oracle.clock.Time().UnixMilli()), customtypes.HeaderTimeMilliseconds(head))
+		simulators  = []*feesim.Simulator{
+			feesim.NewSimulator(chainConfig, head),
+			feesim.NewSimulator(chainConfig, head),
+			feesim.NewSimulator(chainConfig, head),
+		}
+		utilizations = []float64{forecast.Utilization, forecast.UtilizationLow, forecast.UtilizationHigh}
+	)
+	forecast.Blocks = make([]ForecastBlock, 0, blocks)
+	for i := uint64(0); i < blocks; i++ {
+		timeMS := startMS + i*forecast.BlockIntervalMilliseconds
+		feeConfig = scheduledFeeConfig(chainConfig, feeConfig, headTime, timeMS/1000)
+		headTime = timeMS / 1000
+
+		samples := make([]feesim.Sample, len(simulators))
+		for j, simulator := range simulators {
+			gasLimit, err := simulator.GasLimit(feeConfig, timeMS)
+			if err != nil {
+				return nil, err
+			}
+			gasUsed := uint64(utilizations[j] * float64(gasLimit))
+			samples[j], err = simulator.Next(feeConfig, feesim.Block{TimeMilliseconds: timeMS, GasUsed: gasUsed})
+			if err != nil {
+				return nil, err
+			}
+		}
+		forecast.Blocks = append(forecast.Blocks, ForecastBlock{
+			Number:           samples[0].Number,
+			TimeMilliseconds: timeMS,
+			BaseFee:          samples[0].BaseFee,
+			BaseFeeLow:       samples[1].BaseFee,
+			BaseFeeHigh:      samples[2].BaseFee,
+			BlockGasCost:     samples[0].BlockGasCost,
+		})
+	}
+	return forecast, nil
+}
+
+// scheduledFeeConfig returns the fee config in effect at [to] given that [feeConfig] was
+// in effect at [from], taking the fee manager upgrades activating in between into account.
+func scheduledFeeConfig(chainConfig *extras.ChainConfig, feeConfig commontype.FeeConfig, from uint64, to uint64) commontype.FeeConfig {
+	for _, cfg := range chainConfig.GetActivatingPrecompileConfigs(feemanager.ContractAddress, &from, to, chainConfig.PrecompileUpgrades) {
+		feeManagerConfig, ok := cfg.(*feemanager.Config)
+		switch {
+		case !ok || cfg.IsDisabled():
+			feeConfig = chainConfig.FeeConfig
+		case feeManagerConfig.InitialFeeConfig != nil:
+			feeConfig = *feeManagerConfig.InitialFeeConfig
+			feeConfig.ElasticGasLimit = chainConfig.FeeConfig.ElasticGasLimit
+		default:
+			feeConfig = chainConfig.FeeConfig
+		}
+	}
+	return feeConfig
+}
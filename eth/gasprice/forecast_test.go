@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gasprice
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+func TestBaseFeeForecast(t *testing.T) {
+	backend := newTestBackend(t, 10, testGenBlock(t, 10, 5))
+	defer backend.teardown()
+	oracle, err := NewOracle(backend, defaultOracleConfig())
+	require.NoError(t, err)
+	oracle.clock.Set(time.Unix(20, 0))
+
+	zero, full, invalid := 0.0, 1.0, 1.5
+	tests := []struct {
+		name       string
+		req        ForecastRequest
+		wantErr    error
+		wantBlocks int
+	}{
+		{
+			name:    "invalid utilization",
+			req:     ForecastRequest{Blocks: 1, Utilization: &invalid},
+			wantErr: errInvalidUtilization,
+		},
+		{
+			name:    "no horizon",
+			req:     ForecastRequest{},
+			wantErr: errNoForecastHorizon,
+		},
+		{
+			name:    "too many blocks",
+			req:     ForecastRequest{Blocks: MaxForecastBlocks + 1},
+			wantErr: errTooManyForecastBlock,
+		},
+		{
+			name:       "blocks at recent utilization",
+			req:        ForecastRequest{Blocks: 5},
+			wantBlocks: 5,
+		},
+		{
+			name:       "seconds with block interval",
+			req:        ForecastRequest{Seconds: 9, BlockIntervalMilliseconds: 2000, Utilization: &zero},
+			wantBlocks: 5,
+		},
+		{
+			name:       "full blocks",
+			req:        ForecastRequest{Blocks: 20, Utilization: &full},
+			wantBlocks: 20,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			forecast, err := oracle.BaseFeeForecast(context.Background(), test.req)
+			require.ErrorIs(t, err, test.wantErr)
+			if test.wantErr != nil {
+				return
+			}
+			require.Len(t, forecast.Blocks, test.wantBlocks)
+			require.LessOrEqual(t, forecast.UtilizationLow, forecast.Utilization)
+			require.GreaterOrEqual(t, forecast.UtilizationHigh, forecast.Utilization)
+			for i, block := range forecast.Blocks {
+				require.Equal(t, uint64(11+i), block.Number)
+				require.LessOrEqual(t, block.BaseFeeLow.Cmp(block.BaseFee), 0)
+				require.GreaterOrEqual(t, block.BaseFeeHigh.Cmp(block.BaseFee), 0)
+				require.NotNil(t, block.BlockGasCost)
+			}
+		})
+	}
+
+	// Recent blocks are one second apart, which is used as the default block interval.
+	forecast, err := oracle.BaseFeeForecast(context.Background(), ForecastRequest{Blocks: 2})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), forecast.BlockIntervalMilliseconds)
+	require.Equal(t, forecast.Blocks[0].TimeMilliseconds+1000, forecast.Blocks[1].TimeMilliseconds)
+
+	// Full blocks increase the base fee once the fee window fills up.
+	forecast, err = oracle.BaseFeeForecast(context.Background(), ForecastRequest{Blocks: 20, Utilization: &full})
+	require.NoError(t, err)
+	require.Positive(t, forecast.Blocks[19].BaseFee.Cmp(forecast.Blocks[0].BaseFee))
+}
+
+func TestScheduledFeeConfig(t *testing.T) {
+	initialFeeConfig := commontype.ValidTestFeeConfig
+	initialFeeConfig.MinBaseFee = big.NewInt(1)
+
+	chainConfig := *extras.TestChainConfig
+	chainConfig.PrecompileUpgrades = []extras.PrecompileUpgrade{
+		{Config: feemanager.NewConfig(utils.NewUint64(100), nil, nil, nil, &initialFeeConfig)},
+		{Config: feemanager.NewDisableConfig(utils.NewUint64(200))},
+	}
+	current := commontype.ValidTestFeeConfig
+
+	require.Equal(t, current, scheduledFeeConfig(&chainConfig, current, 0, 99))
+	require.Equal(t, initialFeeConfig, scheduledFeeConfig(&chainConfig, current, 99, 100))
+	require.Equal(t, initialFeeConfig, scheduledFeeConfig(&chainConfig, initialFeeConfig, 100, 199))
+	require.Equal(t, chainConfig.FeeConfig, scheduledFeeConfig(&chainConfig, initialFeeConfig, 150, 250))
+}
@@ -31,6 +31,7 @@ import (
 	"context"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/evm/acp176"
@@ -39,6 +40,7 @@ import (
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/libevm/event"
 	"github.com/ava-labs/libevm/log"
+	ethparams "github.com/ava-labs/libevm/params"
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/params"
@@ -90,6 +92,8 @@ type Config struct {
 	MaxPrice        *big.Int `toml:",omitempty"`
 	MinPrice        *big.Int `toml:",omitempty"`
 	MinGasUsed      *big.Int `toml:",omitempty"`
+	// Strategy is the name of the strategy used to suggest tips. Defaults to [PercentileStrategy].
+	Strategy string `toml:",omitempty"`
 }
 
 // OracleBackend includes all necessary background APIs for oracle.
@@ -107,9 +111,14 @@ type OracleBackend interface {
 // Oracle recommends gas prices based on the content of recent
 // blocks. Suitable for both light and full clients.
 type Oracle struct {
-	backend   OracleBackend
-	lastHead  common.Hash
-	lastPrice *big.Int
+	backend  OracleBackend
+	strategy Strategy
+	lastHead common.Hash
+	// lastTime is the time [lastTips] were suggested at.
+	lastTime time.Time
+	lastTips Tips
+	// lastInput is the input [lastTips] were suggested from.
+	lastInput *StrategyInput
 	// [minPrice] ensures we don't get into a positive feedback loop where tips
 	// sink to 0 during a period of slow block production, such that nobody's
 	// transactions will be included until the full block fee duration has
@@ -122,12 +131,12 @@ type Oracle struct {
 	// clock to decide what set of rules to use when recommending a gas price
 	clock mockable.Clock
 
-	checkBlocks, percentile int
-	maxLookbackSeconds      uint64
-	maxCallBlockHistory     uint64
-	maxBlockHistory         uint64
-	historyCache            *lru.Cache[uint64, *slimBlock]
-	feeInfoProvider         *feeInfoProvider
+	checkBlocks         int
+	maxLookbackSeconds  uint64
+	maxCallBlockHistory uint64
+	maxBlockHistory     uint64
+	historyCache        *lru.Cache[uint64, *slimBlock]
+	feeInfoProvider     *feeInfoProvider
 }
 
 // NewOracle returns a new gasprice oracle which can recommend suitable
@@ -189,17 +198,22 @@ func NewOracle(backend OracleBackend, config Config) (*Oracle, error) {
 			lastHead = ev.Block.Hash()
 		}
 	}()
+	strategy, err := newStrategy(config.Strategy, percent, backend)
+	if err != nil {
+		return nil, err
+	}
 	feeInfoProvider, err := newFeeInfoProvider(backend, config.Blocks)
 	if err != nil {
 		return nil, err
 	}
 	return &Oracle{
 		backend:             backend,
-		lastPrice:           minPrice,
+		strategy:            strategy,
+		lastTips:            Tips{Slow: minPrice, Standard: minPrice, Fast: minPrice},
+		lastInput:           &StrategyInput{},
 		minPrice:            minPrice,
 		maxPrice:            maxPrice,
 		checkBlocks:         blocks,
-		percentile:          percent,
 		maxLookbackSeconds:  maxLookbackSeconds,
 		maxCallBlockHistory: maxCallBlockHistory,
 		maxBlockHistory:     maxBlockHistory,
@@ -274,37 +288,178 @@ func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
 	return oracle.suggestTip(ctx)
 }
 
-// suggestTip estimates the gas tip based on a simple sampling method
-func (oracle *Oracle) suggestTip(ctx context.Context) (*big.Int, error) {
+// FeeSuggestion is a suggested fee for transactions of a priority.
+type FeeSuggestion struct {
+	MaxPriorityFeePerGas *big.Int
+	// MaxFeePerGas leaves room for the base fee to double before the transaction is included.
+	MaxFeePerGas *big.Int
+	// ExpectedInclusionBlocks is the expected number of blocks until a transaction
+	// paying [MaxPriorityFeePerGas] is included, based on recent blocks.
+	ExpectedInclusionBlocks uint64
+	// ExpectedInclusionMilliseconds is [ExpectedInclusionBlocks] times the average
+	// interval of recent blocks.
+	ExpectedInclusionMilliseconds uint64
+}
+
+// FeeSuggestions are suggested fees for transactions of each priority.
+type FeeSuggestions struct {
+	// BaseFee is the estimated base fee of the next block, or nil if Subnet-EVM is
+	// not activated.
+	BaseFee  *big.Int
+	Slow     FeeSuggestion
+	Standard FeeSuggestion
+	Fast     FeeSuggestion
+}
+
+// SuggestFees returns suggested fees for slow, standard and fast transactions.
+// The standard tip is the tip returned by [Oracle.SuggestTipCap].
+func (oracle *Oracle) SuggestFees(ctx context.Context) (*FeeSuggestions, error) {
+	tips, input, err := oracle.suggestTips(ctx)
+	if err != nil {
+		return nil, err
+	}
 	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
 	if err != nil {
 		return nil, err
 	}
+	intervalMS, err := oracle.blockInterval(head, input)
+	if err != nil {
+		return nil, err
+	}
+
+	suggest := func(tip *big.Int) FeeSuggestion {
+		maxFee := new(big.Int).Set(tip)
+		if input.NextBaseFee != nil {
+			maxFee.Add(maxFee, new(big.Int).Lsh(input.NextBaseFee, 1))
+		}
+		blocks := expectedInclusionBlocks(tip, input.Blocks)
+		return FeeSuggestion{
+			MaxPriorityFeePerGas:          tip,
+			MaxFeePerGas:                  maxFee,
+			ExpectedInclusionBlocks:       blocks,
+			ExpectedInclusionMilliseconds: blocks * intervalMS,
+		}
+	}
+	return &FeeSuggestions{
+		BaseFee:  input.NextBaseFee,
+		Slow:     suggest(tips.Slow),
+		Standard: suggest(tips.Standard),
+		Fast:     suggest(tips.Fast),
+	}, nil
+}
+
+// blockInterval returns the average interval in milliseconds between the blocks of
+// [input], or the target block rate if there are fewer than two blocks.
+func (oracle *Oracle) blockInterval(head *types.Header, input *StrategyInput) (uint64, error) {
+	if n := len(input.Blocks); n > 1 {
+		newest, oldest := input.Blocks[0], input.Blocks[n-1]
+		if interval := (newest.TimeMilliseconds - oldest.TimeMilliseconds) / uint64(n-1); interval > 0 {
+			return interval, nil
+		}
+	}
+	feeConfig, _, err := oracle.backend.GetFeeConfigAt(head)
+	if err != nil {
+		return 0, err
+	}
+	return max(feeConfig.TargetBlockRate*1000, 1), nil
+}
+
+// expectedInclusionBlocks returns the expected number of blocks until a transaction
+// paying [tip] is included, assuming each block includes it with the probability
+// that one of [blocks] would have. A block would have included it if it had room for
+// another transaction or if its lowest tip was at most [tip]. If none of [blocks]
+// would have, one more block than [blocks] is returned.
+func expectedInclusionBlocks(tip *big.Int, blocks []*BlockFees) uint64 {
+	if len(blocks) == 0 {
+		return 1
+	}
+	var included uint64
+	for _, block := range blocks {
+		if block.GasUsed+ethparams.TxGas <= block.GasLimit || len(block.Tips) == 0 || block.Tips[0].Cmp(tip) <= 0 {
+			included++
+		}
+	}
+	n := uint64(len(blocks))
+	if included == 0 {
+		return n + 1
+	}
+	// The expected number of trials until the first success of a geometric distribution.
+	return (n + included - 1) / included
+}
+
+// suggestTip estimates the gas tip of transactions of standard priority.
+func (oracle *Oracle) suggestTip(ctx context.Context) (*big.Int, error) {
+	tips, _, err := oracle.suggestTips(ctx)
+	return tips.Standard, err
+}
+
+// suggestTips estimates the gas tips of transactions of each priority with the
+// strategy of the oracle, and returns the input they were suggested from.
+// Suggestions are cached until the head changes or they exceed the max age of the strategy.
+func (oracle *Oracle) suggestTips(ctx context.Context) (Tips, *StrategyInput, error) {
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return Tips{}, nil, err
+	}
 
 	headHash := head.Hash()
 
-	// If the latest gasprice is still available, return it.
+	// If the latest suggestions are still available, return them.
 	oracle.cacheLock.RLock()
-	lastHead, lastPrice := oracle.lastHead, oracle.lastPrice
+	lastHead, lastTime, lastTips, lastInput := oracle.lastHead, oracle.lastTime, oracle.lastTips, oracle.lastInput
 	oracle.cacheLock.RUnlock()
-	if headHash == lastHead {
-		return new(big.Int).Set(lastPrice), nil
+	if oracle.fresh(headHash, lastHead, lastTime) {
+		return lastTips.copy(), lastInput, nil
 	}
 	oracle.fetchLock.Lock()
 	defer oracle.fetchLock.Unlock()
 
 	// Try checking the cache again, maybe the last fetch fetched what we need
 	oracle.cacheLock.RLock()
-	lastHead, lastPrice = oracle.lastHead, oracle.lastPrice
+	lastHead, lastTime, lastTips, lastInput = oracle.lastHead, oracle.lastTime, oracle.lastTips, oracle.lastInput
 	oracle.cacheLock.RUnlock()
-	if headHash == lastHead {
-		return new(big.Int).Set(lastPrice), nil
+	if oracle.fresh(headHash, lastHead, lastTime) {
+		return lastTips.copy(), lastInput, nil
+	}
+
+	input, err := oracle.strategyInput(ctx, head)
+	if err != nil {
+		return lastTips.copy(), lastInput, err
+	}
+	tips, err := oracle.strategy.SuggestTips(ctx, input)
+	if err != nil {
+		return lastTips.copy(), lastInput, err
+	}
+	tips = oracle.sanitizeTips(tips, lastTips)
+
+	oracle.cacheLock.Lock()
+	oracle.lastHead = headHash
+	oracle.lastTime = oracle.clock.Time()
+	oracle.lastTips = tips
+	oracle.lastInput = input
+	oracle.cacheLock.Unlock()
+
+	return tips.copy(), input, nil
+}
+
+// fresh returns whether the suggestions made at [lastTime] for [lastHead] can be
+// reused for [headHash], as bounded by the max age of the strategy of the oracle.
+func (oracle *Oracle) fresh(headHash, lastHead common.Hash, lastTime time.Time) bool {
+	if headHash != lastHead {
+		return false
 	}
+	maxAge := oracle.strategy.MaxAge()
+	return maxAge == 0 || oracle.clock.Time().Sub(lastTime) < maxAge
+}
+
+// strategyInput collects the fees of the blocks up to [head] within the lookback
+// window of the oracle.
+func (oracle *Oracle) strategyInput(ctx context.Context, head *types.Header) (*StrategyInput, error) {
 	var (
 		latestBlockNumber     = head.Number.Uint64()
 		lowerBlockNumberLimit = uint64(0)
 		currentTime           = oracle.clock.Unix()
-		tipResults            []*big.Int
+		input                 = &StrategyInput{GasLimit: head.GasLimit}
 	)
 
 	if uint64(oracle.checkBlocks) <= latestBlockNumber {
@@ -315,34 +470,59 @@ func (oracle *Oracle) suggestTip(ctx context.Context) (*big.Int, error) {
 	for i := latestBlockNumber; i > lowerBlockNumberLimit; i-- {
 		feeInfo, err := oracle.getFeeInfo(ctx, i)
 		if err != nil {
-			return new(big.Int).Set(lastPrice), err
+			return nil, err
 		}
 
 		if feeInfo.timestamp+oracle.maxLookbackSeconds < currentTime {
 			break
 		}
 
-		tipResults = append(tipResults, feeInfo.tips...)
+		tips := slices.Clone(feeInfo.tips)
+		slices.SortFunc(tips, func(a, b *big.Int) int { return a.Cmp(b) })
+		input.Blocks = append(input.Blocks, &BlockFees{
+			Number:           feeInfo.number,
+			TimeMilliseconds: feeInfo.timeMilliseconds,
+			BaseFee:          feeInfo.baseFee,
+			Tips:             tips,
+			GasUsed:          feeInfo.gasUsed,
+			GasLimit:         feeInfo.gasLimit,
+		})
 	}
 
-	price := lastPrice
-	if len(tipResults) > 0 {
-		slices.SortFunc(tipResults, func(a, b *big.Int) int { return a.Cmp(b) })
-		price = tipResults[(len(tipResults)-1)*oracle.percentile/100]
+	// The tips do not depend on the next base fee unless the strategy uses it, so
+	// fail to estimate it softly, as the suggestions from blocks remain valid.
+	nextBaseFee, err := oracle.estimateNextBaseFee(ctx)
+	if err != nil {
+		log.Warn("failed to estimate next base fee", "err", err)
 	}
+	input.NextBaseFee = nextBaseFee
+	return input, nil
+}
 
-	if price.Cmp(oracle.maxPrice) > 0 {
-		price = new(big.Int).Set(oracle.maxPrice)
+// sanitizeTips replaces the tips missing from [tips] with [lastTips], bounds them
+// by the minimum and maximum prices of the oracle, and ensures that higher priorities
+// are never suggested lower tips.
+func (oracle *Oracle) sanitizeTips(tips Tips, lastTips Tips) Tips {
+	sanitize := func(tip *big.Int, lastTip *big.Int) *big.Int {
+		if tip == nil {
+			tip = lastTip
+		}
+		if tip.Cmp(oracle.maxPrice) > 0 {
+			tip = oracle.maxPrice
+		}
+		if tip.Cmp(oracle.minPrice) < 0 {
+			tip = oracle.minPrice
+		}
+		return new(big.Int).Set(tip)
 	}
-	if price.Cmp(oracle.minPrice) < 0 {
-		price = new(big.Int).Set(oracle.minPrice)
+	tips = Tips{
+		Slow:     sanitize(tips.Slow, lastTips.Slow),
+		Standard: sanitize(tips.Standard, lastTips.Standard),
+		Fast:     sanitize(tips.Fast, lastTips.Fast),
 	}
-	oracle.cacheLock.Lock()
-	oracle.lastHead = headHash
-	oracle.lastPrice = price
-	oracle.cacheLock.Unlock()
-
-	return new(big.Int).Set(price), nil
+	tips.Standard = maxTip(tips.Standard, tips.Slow)
+	tips.Fast = maxTip(tips.Fast, tips.Standard)
+	return tips
 }
 
 // getFeeInfo calculates the minimum required tip to be included in a given
@@ -0,0 +1,255 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+)
+
+// Names of the built-in strategies, selected with [Config.Strategy].
+const (
+	// PercentileStrategy suggests the tips at fixed percentiles of the tips paid in recent blocks.
+	PercentileStrategy = "percentile"
+	// MempoolStrategy raises the tips of [PercentileStrategy] to outbid the pending transactions
+	// that fill the next blocks.
+	MempoolStrategy = "mempool"
+	// EMAStrategy suggests the exponential moving average of the percentile tips of recent blocks.
+	EMAStrategy = "ema"
+)
+
+// Number of blocks a transaction with the tip suggested by [MempoolStrategy] is
+// expected to wait for, if the pending transactions paying higher tips fill them.
+const (
+	mempoolFastBlocks     = 1
+	mempoolStandardBlocks = 2
+	mempoolSlowBlocks     = 4
+)
+
+// mempoolMaxAge is how long the suggestions of [MempoolStrategy] are reused while
+// the head does not change, so that the transaction pool is not ranked on every call.
+const mempoolMaxAge = 2 * time.Second
+
+var (
+	errUnknownStrategy    = errors.New("unknown gas price oracle strategy")
+	errMempoolUnavailable = errors.New("oracle backend does not expose the transaction pool")
+)
+
+// Tips are suggested gas tips for transactions of each priority.
+type Tips struct {
+	Slow     *big.Int
+	Standard *big.Int
+	Fast     *big.Int
+}
+
+func (t Tips) copy() Tips {
+	return Tips{
+		Slow:     new(big.Int).Set(t.Slow),
+		Standard: new(big.Int).Set(t.Standard),
+		Fast:     new(big.Int).Set(t.Fast),
+	}
+}
+
+// BlockFees are the fees paid in a recent block.
+type BlockFees struct {
+	Number           uint64
+	TimeMilliseconds uint64
+	BaseFee          *big.Int
+	// Tips are the effective tips of the transactions in the block, in ascending order.
+	Tips     []*big.Int
+	GasUsed  uint64
+	GasLimit uint64
+}
+
+// StrategyInput is the state of the fee market a [Strategy] suggests tips from.
+type StrategyInput struct {
+	// Blocks are the recent blocks within the lookback window of the oracle, newest first.
+	Blocks []*BlockFees
+	// NextBaseFee is the estimated base fee of the next block, or nil if Subnet-EVM is
+	// not activated.
+	NextBaseFee *big.Int
+	// GasLimit is the gas limit of the latest block.
+	GasLimit uint64
+}
+
+// Strategy suggests gas tips from the recent state of the fee market.
+// A strategy may leave a tip nil if it has no data to suggest it, in which
+// case the oracle falls back to its previous suggestion.
+type Strategy interface {
+	SuggestTips(ctx context.Context, input *StrategyInput) (Tips, error)
+	// MaxAge returns how long the suggestions of the strategy are reused while the
+	// head does not change, or 0 if they are reused until the head changes.
+	MaxAge() time.Duration
+}
+
+// MempoolBackend is implemented by oracle backends exposing the transaction pool.
+type MempoolBackend interface {
+	TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction)
+}
+
+// newStrategy returns the built-in strategy called [name]. The standard tip of
+// the percentile based strategies is at [percentile], the slow tip halfway to 0
+// and the fast tip halfway to 100.
+func newStrategy(name string, percentile int, backend OracleBackend) (Strategy, error) {
+	percentiles := [3]int{percentile / 2, percentile, (percentile + 100) / 2}
+	switch name {
+	case "", PercentileStrategy:
+		return &percentileStrategy{percentiles: percentiles}, nil
+	case EMAStrategy:
+		return &emaStrategy{percentiles: percentiles}, nil
+	case MempoolStrategy:
+		pool, ok := backend.(MempoolBackend)
+		if !ok {
+			return nil, errMempoolUnavailable
+		}
+		return &mempoolStrategy{
+			pool:     pool,
+			fallback: &percentileStrategy{percentiles: percentiles},
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownStrategy, name)
+	}
+}
+
+// percentileAt returns the tip at [percentile] of the ascending [tips].
+func percentileAt(tips []*big.Int, percentile int) *big.Int {
+	return tips[(len(tips)-1)*percentile/100]
+}
+
+type percentileStrategy struct {
+	percentiles [3]int
+}
+
+func (*percentileStrategy) MaxAge() time.Duration { return 0 }
+
+func (s *percentileStrategy) SuggestTips(_ context.Context, input *StrategyInput) (Tips, error) {
+	var tips []*big.Int
+	for _, block := range input.Blocks {
+		tips = append(tips, block.Tips...)
+	}
+	if len(tips) == 0 {
+		return Tips{}, nil
+	}
+	slices.SortFunc(tips, func(a, b *big.Int) int { return a.Cmp(b) })
+	return Tips{
+		Slow:     percentileAt(tips, s.percentiles[0]),
+		Standard: percentileAt(tips, s.percentiles[1]),
+		Fast:     percentileAt(tips, s.percentiles[2]),
+	}, nil
+}
+
+// emaStrategy weighs recent blocks more than [percentileStrategy], so that its
+// suggestions follow a rising fee market faster. Blocks without transactions
+// carry no information about the tips required and are skipped.
+type emaStrategy struct {
+	percentiles [3]int
+}
+
+func (*emaStrategy) MaxAge() time.Duration { return 0 }
+
+func (s *emaStrategy) SuggestTips(_ context.Context, input *StrategyInput) (Tips, error) {
+	var blocks []*BlockFees
+	for _, block := range input.Blocks {
+		if len(block.Tips) > 0 {
+			blocks = append(blocks, block)
+		}
+	}
+	if len(blocks) == 0 {
+		return Tips{}, nil
+	}
+
+	// The smoothing factor is 2/(n+1), so that the average has the same center
+	// of mass as a simple average of the n blocks.
+	var (
+		n    = big.NewInt(int64(len(blocks)))
+		emas [3]*big.Int
+	)
+	for i := len(blocks) - 1; i >= 0; i-- {
+		for j, percentile := range s.percentiles {
+			tip := percentileAt(blocks[i].Tips, percentile)
+			if emas[j] == nil {
+				emas[j] = new(big.Int).Set(tip)
+				continue
+			}
+			// ema = (2*tip + (n-1)*ema) / (n+1)
+			weighted := new(big.Int).Mul(emas[j], new(big.Int).Sub(n, common.Big1))
+			weighted.Add(weighted, new(big.Int).Lsh(tip, 1))
+			emas[j] = weighted.Div(weighted, new(big.Int).Add(n, common.Big1))
+		}
+	}
+	return Tips{Slow: emas[0], Standard: emas[1], Fast: emas[2]}, nil
+}
+
+// mempoolStrategy raises the tips suggested from recent blocks to outbid the
+// pending transactions that would otherwise fill the next blocks. The tips of
+// pending transactions are ranked regardless of their nonce ordering, so the
+// suggestions are an approximation when senders have nonce gaps.
+type mempoolStrategy struct {
+	pool     MempoolBackend
+	fallback Strategy
+}
+
+type pendingTip struct {
+	tip *big.Int
+	gas uint64
+}
+
+// MaxAge bounds how stale the view of the transaction pool can be, since pending
+// transactions change without the head changing.
+func (*mempoolStrategy) MaxAge() time.Duration { return mempoolMaxAge }
+
+func (s *mempoolStrategy) SuggestTips(ctx context.Context, input *StrategyInput) (Tips, error) {
+	tips, err := s.fallback.SuggestTips(ctx, input)
+	if err != nil || input.NextBaseFee == nil || input.GasLimit == 0 {
+		return tips, err
+	}
+
+	pending, _ := s.pool.TxPoolContent()
+	var pendingTips []pendingTip
+	for _, txs := range pending {
+		for _, tx := range txs {
+			tip, err := tx.EffectiveGasTip(input.NextBaseFee)
+			if err != nil {
+				// The transaction cannot be included until the base fee drops.
+				continue
+			}
+			pendingTips = append(pendingTips, pendingTip{tip: tip, gas: tx.Gas()})
+		}
+	}
+	slices.SortFunc(pendingTips, func(a, b pendingTip) int { return b.tip.Cmp(a.tip) })
+
+	tips.Slow = maxTip(tips.Slow, outbidTip(pendingTips, mempoolSlowBlocks*input.GasLimit))
+	tips.Standard = maxTip(tips.Standard, outbidTip(pendingTips, mempoolStandardBlocks*input.GasLimit))
+	tips.Fast = maxTip(tips.Fast, outbidTip(pendingTips, mempoolFastBlocks*input.GasLimit))
+	return tips, nil
+}
+
+// outbidTip returns the tip required to be ranked ahead of the pending transactions
+// that do not fit in [gas], given [pendingTips] in descending order. It returns nil
+// if all pending transactions fit.
+func outbidTip(pendingTips []pendingTip, gas uint64) *big.Int {
+	var cumulative uint64
+	for _, pending := range pendingTips {
+		cumulative += pending.gas
+		if cumulative > gas {
+			return new(big.Int).Add(pending.tip, common.Big1)
+		}
+	}
+	return nil
+}
+
+// maxTip returns the greater of [a] and [b], treating nil as missing.
+func maxTip(a, b *big.Int) *big.Int {
+	if a == nil || (b != nil && b.Cmp(a) > 0) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,217 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/params"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+func testBlockFees(gasUsed uint64, tips ...int64) *BlockFees {
+	fees := &BlockFees{GasUsed: gasUsed, GasLimit: 100 * ethparams.TxGas}
+	for _, tip := range tips {
+		fees.Tips = append(fees.Tips, big.NewInt(tip))
+	}
+	return fees
+}
+
+type testMempool struct {
+	pending map[common.Address][]*types.Transaction
+	calls   int
+}
+
+func (m *testMempool) TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
+	m.calls++
+	return m.pending, nil
+}
+
+// failingFeeConfigBackend fails to return the fee config, so that the next base fee
+// cannot be estimated.
+type failingFeeConfigBackend struct {
+	*testBackend
+}
+
+func (*failingFeeConfigBackend) GetFeeConfigAt(*types.Header) (commontype.FeeConfig, *big.Int, error) {
+	return commontype.FeeConfig{}, nil, errors.New("fee config unavailable")
+}
+
+func TestPercentileStrategy(t *testing.T) {
+	strategy, err := newStrategy(PercentileStrategy, 40, nil)
+	require.NoError(t, err)
+
+	tips, err := strategy.SuggestTips(context.Background(), &StrategyInput{})
+	require.NoError(t, err)
+	require.Equal(t, Tips{}, tips)
+
+	// Tips 1 to 10 spread over blocks: the 20th, 40th and 70th percentiles.
+	tips, err = strategy.SuggestTips(context.Background(), &StrategyInput{
+		Blocks: []*BlockFees{testBlockFees(0, 6, 7, 8, 9, 10), testBlockFees(0), testBlockFees(0, 1, 2, 3, 4, 5)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, Tips{Slow: big.NewInt(2), Standard: big.NewInt(4), Fast: big.NewInt(7)}, tips)
+}
+
+func TestEMAStrategy(t *testing.T) {
+	strategy, err := newStrategy(EMAStrategy, 50, nil)
+	require.NoError(t, err)
+
+	// Blocks are newest first, and the block without transactions is skipped. With
+	// 3 blocks the smoothing factor is 1/2: ((10+20)/2+40)/2 = 27.
+	tips, err := strategy.SuggestTips(context.Background(), &StrategyInput{
+		Blocks: []*BlockFees{testBlockFees(0, 40), testBlockFees(0), testBlockFees(0, 20), testBlockFees(0, 10)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, Tips{Slow: big.NewInt(27), Standard: big.NewInt(27), Fast: big.NewInt(27)}, tips)
+}
+
+func TestMempoolStrategy(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.LatestSigner(params.TestChainConfig)
+	pendingTx := func(nonce uint64, tip int64) *types.Transaction {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: big.NewInt(100 + tip),
+			Gas:       ethparams.TxGas,
+		})
+		require.NoError(t, err)
+		return tx
+	}
+	// Blocks fit 2 transactions. The transaction with a tip of 50 cannot pay the base fee.
+	pool := &testMempool{pending: map[common.Address][]*types.Transaction{
+		{1}: {pendingTx(0, 9), pendingTx(1, 8), pendingTx(2, 7), pendingTx(3, 6), pendingTx(4, 5)},
+		{2}: {pendingTx(0, 4), pendingTx(1, 3), pendingTx(2, 2), pendingTx(3, 1)},
+	}}
+	underpriced := pendingTx(0, 50)
+	underpriced, err = types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		GasTipCap: underpriced.GasTipCap(),
+		GasFeeCap: big.NewInt(99),
+		Gas:       ethparams.TxGas,
+	})
+	require.NoError(t, err)
+	pool.pending[common.Address{3}] = []*types.Transaction{underpriced}
+
+	strategy := &mempoolStrategy{pool: pool, fallback: &percentileStrategy{percentiles: [3]int{20, 40, 70}}}
+	input := &StrategyInput{
+		Blocks:      []*BlockFees{testBlockFees(0, 1, 2, 3)},
+		NextBaseFee: big.NewInt(100),
+		GasLimit:    2 * ethparams.TxGas,
+	}
+	tips, err := strategy.SuggestTips(context.Background(), input)
+	require.NoError(t, err)
+	// Fast outbids the third best transaction, standard the fifth and slow the ninth.
+	require.Equal(t, Tips{Slow: big.NewInt(2), Standard: big.NewInt(6), Fast: big.NewInt(8)}, tips)
+
+	// All pending transactions fit in the slow horizon with larger blocks.
+	input.GasLimit = 3 * ethparams.TxGas
+	tips, err = strategy.SuggestTips(context.Background(), input)
+	require.NoError(t, err)
+	require.Equal(t, Tips{Slow: big.NewInt(1), Standard: big.NewInt(4), Fast: big.NewInt(7)}, tips)
+}
+
+func TestMempoolStrategyCache(t *testing.T) {
+	backend := newTestBackend(t, 3, testGenBlockWithTips(t, []int64{1, 2, 3}))
+	defer backend.teardown()
+	oracle, err := NewOracle(backend, Config{Blocks: 20, Percentile: 40, MaxLookbackSeconds: 80})
+	require.NoError(t, err)
+	oracle.clock.Set(time.Unix(20, 0))
+	pool := &testMempool{}
+	oracle.strategy = &mempoolStrategy{pool: pool, fallback: oracle.strategy}
+
+	// The transaction pool is ranked once per head until the suggestions expire.
+	for i := 0; i < 3; i++ {
+		_, err := oracle.SuggestTipCap(context.Background())
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, pool.calls)
+
+	oracle.clock.Set(oracle.clock.Time().Add(mempoolMaxAge))
+	_, err = oracle.SuggestTipCap(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, pool.calls)
+}
+
+func TestSuggestTipCapWithoutNextBaseFee(t *testing.T) {
+	backend := newTestBackend(t, 3, testGenBlockWithTips(t, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}))
+	defer backend.teardown()
+	oracle, err := NewOracle(&failingFeeConfigBackend{backend}, Config{Blocks: 20, Percentile: 40, MaxLookbackSeconds: 80})
+	require.NoError(t, err)
+	oracle.clock.Set(time.Unix(20, 0))
+
+	tip, err := oracle.SuggestTipCap(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(4*params.GWei), tip)
+}
+
+func TestNewStrategy(t *testing.T) {
+	_, err := newStrategy("unknown", 40, nil)
+	require.ErrorIs(t, err, errUnknownStrategy)
+
+	backend := newTestBackend(t, 1, testGenBlock(t, 1, 1))
+	defer backend.teardown()
+	_, err = NewOracle(backend, Config{Strategy: MempoolStrategy})
+	require.ErrorIs(t, err, errMempoolUnavailable)
+}
+
+func TestExpectedInclusionBlocks(t *testing.T) {
+	full := 100 * ethparams.TxGas
+	blocks := []*BlockFees{
+		testBlockFees(full, 10, 20),
+		testBlockFees(full, 5, 20),
+		testBlockFees(0, 30),
+		testBlockFees(full, 30),
+	}
+	require.Equal(t, uint64(1), expectedInclusionBlocks(big.NewInt(30), blocks))
+	require.Equal(t, uint64(2), expectedInclusionBlocks(big.NewInt(10), blocks))
+	require.Equal(t, uint64(4), expectedInclusionBlocks(big.NewInt(1), blocks))
+	require.Equal(t, uint64(2), expectedInclusionBlocks(big.NewInt(1), blocks[:1]))
+	require.Equal(t, uint64(1), expectedInclusionBlocks(big.NewInt(1), nil))
+}
+
+func TestSuggestFees(t *testing.T) {
+	backend := newTestBackend(t, 3, testGenBlockWithTips(t, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}))
+	defer backend.teardown()
+	oracle, err := NewOracle(backend, Config{
+		Blocks:             20,
+		Percentile:         40,
+		MaxLookbackSeconds: 80,
+	})
+	require.NoError(t, err)
+	oracle.clock.Set(time.Unix(20, 0))
+
+	fees, err := oracle.SuggestFees(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, fees.BaseFee)
+
+	tipCap, err := oracle.SuggestTipCap(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, tipCap, fees.Standard.MaxPriorityFeePerGas)
+
+	require.Equal(t, big.NewInt(2*params.GWei), fees.Slow.MaxPriorityFeePerGas)
+	require.Equal(t, big.NewInt(4*params.GWei), fees.Standard.MaxPriorityFeePerGas)
+	require.Equal(t, big.NewInt(7*params.GWei), fees.Fast.MaxPriorityFeePerGas)
+	for _, fee := range []FeeSuggestion{fees.Slow, fees.Standard, fees.Fast} {
+		expectedMaxFee := new(big.Int).Add(fee.MaxPriorityFeePerGas, new(big.Int).Lsh(fees.BaseFee, 1))
+		require.Equal(t, expectedMaxFee, fee.MaxFeePerGas)
+		// The test blocks are not full, so transactions are included in the next block.
+		require.Equal(t, uint64(1), fee.ExpectedInclusionBlocks)
+		require.NotZero(t, fee.ExpectedInclusionMilliseconds)
+	}
+}
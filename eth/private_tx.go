@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/log"
+)
+
+// privateTxExpiryInterval is how often the expired private transactions are
+// dropped or released to public gossip.
+const privateTxExpiryInterval = time.Second
+
+// privateTxBackend is an [EthAPIBackend] adding the transactions sent over the
+// RPC to the transaction pool as private transactions.
+type privateTxBackend struct {
+	*EthAPIBackend
+}
+
+// SendTx adds [signedTx] to the transaction pool for inclusion only by the
+// blocks built by this node, without pushing it to peers.
+func (b privateTxBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := b.admitOrigin(ctx); err != nil {
+		return err
+	}
+	expiry := b.eth.clock.Time().Add(b.eth.config.PrivateTxLifetime)
+	return b.eth.txPool.AddPrivate(signedTx, expiry)
+}
+
+// expirePrivateTxs periodically drops the expired private transactions from the
+// transaction pool, or releases them to public gossip if [Config.PrivateTxRelease]
+// is set, until [Ethereum.Stop] is called.
+func (s *Ethereum) expirePrivateTxs() {
+	ticker := time.NewTicker(privateTxExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			release := s.config.PrivateTxRelease
+			for _, tx := range s.txPool.ExpirePrivate(s.clock.Time(), release) {
+				if release {
+					log.Debug("Releasing expired private transaction", "hash", tx.Hash())
+					s.gossiper.Add(tx)
+					continue
+				}
+				log.Debug("Dropped expired private transaction", "hash", tx.Hash())
+			}
+		case <-s.closePrivateTxs:
+			return
+		}
+	}
+}
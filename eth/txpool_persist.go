@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/log"
+
+	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
+)
+
+// txPoolPersistInterval is how often the transactions of the pool are saved to
+// the database if [Config.PersistTxPool] is set, so that they also survive
+// unclean shutdowns.
+const txPoolPersistInterval = time.Minute
+
+// loadTxPool adds the transactions saved by [Ethereum.saveTxPool] back to the
+// transaction pool, which revalidates them against the current head. It is
+// called from [New], before the transactions of the pool can be gossiped.
+func (s *Ethereum) loadTxPool() {
+	saved, err := customrawdb.ReadPoolTransactions(s.chainDb)
+	if err != nil {
+		log.Warn("Failed to read saved transaction pool", "err", err)
+		return
+	}
+	if len(saved) == 0 {
+		return
+	}
+	var locals, remotes []*types.Transaction
+	for _, tx := range saved {
+		if tx.Local {
+			locals = append(locals, tx.Tx)
+		} else {
+			remotes = append(remotes, tx.Tx)
+		}
+	}
+	var dropped int
+	for _, err := range append(s.txPool.Add(locals, true, true), s.txPool.Add(remotes, false, true)...) {
+		if err != nil {
+			dropped++
+		}
+	}
+	log.Info("Loaded saved transaction pool", "transactions", len(saved), "dropped", dropped)
+}
+
+// saveTxPool saves the pending and queued transactions of the pool to the
+// database, replacing the previously saved ones. Private and conditional
+// transactions are not saved, as they would be added back to the pool as
+// regular transactions.
+func (s *Ethereum) saveTxPool() {
+	locals := make(map[common.Address]struct{})
+	for _, addr := range s.txPool.Locals() {
+		locals[addr] = struct{}{}
+	}
+	var txs []customrawdb.PoolTransaction
+	pending, queued := s.txPool.Content()
+	for _, content := range []map[common.Address][]*types.Transaction{pending, queued} {
+		for addr, accTxs := range content {
+			_, local := locals[addr]
+			for _, tx := range accTxs {
+				hash := tx.Hash()
+				if s.txPool.IsPrivate(hash) || s.txPool.Conditional(hash) != nil {
+					continue
+				}
+				txs = append(txs, customrawdb.PoolTransaction{Tx: tx, Local: local})
+			}
+		}
+	}
+	if err := customrawdb.WritePoolTransactions(s.chainDb, txs); err != nil {
+		log.Warn("Failed to save transaction pool", "err", err)
+		return
+	}
+	log.Debug("Saved transaction pool", "transactions", len(txs))
+}
+
+// persistTxPool periodically saves the transactions of the pool to the database
+// until [Ethereum.Stop] is called.
+func (s *Ethereum) persistTxPool() {
+	defer s.txPersistWg.Done()
+
+	ticker := time.NewTicker(txPoolPersistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.saveTxPool()
+		case <-s.closeTxPersist:
+			return
+		}
+	}
+}
@@ -36,12 +36,21 @@ import (
 	"testing"
 	"time"
 
+	ethereum "github.com/ava-labs/libevm"
 	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/hexutil"
 	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
 	"github.com/ava-labs/libevm/crypto"
 	ethparams "github.com/ava-labs/libevm/params"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/encryptedpool"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/eth/ethconfig"
+	"github.com/ava-labs/subnet-evm/node"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/stretchr/testify/require"
@@ -160,6 +169,394 @@ func TestSendTransaction(t *testing.T) {
 	}
 }
 
+func TestSendPrivateRawTransaction(t *testing.T) {
+	sim := simTestBackend(testAddr)
+	defer sim.Close()
+
+	client := sim.Client()
+	ctx := context.Background()
+
+	signedTx, err := newTx(sim, testKey)
+	require.NoError(t, err)
+	input, err := signedTx.MarshalBinary()
+	require.NoError(t, err)
+
+	var hash common.Hash
+	require.NoError(t, sim.client.Client.Client().CallContext(ctx, &hash, "eth_sendPrivateRawTransaction", hexutil.Bytes(input)))
+	require.Equal(t, signedTx.Hash(), hash)
+	require.True(t, sim.eth.TxPool().IsPrivate(hash))
+
+	// The private transaction is included by the local block builder.
+	sim.Commit(false)
+	block, err := client.BlockByNumber(ctx, big.NewInt(1))
+	require.NoError(t, err)
+	require.Len(t, block.Transactions(), 1)
+	require.Equal(t, hash, block.Transactions()[0].Hash())
+}
+
+func TestSendBundle(t *testing.T) {
+	require := require.New(t)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(err)
+	sim := NewBackend(types.GenesisAlloc{
+		testAddr: {Balance: big.NewInt(10000000000000000)},
+		crypto.PubkeyToAddress(otherKey.PublicKey): {Balance: big.NewInt(10000000000000000)},
+	})
+	defer sim.Close()
+
+	client := sim.Client()
+	ctx := context.Background()
+	head, err := client.HeaderByNumber(ctx, nil)
+	require.NoError(err)
+	signer := types.LatestSignerForChainID(big.NewInt(1337))
+	newBundleTx := func(nonce uint64, to *common.Address, data []byte, gas uint64) hexutil.Bytes {
+		tx, err := types.SignNewTx(testKey, signer, &types.DynamicFeeTx{
+			ChainID:   big.NewInt(1337),
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: new(big.Int).Add(head.BaseFee, big.NewInt(params.GWei)),
+			Gas:       gas,
+			To:        to,
+			Data:      data,
+		})
+		require.NoError(err)
+		input, err := tx.MarshalBinary()
+		require.NoError(err)
+		return input
+	}
+	sendBundle := func(txs ...hexutil.Bytes) common.Hash {
+		var result struct {
+			BundleHash common.Hash `json:"bundleHash"`
+		}
+		require.NoError(sim.client.Client.Client().CallContext(ctx, &result, "eth_sendBundle", map[string]interface{}{"txs": txs}))
+		return result.BundleHash
+	}
+
+	// Bundles are included at the front of the block, before the public
+	// transaction sent first.
+	publicTx, err := newTx(sim, otherKey)
+	require.NoError(err)
+	require.NoError(client.SendTransaction(ctx, publicTx))
+	included := []hexutil.Bytes{
+		newBundleTx(0, &testAddr, nil, ethparams.TxGas),
+		newBundleTx(1, &testAddr, nil, ethparams.TxGas),
+	}
+	sendBundle(included...)
+	// The second transaction of this bundle reverts, so none of them is included.
+	sendBundle(
+		newBundleTx(2, &testAddr, nil, ethparams.TxGas),
+		newBundleTx(3, nil, []byte{byte(vm.INVALID)}, 100_000),
+	)
+	require.Equal(2, sim.eth.BundlePool().Len())
+
+	sim.Commit(false)
+	block, err := client.BlockByNumber(ctx, big.NewInt(1))
+	require.NoError(err)
+	var got []common.Hash
+	for _, tx := range block.Transactions() {
+		got = append(got, tx.Hash())
+	}
+	want := make([]common.Hash, 0, len(included)+1)
+	for _, input := range included {
+		tx := new(types.Transaction)
+		require.NoError(tx.UnmarshalBinary(input))
+		want = append(want, tx.Hash())
+	}
+	want = append(want, publicTx.Hash())
+	require.Equal(want, got)
+
+	// The included bundle is removed from the pool, while the failed bundle is
+	// retried until it expires.
+	require.Equal(1, sim.eth.BundlePool().Len())
+
+	// Bundles with transactions that cannot be included on top of the head are rejected.
+	err = sim.client.Client.Client().CallContext(ctx, nil, "eth_sendBundle", map[string]interface{}{
+		"txs": []hexutil.Bytes{newBundleTx(0, &testAddr, nil, ethparams.TxGas)},
+	})
+	require.ErrorContains(err, core.ErrNonceTooLow.Error())
+
+	// A bundle taking the nonce of the failed bundle is included after it fails
+	// again, and the failed bundle is evicted once its nonce is used.
+	sendBundle(newBundleTx(2, &testAddr, nil, ethparams.TxGas+1))
+	sim.Commit(false)
+	require.Equal(1, sim.eth.BundlePool().Len())
+	sim.Commit(false)
+	require.Zero(sim.eth.BundlePool().Len())
+}
+
+func TestMaxBuildTime(t *testing.T) {
+	require := require.New(t)
+	keys := make([]*ecdsa.PrivateKey, 3)
+	alloc := make(types.GenesisAlloc)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(err)
+		keys[i] = key
+		alloc[crypto.PubkeyToAddress(key.PublicKey)] = types.Account{Balance: big.NewInt(10000000000000000)}
+	}
+	// The build time is always exhausted, so each block only includes the
+	// first transaction committed.
+	sim := NewBackend(alloc, func(_ *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Miner.MaxBuildTime = time.Nanosecond
+	})
+	defer sim.Close()
+
+	client := sim.Client()
+	ctx := context.Background()
+	for _, key := range keys {
+		tx, err := newTx(sim, key)
+		require.NoError(err)
+		require.NoError(client.SendTransaction(ctx, tx))
+	}
+	for number := int64(1); number <= int64(len(keys)); number++ {
+		sim.Commit(false)
+		block, err := client.BlockByNumber(ctx, big.NewInt(number))
+		require.NoError(err)
+		require.Len(block.Transactions(), 1)
+	}
+	pending, queued := sim.eth.TxPool().Stats()
+	require.Zero(pending)
+	require.Zero(queued)
+}
+
+func TestPrepareBlock(t *testing.T) {
+	require := require.New(t)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(err)
+	sim := NewBackend(types.GenesisAlloc{
+		testAddr: {Balance: big.NewInt(10000000000000000)},
+		crypto.PubkeyToAddress(otherKey.PublicKey): {Balance: big.NewInt(10000000000000000)},
+	}, func(_ *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Miner.BuildSlice = time.Second
+	})
+	defer sim.Close()
+
+	client := sim.Client()
+	ctx := context.Background()
+	preparedTx, err := newTx(sim, testKey)
+	require.NoError(err)
+	require.NoError(client.SendTransaction(ctx, preparedTx))
+	require.NoError(sim.eth.TxPool().Sync())
+
+	// Prepare the block at the time it is built by Commit.
+	parent := sim.eth.BlockChain().CurrentBlock()
+	sim.clock.Set(time.Unix(int64(parent.Time+10), 0))
+	require.NoError(sim.eth.Miner().PrepareBlock(time.Time{}))
+
+	// A transaction paying a higher tip, sent after the block was prepared,
+	// comes after the prepared transaction.
+	chainID, err := client.ChainID(ctx)
+	require.NoError(err)
+	tipTx := func(nonce uint64) *types.Transaction {
+		head, err := client.HeaderByNumber(ctx, nil)
+		require.NoError(err)
+		to := crypto.PubkeyToAddress(otherKey.PublicKey)
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(2 * params.GWei),
+			GasFeeCap: new(big.Int).Add(head.BaseFee, big.NewInt(2*params.GWei)),
+			Gas:       21000,
+			To:        &to,
+		}), types.LatestSignerForChainID(chainID), otherKey)
+		require.NoError(err)
+		require.NoError(client.SendTransaction(ctx, tx))
+		return tx
+	}
+	blockTxs := func(number int64) []common.Hash {
+		block, err := client.BlockByNumber(ctx, big.NewInt(number))
+		require.NoError(err)
+		var hashes []common.Hash
+		for _, tx := range block.Transactions() {
+			hashes = append(hashes, tx.Hash())
+		}
+		return hashes
+	}
+	laterTx := tipTx(0)
+
+	sim.Commit(false)
+	require.Equal([]common.Hash{preparedTx.Hash(), laterTx.Hash()}, blockTxs(1))
+
+	// A block prepared too long before it is built is discarded, so the
+	// transactions are ordered by tip.
+	staleTx, err := newTx(sim, testKey)
+	require.NoError(err)
+	require.NoError(client.SendTransaction(ctx, staleTx))
+	require.NoError(sim.eth.TxPool().Sync())
+	require.NoError(sim.eth.Miner().PrepareBlock(time.Time{}))
+	laterTx = tipTx(1)
+
+	sim.Commit(false)
+	require.Equal([]common.Hash{laterTx.Hash(), staleTx.Hash()}, blockTxs(2))
+}
+
+func TestSendEncryptedTransaction(t *testing.T) {
+	require := require.New(t)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(err)
+	envelopeKey, err := crypto.GenerateKey()
+	require.NoError(err)
+	key, err := encryptedpool.NewLocalKey(make([]byte, encryptedpool.LocalKeySize))
+	require.NoError(err)
+	sim := NewBackend(types.GenesisAlloc{
+		testAddr: {Balance: big.NewInt(10000000000000000)},
+		crypto.PubkeyToAddress(otherKey.PublicKey):    {Balance: big.NewInt(10000000000000000)},
+		crypto.PubkeyToAddress(envelopeKey.PublicKey): {Balance: big.NewInt(10000000000000000)},
+	}, func(_ *node.Config, ethConf *ethconfig.Config) {
+		envelopeConfig := extras.DefaultEnvelopeConfig
+		params.GetExtra(ethConf.Genesis.Config).EnvelopeConfig = &envelopeConfig
+		ethConf.EnvelopeDecrypter = key
+	})
+	defer sim.Close()
+
+	client := sim.Client()
+	ctx := context.Background()
+	chainID, err := client.ChainID(ctx)
+	require.NoError(err)
+	head, err := client.HeaderByNumber(ctx, nil)
+	require.NoError(err)
+	sendEnvelope := func(nonce uint64, ciphertext []byte) common.Hash {
+		to := core.EnvelopeAddress
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: new(big.Int).Add(head.BaseFee, big.NewInt(params.GWei)),
+			Gas:       100_000,
+			To:        &to,
+			Data:      ciphertext,
+		}), types.LatestSignerForChainID(chainID), envelopeKey)
+		require.NoError(err)
+		input, err := tx.MarshalBinary()
+		require.NoError(err)
+		var hash common.Hash
+		require.NoError(sim.client.Client.Client().CallContext(ctx, &hash, "eth_sendEncryptedTransaction", hexutil.Bytes(input)))
+		require.Equal(tx.Hash(), hash)
+		return hash
+	}
+	blockTxs := func(number int64) []common.Hash {
+		block, err := client.BlockByNumber(ctx, big.NewInt(number))
+		require.NoError(err)
+		var hashes []common.Hash
+		for _, tx := range block.Transactions() {
+			hashes = append(hashes, tx.Hash())
+		}
+		return hashes
+	}
+
+	publicTx, err := newTx(sim, otherKey)
+	require.NoError(err)
+	require.NoError(client.SendTransaction(ctx, publicTx))
+	encryptedTx, err := newTx(sim, testKey)
+	require.NoError(err)
+	ciphertext, err := key.Seal(encryptedTx)
+	require.NoError(err)
+	envelope := sendEnvelope(0, ciphertext)
+	// This envelope cannot be decrypted, but still pays for its gas.
+	undecryptable := sendEnvelope(1, []byte("not a transaction"))
+	require.Equal(2, sim.eth.EncryptedPool().Len())
+
+	// The encrypted transaction is not in the transaction pool.
+	_, pending, err := client.TransactionByHash(ctx, encryptedTx.Hash())
+	require.ErrorIs(err, ethereum.NotFound)
+	require.False(pending)
+
+	// The envelopes are included at the front of the block, without being
+	// decrypted, and leave the pool once the block is accepted.
+	sim.Commit(true)
+	require.Equal([]common.Hash{envelope, undecryptable, publicTx.Hash()}, blockTxs(1))
+	receipt, err := client.TransactionReceipt(ctx, undecryptable)
+	require.NoError(err)
+	require.Equal(types.ReceiptStatusSuccessful, receipt.Status)
+	require.NotZero(receipt.GasUsed)
+	require.Eventually(func() bool {
+		return sim.eth.EncryptedPool().Len() == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// The block builder starts the next block with the encrypted transaction,
+	// before the public transaction sent first.
+	nextPublicTx, err := newTx(sim, otherKey)
+	require.NoError(err)
+	require.NoError(client.SendTransaction(ctx, nextPublicTx))
+	sim.Commit(true)
+	require.Equal([]common.Hash{encryptedTx.Hash(), nextPublicTx.Hash()}, blockTxs(2))
+}
+
+func TestSendRawTransactionConditional(t *testing.T) {
+	require := require.New(t)
+	sim := simTestBackend(testAddr)
+	defer sim.Close()
+
+	client := sim.Client()
+	ctx := context.Background()
+
+	signedTx, err := newTx(sim, testKey)
+	require.NoError(err)
+	input, err := signedTx.MarshalBinary()
+	require.NoError(err)
+	sendConditional := func(conditional map[string]interface{}) error {
+		var hash common.Hash
+		return sim.client.Client.Client().CallContext(ctx, &hash, "eth_sendRawTransactionConditional", hexutil.Bytes(input), conditional)
+	}
+
+	// Conditions the current state does not meet are rejected.
+	err = sendConditional(map[string]interface{}{
+		"knownAccounts": map[common.Address]map[common.Hash]common.Hash{
+			testAddr: {{}: common.HexToHash("0x01")},
+		},
+	})
+	require.ErrorContains(err, txpool.ErrConditionalFailed.Error())
+
+	// The transaction is only included once the block number condition is met.
+	require.NoError(sendConditional(map[string]interface{}{
+		"knownAccounts":  map[common.Address]map[common.Hash]common.Hash{testAddr: {{}: {}}},
+		"blockNumberMin": hexutil.Uint64(2),
+	}))
+	sim.Commit(false)
+	block, err := client.BlockByNumber(ctx, big.NewInt(1))
+	require.NoError(err)
+	require.Empty(block.Transactions())
+
+	sim.Commit(false)
+	block, err = client.BlockByNumber(ctx, big.NewInt(2))
+	require.NoError(err)
+	require.Len(block.Transactions(), 1)
+	require.Equal(signedTx.Hash(), block.Transactions()[0].Hash())
+}
+
+func TestTxPoolExplain(t *testing.T) {
+	require := require.New(t)
+	sim := simTestBackend(testAddr)
+	defer sim.Close()
+
+	ctx := context.Background()
+	signedTx, err := newTx(sim, testKey)
+	require.NoError(err)
+	input, err := signedTx.MarshalBinary()
+	require.NoError(err)
+
+	explain := func(hash common.Hash) map[string]interface{} {
+		var result map[string]interface{}
+		require.NoError(sim.client.Client.Client().CallContext(ctx, &result, "txpool_explain", hash))
+		return result
+	}
+	require.Nil(explain(signedTx.Hash()))
+
+	// The block builder skips the transaction until its conditions are met.
+	require.NoError(sim.client.Client.Client().CallContext(ctx, nil, "eth_sendRawTransactionConditional", hexutil.Bytes(input), map[string]interface{}{
+		"knownAccounts":  map[common.Address]interface{}{},
+		"blockNumberMin": hexutil.Uint64(2),
+	}))
+	sim.Commit(false)
+
+	result := explain(signedTx.Hash())
+	require.Equal("pending", result["status"])
+	require.Equal("0x0", result["nonceGap"])
+	require.Equal(false, result["feeCapBelowBaseFee"])
+	require.Equal(false, result["notAllowListed"])
+	require.Contains(result["lastInclusionError"], txpool.ErrConditionalFailed.Error())
+}
+
 // TestFork check that the chain length after a reorg is correct.
 // Steps:
 //  1. Save the current block which will serve as parent for the fork.
@@ -52,6 +52,7 @@ import (
 	"github.com/ava-labs/libevm/trie"
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/eth/gasestimator"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
@@ -256,6 +257,63 @@ func (s *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	return content
 }
 
+// TxExplanation describes a pooled transaction and what may keep it from being
+// included in the next block.
+type TxExplanation struct {
+	Status                      string          `json:"status"`
+	Local                       bool            `json:"local"`
+	Nonce                       hexutil.Uint64  `json:"nonce"`
+	NextNonce                   hexutil.Uint64  `json:"nextNonce"`
+	NonceGap                    hexutil.Uint64  `json:"nonceGap"`
+	GasFeeCap                   *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	BaseFee                     *hexutil.Big    `json:"baseFee,omitempty"`
+	EstimatedBaseFee            *hexutil.Big    `json:"estimatedBaseFee,omitempty"`
+	FeeCapBelowBaseFee          bool            `json:"feeCapBelowBaseFee"`
+	FeeCapBelowEstimatedBaseFee bool            `json:"feeCapBelowEstimatedBaseFee"`
+	PriceRank                   *hexutil.Uint64 `json:"priceRank,omitempty"`
+	PricedTxs                   hexutil.Uint64  `json:"pricedTxs"`
+	NotAllowListed              bool            `json:"notAllowListed"`
+	LastInclusionError          string          `json:"lastInclusionError,omitempty"`
+}
+
+// Explain describes the pooled transaction with the given hash and what may keep
+// it from being included: whether it is pending or queued, the nonces missing
+// before it, how its fee cap compares to the base fee, its rank among the remote
+// transactions by price, whether its sender is allow listed, and the last error
+// the block builder hit when trying to include it. It returns nil if the
+// transaction is not in the pool.
+func (s *TxPoolAPI) Explain(hash common.Hash) *TxExplanation {
+	explanation := s.b.TxPoolExplain(hash)
+	if explanation == nil {
+		return nil
+	}
+	result := &TxExplanation{
+		Status:                      "pending",
+		Local:                       explanation.Local,
+		Nonce:                       hexutil.Uint64(explanation.Nonce),
+		NextNonce:                   hexutil.Uint64(explanation.NextNonce),
+		NonceGap:                    hexutil.Uint64(explanation.NonceGap),
+		GasFeeCap:                   (*hexutil.Big)(explanation.GasFeeCap),
+		BaseFee:                     (*hexutil.Big)(explanation.BaseFee),
+		EstimatedBaseFee:            (*hexutil.Big)(explanation.EstimatedBaseFee),
+		FeeCapBelowBaseFee:          explanation.FeeCapBelowBaseFee(),
+		FeeCapBelowEstimatedBaseFee: explanation.FeeCapBelowEstimatedBaseFee(),
+		PricedTxs:                   hexutil.Uint64(explanation.PricedTxs),
+		NotAllowListed:              explanation.NotAllowListed,
+	}
+	if explanation.Status == txpool.TxStatusQueued {
+		result.Status = "queued"
+	}
+	if explanation.PriceRank != 0 {
+		rank := hexutil.Uint64(explanation.PriceRank)
+		result.PriceRank = &rank
+	}
+	if err := s.b.TxInclusionError(hash); err != nil {
+		result.LastInclusionError = err.Error()
+	}
+	return result
+}
+
 // EthereumAccountAPI provides an API to access accounts managed by this node.
 // It offers only methods that can retrieve accounts.
 type EthereumAccountAPI struct {
@@ -1049,6 +1107,7 @@ func (diff *BlockOverrides) Apply(blockCtx *vm.BlockContext) {
 type ChainContextBackend interface {
 	Engine() consensus.Engine
 	HeaderByNumber(context.Context, rpc.BlockNumber) (*types.Header, error)
+	ChainConfig() *params.ChainConfig
 }
 
 // ChainContext is an implementation of core.ChainContext. It's main use-case
@@ -1067,6 +1126,10 @@ func (context *ChainContext) Engine() consensus.Engine {
 	return context.b.Engine()
 }
 
+func (context *ChainContext) Config() *params.ChainConfig {
+	return context.b.ChainConfig()
+}
+
 func (context *ChainContext) GetHeader(hash common.Hash, number uint64) *types.Header {
 	// This method is called to get the hash for a block number when executing the BLOCKHASH
 	// opcode. Hence no need to search for non-canonical blocks.
@@ -1866,6 +1929,32 @@ func (s *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil.B
 	return SubmitTransaction(ctx, s.b, tx)
 }
 
+// conditionalBackend is a [Backend] adding the transactions sent over the RPC to
+// the transaction pool with conditions.
+type conditionalBackend struct {
+	Backend
+	conditional *txpool.TransactionConditional
+}
+
+func (b conditionalBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
+	return b.SendConditionalTx(ctx, signedTx, b.conditional)
+}
+
+// SendRawTransactionConditional will add the signed transaction to the transaction
+// pool, to be included only while the given conditions on the state and on the
+// block hold. The transaction is evicted from the pool once they fail or expire,
+// and is never gossiped, as peers cannot check the conditions.
+func (s *TransactionAPI) SendRawTransactionConditional(ctx context.Context, input hexutil.Bytes, conditional txpool.TransactionConditional) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	if err := conditional.Validate(); err != nil {
+		return common.Hash{}, err
+	}
+	return SubmitTransaction(ctx, conditionalBackend{s.b, &conditional}, tx)
+}
+
 // Sign calculates an ECDSA signature for:
 // keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).
 //
@@ -26,6 +26,7 @@ import (
 	commontype "github.com/ava-labs/subnet-evm/commontype"
 	consensus "github.com/ava-labs/subnet-evm/consensus"
 	core "github.com/ava-labs/subnet-evm/core"
+	txpool "github.com/ava-labs/subnet-evm/core/txpool"
 	params "github.com/ava-labs/subnet-evm/params"
 	rpc "github.com/ava-labs/subnet-evm/rpc"
 	gomock "go.uber.org/mock/gomock"
@@ -528,6 +529,20 @@ func (mr *MockBackendMockRecorder) RPCTxFeeCap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPCTxFeeCap", reflect.TypeOf((*MockBackend)(nil).RPCTxFeeCap))
 }
 
+// SendConditionalTx mocks base method.
+func (m *MockBackend) SendConditionalTx(ctx context.Context, signedTx *types.Transaction, conditional *txpool.TransactionConditional) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendConditionalTx", ctx, signedTx, conditional)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendConditionalTx indicates an expected call of SendConditionalTx.
+func (mr *MockBackendMockRecorder) SendConditionalTx(ctx, signedTx, conditional any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendConditionalTx", reflect.TypeOf((*MockBackend)(nil).SendConditionalTx), ctx, signedTx, conditional)
+}
+
 // SendTx mocks base method.
 func (m *MockBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	m.ctrl.T.Helper()
@@ -729,6 +744,20 @@ func (mr *MockBackendMockRecorder) SuggestPrice(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestPrice", reflect.TypeOf((*MockBackend)(nil).SuggestPrice), ctx)
 }
 
+// TxInclusionError mocks base method.
+func (m *MockBackend) TxInclusionError(txHash common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxInclusionError", txHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TxInclusionError indicates an expected call of TxInclusionError.
+func (mr *MockBackendMockRecorder) TxInclusionError(txHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxInclusionError", reflect.TypeOf((*MockBackend)(nil).TxInclusionError), txHash)
+}
+
 // TxPoolContent mocks base method.
 func (m *MockBackend) TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
 	m.ctrl.T.Helper()
@@ -759,6 +788,20 @@ func (mr *MockBackendMockRecorder) TxPoolContentFrom(addr any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolContentFrom", reflect.TypeOf((*MockBackend)(nil).TxPoolContentFrom), addr)
 }
 
+// TxPoolExplain mocks base method.
+func (m *MockBackend) TxPoolExplain(txHash common.Hash) *txpool.TxExplanation {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxPoolExplain", txHash)
+	ret0, _ := ret[0].(*txpool.TxExplanation)
+	return ret0
+}
+
+// TxPoolExplain indicates an expected call of TxPoolExplain.
+func (mr *MockBackendMockRecorder) TxPoolExplain(txHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolExplain", reflect.TypeOf((*MockBackend)(nil).TxPoolExplain), txHash)
+}
+
 // UnprotectedAllowed mocks base method.
 func (m *MockBackend) UnprotectedAllowed(tx *types.Transaction) bool {
 	m.ctrl.T.Helper()
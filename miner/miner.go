@@ -29,12 +29,16 @@
 package miner
 
 import (
+	"time"
+
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/libevm/event"
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/bundlepool"
+	"github.com/ava-labs/subnet-evm/core/encryptedpool"
 	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -44,22 +48,33 @@ import (
 type Backend interface {
 	BlockChain() *core.BlockChain
 	TxPool() *txpool.TxPool
+	BundlePool() *bundlepool.BundlePool
+	EncryptedPool() *encryptedpool.EncryptedPool // nil if the encrypted mempool is disabled
+	EnvelopeDecrypter() core.EnvelopeDecrypter   // nil if the encrypted mempool is disabled
 }
 
 // Config is the configuration parameters of mining.
 type Config struct {
-	Etherbase                    common.Address `toml:",omitempty"` // Public address for block mining rewards
-	TestOnlyAllowDuplicateBlocks bool           // Allow mining of duplicate blocks (used in tests only)
+	Etherbase                    common.Address   `toml:",omitempty"` // Public address for block mining rewards
+	TestOnlyAllowDuplicateBlocks bool             // Allow mining of duplicate blocks (used in tests only)
+	OrderingPolicy               string           `toml:",omitempty"` // Policy ordering the transactions of a block. Defaults to [PriceOrdering].
+	PriorityAddresses            []common.Address `toml:",omitempty"` // Accounts in the priority lane of [PriorityOrdering]
+	MaxBuildTime                 time.Duration    `toml:",omitempty"` // Time after which a block is built with the transactions committed so far, or 0 for no limit
+	BuildSlice                   time.Duration    `toml:",omitempty"` // Time of each slice spent by [Miner.PrepareBlock] committing transactions to the next block
 }
 
 type Miner struct {
 	worker *worker
 }
 
-func New(eth Backend, config *Config, chainConfig *params.ChainConfig, mux *event.TypeMux, engine consensus.Engine, clock *mockable.Clock) *Miner {
-	return &Miner{
-		worker: newWorker(config, chainConfig, engine, eth, mux, clock),
+func New(eth Backend, config *Config, chainConfig *params.ChainConfig, mux *event.TypeMux, engine consensus.Engine, clock *mockable.Clock) (*Miner, error) {
+	worker, err := newWorker(config, chainConfig, engine, eth, mux, clock)
+	if err != nil {
+		return nil, err
 	}
+	return &Miner{
+		worker: worker,
+	}, nil
 }
 
 func (miner *Miner) SetEtherbase(addr common.Address) {
@@ -70,6 +85,31 @@ func (miner *Miner) GenerateBlock(predicateContext *precompileconfig.PredicateCo
 	return miner.worker.commitNewWork(predicateContext)
 }
 
+// PrepareBlock commits pending transactions to the next block for one slice of
+// [Config.BuildSlice], timestamping it no earlier than [timestamp]. The block
+// is resumed by the following calls and sealed by [Miner.GenerateBlock], as
+// long as the head of the chain does not change, so that only the transactions
+// arriving last are left to commit when the block is built.
+func (miner *Miner) PrepareBlock(timestamp time.Time) error {
+	return miner.worker.prepareWork(timestamp, miner.worker.config.BuildSlice)
+}
+
+// DecryptedEnvelopes returns the transactions decrypted from the envelopes of
+// [parent], which are committed first to the block built on top of it. Returns
+// [core.ErrEnvelopesNotDecrypted] while [parent] includes envelopes and is not
+// accepted, as no block is built on top of it until then.
+func (miner *Miner) DecryptedEnvelopes(parent *types.Header) (types.Transactions, error) {
+	return miner.worker.decryptedEnvelopes(parent)
+}
+
+// InclusionError returns the last error that prevented the pool transaction
+// with the given hash from being included in a block built by this node, or nil
+// if there was none recently.
+func (miner *Miner) InclusionError(hash common.Hash) error {
+	err, _ := miner.worker.inclusionErrors.Get(hash)
+	return err
+}
+
 // SubscribePendingLogs starts delivering logs from pending transactions
 // to the given channel.
 func (miner *Miner) SubscribePendingLogs(ch chan<- []*types.Log) event.Subscription {
@@ -34,6 +34,7 @@ import (
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
 	"github.com/holiman/uint256"
 )
 
@@ -98,27 +99,39 @@ func (s *txByPriceAndTime) Pop() interface{} {
 // transactions in a profit-maximizing sorted order, while supporting removing
 // entire batches of transactions for non-executable accounts.
 type transactionsByPriceAndNonce struct {
-	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
-	heads   txByPriceAndTime                             // Next transaction for each unique account (price heap)
-	signer  types.Signer                                 // Signer for the set of transactions
-	baseFee *uint256.Int                                 // Current base fee
+	txs      map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
+	heads    txByPriceAndTime                             // Next transaction for each unique account (price heap)
+	signer   types.Signer                                 // Signer for the set of transactions
+	baseFee  *uint256.Int                                 // Current base fee
+	baseFees map[common.Address]*uint256.Int              // Base fees of the accounts with a fee discount
 }
 
 // newTransactionsByPriceAndNonce creates a transaction set that can retrieve
 // price sorted transactions in a nonce-honouring way.
 //
+// If [feePercentage] is not nil, the effective miner tip of the transactions of each
+// account is based on the percentage of the base fee charged to the account.
+//
 // Note, the input map is reowned so the caller should not interact any more with
 // if after providing it to the constructor.
-func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *transactionsByPriceAndNonce {
+func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, feePercentage func(from common.Address) uint64) *transactionsByPriceAndNonce {
 	// Convert the basefee from header format to uint256 format
 	var baseFeeUint *uint256.Int
 	if baseFee != nil {
 		baseFeeUint = uint256.MustFromBig(baseFee)
 	}
+	baseFees := make(map[common.Address]*uint256.Int)
+	if baseFee != nil && feePercentage != nil {
+		for from := range txs {
+			if percentage := feePercentage(from); percentage < feemanager.MaxFeePercentage {
+				baseFees[from] = uint256.MustFromBig(feemanager.DiscountBaseFee(baseFee, percentage))
+			}
+		}
+	}
 	// Initialize a price and received time based heap with the head transactions
 	heads := make(txByPriceAndTime, 0, len(txs))
 	for from, accTxs := range txs {
-		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFeeUint)
+		wrapped, err := newTxWithMinerFee(accTxs[0], from, senderBaseFee(baseFeeUint, baseFees, from))
 		if err != nil {
 			delete(txs, from)
 			continue
@@ -130,11 +143,21 @@ func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address]
 
 	// Assemble and return the transaction set
 	return &transactionsByPriceAndNonce{
-		txs:     txs,
-		heads:   heads,
-		signer:  signer,
-		baseFee: baseFeeUint,
+		txs:      txs,
+		heads:    heads,
+		signer:   signer,
+		baseFee:  baseFeeUint,
+		baseFees: baseFees,
+	}
+}
+
+// senderBaseFee returns the base fee charged to [from], which is [baseFee]
+// unless [from] has a fee discount in [baseFees].
+func senderBaseFee(baseFee *uint256.Int, baseFees map[common.Address]*uint256.Int, from common.Address) *uint256.Int {
+	if discounted, ok := baseFees[from]; ok {
+		return discounted
 	}
+	return baseFee
 }
 
 // Peek returns the next transaction by price.
@@ -149,7 +172,7 @@ func (t *transactionsByPriceAndNonce) Peek() (*txpool.LazyTransaction, *uint256.
 func (t *transactionsByPriceAndNonce) Shift() {
 	acc := t.heads[0].from
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
-		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
+		if wrapped, err := newTxWithMinerFee(txs[0], acc, senderBaseFee(t.baseFee, t.baseFees, acc)); err == nil {
 			t.heads[0], t.txs[acc] = wrapped, txs[1:]
 			heap.Fix(&t.heads, 0)
 			return
@@ -0,0 +1,284 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"bytes"
+	"container/heap"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/holiman/uint256"
+
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+)
+
+// Names of the built-in ordering policies, selected with [Config.OrderingPolicy].
+const (
+	// PriceOrdering commits the transactions paying the highest effective tips first.
+	PriceOrdering = "price"
+	// ArrivalOrdering commits the transactions first seen by the transaction pool first.
+	ArrivalOrdering = "arrival"
+	// PriorityOrdering commits the transactions of [Config.PriorityAddresses] before
+	// the transactions of the other accounts, ordering both lanes by [PriceOrdering].
+	PriorityOrdering = "priority"
+	// FairShareOrdering commits one transaction of each account per round, ordering
+	// the transactions of a round by [PriceOrdering], so that an account sending many
+	// transactions cannot crowd out the other accounts.
+	FairShareOrdering = "fair-share"
+)
+
+var errUnknownOrderingPolicy = errors.New("unknown transaction ordering policy")
+
+// TransactionSet is a set of pending transactions returning the transactions to
+// commit to a block one at a time, while honouring the nonces of each account.
+type TransactionSet interface {
+	// Peek returns the next transaction and its effective miner tip, or nil if the
+	// set is empty.
+	Peek() (*txpool.LazyTransaction, *uint256.Int)
+	// Shift replaces the next transaction with the next one from the same account.
+	Shift()
+	// Pop removes the next transaction, *not* replacing it with the next one from
+	// the same account.
+	Pop()
+	// Empty returns if the set is empty.
+	Empty() bool
+	// Clear removes the entire content of the set.
+	Clear()
+}
+
+// OrderingPolicy orders the pending transactions the worker commits to a block.
+type OrderingPolicy interface {
+	// NewTransactionSet returns a set of [txs], the nonce-sorted pending transactions of
+	// each account. If [feePercentage] is not nil, the effective miner tip of the
+	// transactions of each account is based on the percentage of [baseFee] charged to
+	// the account. The set reowns [txs].
+	NewTransactionSet(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, feePercentage func(from common.Address) uint64) TransactionSet
+}
+
+// newOrderingPolicy returns the built-in ordering policy called [name].
+// [priorityAddresses] are the accounts in the priority lane of [PriorityOrdering].
+func newOrderingPolicy(name string, priorityAddresses []common.Address) (OrderingPolicy, error) {
+	switch name {
+	case "", PriceOrdering:
+		return priceOrdering{}, nil
+	case ArrivalOrdering:
+		return arrivalOrdering{}, nil
+	case PriorityOrdering:
+		priority := make(map[common.Address]struct{}, len(priorityAddresses))
+		for _, addr := range priorityAddresses {
+			priority[addr] = struct{}{}
+		}
+		return priorityOrdering{priority: priority}, nil
+	case FairShareOrdering:
+		return fairShareOrdering{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownOrderingPolicy, name)
+	}
+}
+
+type priceOrdering struct{}
+
+func (priceOrdering) NewTransactionSet(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, feePercentage func(from common.Address) uint64) TransactionSet {
+	return newTransactionsByPriceAndNonce(signer, txs, baseFee, feePercentage)
+}
+
+type arrivalOrdering struct{}
+
+func (arrivalOrdering) NewTransactionSet(_ types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, feePercentage func(from common.Address) uint64) TransactionSet {
+	return newTransactionsByOrder(txs, baseFee, feePercentage, func(a, b *txWithMinerFee, _ map[common.Address]int) bool {
+		if !a.tx.Time.Equal(b.tx.Time) {
+			return a.tx.Time.Before(b.tx.Time)
+		}
+		// Break ties deterministically, since the transactions of different
+		// accounts may be seen at the same time.
+		return bytes.Compare(a.tx.Hash[:], b.tx.Hash[:]) < 0
+	})
+}
+
+type fairShareOrdering struct{}
+
+func (fairShareOrdering) NewTransactionSet(_ types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, feePercentage func(from common.Address) uint64) TransactionSet {
+	return newTransactionsByOrder(txs, baseFee, feePercentage, func(a, b *txWithMinerFee, shifted map[common.Address]int) bool {
+		if shifted[a.from] != shifted[b.from] {
+			return shifted[a.from] < shifted[b.from]
+		}
+		return txByPriceAndTime{a, b}.Less(0, 1)
+	})
+}
+
+type priorityOrdering struct {
+	priority map[common.Address]struct{}
+}
+
+func (p priorityOrdering) NewTransactionSet(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, feePercentage func(from common.Address) uint64) TransactionSet {
+	priorityTxs := make(map[common.Address][]*txpool.LazyTransaction)
+	for from, accTxs := range txs {
+		if _, ok := p.priority[from]; ok {
+			priorityTxs[from] = accTxs
+			delete(txs, from)
+		}
+	}
+	return &transactionsInLanes{
+		lanes: []TransactionSet{
+			newTransactionsByPriceAndNonce(signer, priorityTxs, baseFee, feePercentage),
+			newTransactionsByPriceAndNonce(signer, txs, baseFee, feePercentage),
+		},
+	}
+}
+
+// transactionsInLanes returns the transactions of each lane once the transactions
+// of the previous lanes have all been returned.
+type transactionsInLanes struct {
+	lanes []TransactionSet
+}
+
+// lane returns the first lane that is not empty, or nil if all lanes are empty.
+func (t *transactionsInLanes) lane() TransactionSet {
+	for _, lane := range t.lanes {
+		if !lane.Empty() {
+			return lane
+		}
+	}
+	return nil
+}
+
+func (t *transactionsInLanes) Peek() (*txpool.LazyTransaction, *uint256.Int) {
+	if lane := t.lane(); lane != nil {
+		return lane.Peek()
+	}
+	return nil, nil
+}
+
+func (t *transactionsInLanes) Shift() {
+	if lane := t.lane(); lane != nil {
+		lane.Shift()
+	}
+}
+
+func (t *transactionsInLanes) Pop() {
+	if lane := t.lane(); lane != nil {
+		lane.Pop()
+	}
+}
+
+func (t *transactionsInLanes) Empty() bool {
+	return t.lane() == nil
+}
+
+func (t *transactionsInLanes) Clear() {
+	for _, lane := range t.lanes {
+		lane.Clear()
+	}
+}
+
+// txLess reports whether the head transaction [a] must be committed before [b].
+// [shifted] holds the number of transactions shifted out of the set for each account.
+type txLess func(a, b *txWithMinerFee, shifted map[common.Address]int) bool
+
+// txHeads is a heap of the next transaction of each account.
+type txHeads struct {
+	heads   []*txWithMinerFee
+	less    txLess
+	shifted map[common.Address]int
+}
+
+func (h *txHeads) Len() int           { return len(h.heads) }
+func (h *txHeads) Less(i, j int) bool { return h.less(h.heads[i], h.heads[j], h.shifted) }
+func (h *txHeads) Swap(i, j int)      { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+
+func (h *txHeads) Push(x interface{}) {
+	h.heads = append(h.heads, x.(*txWithMinerFee))
+}
+
+func (h *txHeads) Pop() interface{} {
+	old := h.heads
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	h.heads = old[0 : n-1]
+	return x
+}
+
+// transactionsByOrder is a [TransactionSet] returning the next transaction of each
+// account in the order defined by a [txLess].
+type transactionsByOrder struct {
+	txs      map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
+	heads    *txHeads                                     // Next transaction for each unique account
+	baseFee  *uint256.Int                                 // Current base fee
+	baseFees map[common.Address]*uint256.Int              // Base fees of the accounts with a fee discount
+}
+
+func newTransactionsByOrder(txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, feePercentage func(from common.Address) uint64, less txLess) *transactionsByOrder {
+	var baseFeeUint *uint256.Int
+	if baseFee != nil {
+		baseFeeUint = uint256.MustFromBig(baseFee)
+	}
+	baseFees := make(map[common.Address]*uint256.Int)
+	if baseFee != nil && feePercentage != nil {
+		for from := range txs {
+			if percentage := feePercentage(from); percentage < feemanager.MaxFeePercentage {
+				baseFees[from] = uint256.MustFromBig(feemanager.DiscountBaseFee(baseFee, percentage))
+			}
+		}
+	}
+	heads := &txHeads{
+		heads:   make([]*txWithMinerFee, 0, len(txs)),
+		less:    less,
+		shifted: make(map[common.Address]int),
+	}
+	for from, accTxs := range txs {
+		wrapped, err := newTxWithMinerFee(accTxs[0], from, senderBaseFee(baseFeeUint, baseFees, from))
+		if err != nil {
+			delete(txs, from)
+			continue
+		}
+		heads.heads = append(heads.heads, wrapped)
+		txs[from] = accTxs[1:]
+	}
+	heap.Init(heads)
+
+	return &transactionsByOrder{
+		txs:      txs,
+		heads:    heads,
+		baseFee:  baseFeeUint,
+		baseFees: baseFees,
+	}
+}
+
+func (t *transactionsByOrder) Peek() (*txpool.LazyTransaction, *uint256.Int) {
+	if len(t.heads.heads) == 0 {
+		return nil, nil
+	}
+	return t.heads.heads[0].tx, t.heads.heads[0].fees
+}
+
+func (t *transactionsByOrder) Shift() {
+	acc := t.heads.heads[0].from
+	t.heads.shifted[acc]++
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		if wrapped, err := newTxWithMinerFee(txs[0], acc, senderBaseFee(t.baseFee, t.baseFees, acc)); err == nil {
+			t.heads.heads[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(t.heads, 0)
+			return
+		}
+	}
+	heap.Pop(t.heads)
+}
+
+func (t *transactionsByOrder) Pop() {
+	heap.Pop(t.heads)
+}
+
+func (t *transactionsByOrder) Empty() bool {
+	return len(t.heads.heads) == 0
+}
+
+func (t *transactionsByOrder) Clear() {
+	t.heads.heads, t.txs = nil, nil
+}
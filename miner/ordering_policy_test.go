@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+	"github.com/ava-labs/subnet-evm/utils"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+func TestMain(m *testing.M) {
+	core.RegisterExtras()
+	customtypes.Register()
+	params.RegisterExtras()
+	os.Exit(m.Run())
+}
+
+// orderingTx is a pending transaction of the ordering policy tests.
+type orderingTx struct {
+	name    string
+	account int
+	nonce   uint64
+	tip     int64 // in gwei
+	seen    int64 // time first seen by the transaction pool, in seconds
+}
+
+func TestOrderingPolicies(t *testing.T) {
+	keys := []*ecdsa.PrivateKey{
+		mustKey(t, "b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291"),
+		mustKey(t, "8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a"),
+		mustKey(t, "49a7b37aa6f6645917e7b807e9d1c00d4fa71f18343b0d4122a4d2df64dd6fee"),
+	}
+	pending := []orderingTx{
+		{name: "a0", account: 0, nonce: 0, tip: 3, seen: 3},
+		{name: "a1", account: 0, nonce: 1, tip: 3, seen: 4},
+		{name: "a2", account: 0, nonce: 2, tip: 3, seen: 5},
+		{name: "b0", account: 1, nonce: 0, tip: 1, seen: 1},
+		{name: "c0", account: 2, nonce: 0, tip: 2, seen: 2},
+		{name: "c1", account: 2, nonce: 1, tip: 2, seen: 6},
+	}
+
+	tests := []struct {
+		policy            string
+		priorityAddresses []common.Address
+		want              []string
+	}{
+		{
+			policy: PriceOrdering,
+			want:   []string{"a0", "a1", "a2", "c0", "c1", "b0"},
+		},
+		{
+			policy: ArrivalOrdering,
+			want:   []string{"b0", "c0", "a0", "a1", "a2", "c1"},
+		},
+		{
+			policy:            PriorityOrdering,
+			priorityAddresses: []common.Address{crypto.PubkeyToAddress(keys[1].PublicKey)},
+			want:              []string{"b0", "a0", "a1", "a2", "c0", "c1"},
+		},
+		{
+			policy: FairShareOrdering,
+			want:   []string{"a0", "c0", "b0", "a1", "c1", "a2"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.policy, func(t *testing.T) {
+			require := require.New(t)
+
+			config := params.TestChainConfig
+			signer := types.LatestSigner(config)
+			gspec := &core.Genesis{
+				Config: config,
+				Alloc:  types.GenesisAlloc{},
+			}
+			for _, key := range keys {
+				gspec.Alloc[crypto.PubkeyToAddress(key.PublicKey)] = types.Account{Balance: big.NewInt(params.Ether)}
+			}
+
+			names := make(map[common.Hash]string)
+			groups := make(map[common.Address][]*txpool.LazyTransaction)
+			for _, p := range pending {
+				tx, err := types.SignNewTx(keys[p.account], signer, &types.DynamicFeeTx{
+					ChainID:   config.ChainID,
+					Nonce:     p.nonce,
+					To:        &common.Address{},
+					Gas:       ethparams.TxGas,
+					GasFeeCap: big.NewInt(1000 * utils.GWei),
+					GasTipCap: big.NewInt(p.tip * utils.GWei),
+				})
+				require.NoError(err)
+				tx.SetTime(time.Unix(p.seen, 0))
+				names[tx.Hash()] = p.name
+
+				from := crypto.PubkeyToAddress(keys[p.account].PublicKey)
+				groups[from] = append(groups[from], &txpool.LazyTransaction{
+					Hash:      tx.Hash(),
+					Tx:        tx,
+					Time:      tx.Time(),
+					GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+					GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+					Gas:       tx.Gas(),
+				})
+			}
+
+			policy, err := newOrderingPolicy(test.policy, test.priorityAddresses)
+			require.NoError(err)
+			txset := policy.NewTransactionSet(signer, groups, nil, nil)
+			var ordered []*types.Transaction
+			for ltx, _ := txset.Peek(); ltx != nil; ltx, _ = txset.Peek() {
+				ordered = append(ordered, ltx.Tx)
+				txset.Shift()
+			}
+			require.True(txset.Empty())
+
+			// The ordered transactions must form a valid block.
+			_, blocks, receipts, err := core.GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 1, 10, func(_ int, gen *core.BlockGen) {
+				for _, tx := range ordered {
+					gen.AddTx(tx)
+				}
+			})
+			require.NoError(err)
+			for _, receipt := range receipts[0] {
+				require.Equal(types.ReceiptStatusSuccessful, receipt.Status)
+			}
+			var got []string
+			for _, tx := range blocks[0].Transactions() {
+				got = append(got, names[tx.Hash()])
+			}
+			require.Equal(test.want, got)
+		})
+	}
+}
+
+func TestUnknownOrderingPolicy(t *testing.T) {
+	_, err := newOrderingPolicy("random", nil)
+	require.ErrorIs(t, err, errUnknownOrderingPolicy)
+}
+
+func mustKey(t *testing.T, hex string) *ecdsa.PrivateKey {
+	key, err := crypto.HexToECDSA(hex)
+	require.NoError(t, err)
+	return key
+}
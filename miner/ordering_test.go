@@ -38,6 +38,7 @@ import (
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/libevm/crypto"
 	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
 	"github.com/holiman/uint256"
 )
 
@@ -113,7 +114,7 @@ func testTransactionPriceNonceSort(t *testing.T, baseFee *big.Int) {
 		expectedCount += count
 	}
 	// Sort the transactions and cross check the nonce ordering
-	txset := newTransactionsByPriceAndNonce(signer, groups, baseFee)
+	txset := newTransactionsByPriceAndNonce(signer, groups, baseFee, nil)
 
 	txs := types.Transactions{}
 	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
@@ -179,7 +180,7 @@ func TestTransactionTimeSort(t *testing.T) {
 		})
 	}
 	// Sort the transactions and cross check the nonce ordering
-	txset := newTransactionsByPriceAndNonce(signer, groups, nil)
+	txset := newTransactionsByPriceAndNonce(signer, groups, nil, nil)
 
 	txs := types.Transactions{}
 	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
@@ -205,3 +206,71 @@ func TestTransactionTimeSort(t *testing.T) {
 		}
 	}
 }
+
+// Tests that transactions are ordered by the tips they pay on top of the base fee
+// charged to their sender, and that transactions of discounted senders whose fee cap
+// only covers the discounted base fee are not dropped.
+func TestTransactionFeeDiscountSort(t *testing.T) {
+	t.Parallel()
+
+	discountedKey, _ := crypto.GenerateKey()
+	fullKey, _ := crypto.GenerateKey()
+	discounted := crypto.PubkeyToAddress(discountedKey.PublicKey)
+	full := crypto.PubkeyToAddress(fullKey.PublicKey)
+	signer := types.LatestSignerForChainID(common.Big1)
+
+	newTx := func(key *ecdsa.PrivateKey, feeCap int64) *txpool.LazyTransaction {
+		tx, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   common.Big1,
+			To:        &common.Address{},
+			Gas:       21_000,
+			GasFeeCap: big.NewInt(feeCap),
+			GasTipCap: big.NewInt(feeCap),
+		})
+		return &txpool.LazyTransaction{
+			Hash:      tx.Hash(),
+			Tx:        tx,
+			Time:      tx.Time(),
+			GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+			GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+			Gas:       tx.Gas(),
+		}
+	}
+	// With a base fee of 100, the discounted sender is charged 50 and tips 60-50,
+	// while the other sender tips 105-100.
+	groups := map[common.Address][]*txpool.LazyTransaction{
+		discounted: {newTx(discountedKey, 60)},
+		full:       {newTx(fullKey, 105)},
+	}
+	feePercentage := func(from common.Address) uint64 {
+		if from == discounted {
+			return 50
+		}
+		return feemanager.MaxFeePercentage
+	}
+	txset := newTransactionsByPriceAndNonce(signer, groups, big.NewInt(100), feePercentage)
+
+	wantTips := []struct {
+		from common.Address
+		tip  uint64
+	}{
+		{discounted, 10},
+		{full, 5},
+	}
+	for _, want := range wantTips {
+		tx, tip := txset.Peek()
+		if tx == nil {
+			t.Fatalf("missing transaction of %x", want.from)
+		}
+		if from, _ := types.Sender(signer, tx.Tx); from != want.from {
+			t.Errorf("sender mismatch: have %x, want %x", from, want.from)
+		}
+		if tip.Uint64() != want.tip {
+			t.Errorf("tip mismatch: have %d, want %d", tip.Uint64(), want.tip)
+		}
+		txset.Shift()
+	}
+	if tx, _ := txset.Peek(); tx != nil {
+		t.Errorf("unexpected transaction %x", tx.Hash)
+	}
+}
@@ -41,16 +41,18 @@ import (
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/evm/predicate"
 	"github.com/ava-labs/libevm/common"
-	"github.com/ava-labs/libevm/consensus/misc/eip4844"
+	"github.com/ava-labs/libevm/common/lru"
 	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/libevm/core/vm"
 	"github.com/ava-labs/libevm/event"
 	"github.com/ava-labs/libevm/log"
+	"github.com/ava-labs/libevm/metrics"
 	ethparams "github.com/ava-labs/libevm/params"
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/bundlepool"
 	"github.com/ava-labs/subnet-evm/core/extstate"
 	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/params"
@@ -62,8 +64,40 @@ import (
 
 const (
 	targetTxsSize = 1800 * units.KiB
+
+	// inclusionErrorsLimit is the number of transactions the worker remembers the
+	// last inclusion error of.
+	inclusionErrorsLimit = 4096
+)
+
+var (
+	errBundleTooLarge   = errors.New("bundle exceeds target size")
+	errBundleTxReverted = errors.New("bundle transaction reverted")
+	errBlockGasExceeded = errors.New("not enough gas left in block")
+	errBlobGasExceeded  = errors.New("not enough blob gas left in block")
+	errBlockSizeTarget  = errors.New("transaction would exceed target block size")
+
+	bundleIncludedMeter = metrics.NewRegisteredMeter("miner/bundles/included", nil)
+	bundleFailedMeter   = metrics.NewRegisteredMeter("miner/bundles/failed", nil)
+	bundleEvictedMeter  = metrics.NewRegisteredMeter("miner/bundles/evicted", nil)
+
+	envelopeIncludedMeter  = metrics.NewRegisteredMeter("miner/envelopes/included", nil)
+	envelopeFailedMeter    = metrics.NewRegisteredMeter("miner/envelopes/failed", nil)
+	decryptedIncludedMeter = metrics.NewRegisteredMeter("miner/envelopes/decrypted/included", nil)
+	decryptedOmittedMeter  = metrics.NewRegisteredMeter("miner/envelopes/decrypted/omitted", nil)
+
+	buildTimer         = metrics.NewRegisteredTimer("miner/build/time", nil)
+	buildTxsHistogram  = metrics.NewRegisteredHistogram("miner/build/txs", nil, metrics.NewExpDecaySample(1028, 0.015))
+	buildGasHistogram  = metrics.NewRegisteredHistogram("miner/build/gas", nil, metrics.NewExpDecaySample(1028, 0.015))
+	buildDeadlineMeter = metrics.NewRegisteredMeter("miner/build/deadline", nil)
+	buildSlicesMeter   = metrics.NewRegisteredMeter("miner/build/slices", nil)
+	buildPreparedMeter = metrics.NewRegisteredMeter("miner/build/prepared", nil)
 )
 
+// maxPreparedWorkAge is the age after which a block prepared ahead of its build
+// is discarded rather than sealed, so that built blocks keep recent timestamps.
+const maxPreparedWorkAge = 5 * time.Second
+
 // environment is the worker's current environment and holds all of the current state information.
 type environment struct {
 	signer  types.Signer
@@ -88,6 +122,21 @@ type environment struct {
 	predicateResults predicate.BlockResults
 
 	start time.Time // Time that block building began
+
+	buildStart      time.Time // Wall clock time that block building began
+	deadline        time.Time // Time after which no more transactions are committed, zero for none
+	deadlineReached bool      // Whether transactions were left out because of the deadline
+	preparing       bool      // Whether the block is prepared ahead of its build, without a predicate context
+}
+
+// pastDeadline returns whether the time to build the block is exhausted. At
+// least one transaction is committed before, so that each build makes progress.
+func (env *environment) pastDeadline() bool {
+	if env.deadline.IsZero() || env.tcount == 0 || time.Now().Before(env.deadline) {
+		return false
+	}
+	env.deadlineReached = true
+	return true
 }
 
 // worker is the main object which takes care of submitting new work to consensus engine
@@ -98,6 +147,7 @@ type worker struct {
 	engine      consensus.Engine
 	eth         Backend
 	chain       *core.BlockChain
+	ordering    OrderingPolicy
 
 	// Feeds
 	// TODO remove since this will never be written to
@@ -109,22 +159,44 @@ type worker struct {
 	coinbase   common.Address
 	clock      *mockable.Clock // Allows us mock the clock for testing
 	beaconRoot *common.Hash    // TODO: set to empty hash, retained for upstream compatibility and future use
+
+	// inclusionErrors holds the last error that prevented each recently skipped
+	// pool transaction from being included in a block.
+	inclusionErrors *lru.Cache[common.Hash, error]
+
+	// prepared is the block prepared by [worker.prepareWork] ahead of its build,
+	// resumed by [worker.commitNewWork] if still on top of the current head.
+	preparedLock sync.Mutex
+	prepared     *environment
+
+	// decrypted holds the transactions decrypted from the envelopes of the
+	// accepted block with hash decryptedParent, the last block built on.
+	decryptedLock   sync.Mutex
+	decryptedParent common.Hash
+	decrypted       types.Transactions
 }
 
-func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus.Engine, eth Backend, mux *event.TypeMux, clock *mockable.Clock) *worker {
+func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus.Engine, eth Backend, mux *event.TypeMux, clock *mockable.Clock) (*worker, error) {
+	ordering, err := newOrderingPolicy(config.OrderingPolicy, config.PriorityAddresses)
+	if err != nil {
+		return nil, err
+	}
 	worker := &worker{
 		config:      config,
 		chainConfig: chainConfig,
 		engine:      engine,
 		eth:         eth,
 		chain:       eth.BlockChain(),
+		ordering:    ordering,
 		mux:         mux,
 		coinbase:    config.Etherbase,
 		clock:       clock,
 		beaconRoot:  &common.Hash{},
+
+		inclusionErrors: lru.NewCache[common.Hash, error](inclusionErrorsLimit),
 	}
 
-	return worker
+	return worker, nil
 }
 
 // setEtherbase sets the etherbase used to initialize the block coinbase field.
@@ -134,14 +206,114 @@ func (w *worker) setEtherbase(addr common.Address) {
 	w.coinbase = addr
 }
 
-// commitNewWork generates several new sealing tasks based on the parent block.
+// commitNewWork builds a block on top of the current head, resuming the block
+// prepared by [worker.prepareWork] if any, and seals it.
 func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateContext) (*types.Block, error) {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
+	w.preparedLock.Lock()
+	defer w.preparedLock.Unlock()
+
+	var (
+		buildStart = time.Now()
+		parent     = w.chain.CurrentBlock()
+		now        = w.clock.Time()
+		deadline   time.Time
+	)
+	if w.config.MaxBuildTime > 0 {
+		deadline = buildStart.Add(w.config.MaxBuildTime)
+	}
+	env := w.takePrepared(parent, now)
+	if env != nil {
+		// The transactions committed while preparing the block have no
+		// predicates, so the context only applies to the ones committed next.
+		env.predicateContext = predicateContext
+		env.preparing = false
+		env.deadline, env.deadlineReached = deadline, false
+		buildPreparedMeter.Mark(1)
+	} else {
+		newEnv, decrypted, err := w.newWork(predicateContext, parent, customheader.GetNextTimestamp(parent, now))
+		if err != nil {
+			return nil, err
+		}
+		env = newEnv
+		env.deadline = deadline
+		w.commitFirst(env, decrypted)
+	}
+	// Ensure we always stop prefetcher after block building is complete.
+	defer env.state.StopPrefetcher()
+
+	env.buildStart = buildStart
+	w.commitPending(env)
+
+	return w.commit(env)
+}
+
+// prepareWork commits pending transactions for at most [slice] to the block
+// built on top of the current head, ahead of its build by [commitNewWork]. The
+// block is timestamped no earlier than [timestamp]. Successive calls resume the
+// same block while the head does not change, each committing the transactions
+// that arrived in between. Transactions with predicates are left for the build,
+// as they are verified against its context.
+func (w *worker) prepareWork(timestamp time.Time, slice time.Duration) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	w.preparedLock.Lock()
+	defer w.preparedLock.Unlock()
+
+	var (
+		parent   = w.chain.CurrentBlock()
+		deadline = time.Now().Add(slice)
+		env      = w.prepared
+	)
+	if env == nil || env.parent.Hash() != parent.Hash() {
+		w.discardPrepared()
+		if now := w.clock.Time(); timestamp.Before(now) {
+			timestamp = now
+		}
+		newEnv, decrypted, err := w.newWork(nil, parent, customheader.GetNextTimestamp(parent, timestamp))
+		if err != nil {
+			return err
+		}
+		env = newEnv
+		env.preparing = true
+		env.deadline = deadline
+		w.commitFirst(env, decrypted)
+		w.prepared = env
+	}
+	env.deadline = deadline
+	w.commitPending(env)
+	buildSlicesMeter.Mark(1)
+	return nil
+}
+
+// takePrepared returns the block prepared on top of [parent], unless it is too
+// old to be sealed at [now], and clears it. Assumes preparedLock is held.
+func (w *worker) takePrepared(parent *types.Header, now time.Time) *environment {
+	env := w.prepared
+	if env == nil || env.parent.Hash() != parent.Hash() || env.start.After(now) || now.Sub(env.start) > maxPreparedWorkAge {
+		w.discardPrepared()
+		return nil
+	}
+	w.prepared = nil
+	return env
+}
+
+// discardPrepared drops the prepared block, if any. Assumes preparedLock is
+// held.
+func (w *worker) discardPrepared() {
+	if w.prepared != nil {
+		w.prepared.state.StopPrefetcher()
+		w.prepared = nil
+	}
+}
+
+// newWork starts a block on top of [parent] timestamped [tstart]. It returns the
+// transactions decrypted from the envelopes of [parent], to be committed first
+// with [worker.commitFirst].
+func (w *worker) newWork(predicateContext *precompileconfig.PredicateContext, parent *types.Header, tstart time.Time) (*environment, types.Transactions, error) {
 	var (
-		parent      = w.chain.CurrentBlock()
 		chainExtra  = params.GetExtra(w.chainConfig)
-		tstart      = customheader.GetNextTimestamp(parent, w.clock.Time())
 		timestamp   = uint64(tstart.Unix())
 		timestampMS = uint64(tstart.UnixMilli())
 	)
@@ -157,46 +329,47 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 		headerExtra.TimeMilliseconds = &timestampMS
 	}
 
+	// The block starts with the transactions decrypted from the envelopes of the
+	// parent, which can only be built once the parent is accepted.
+	decrypted, err := w.decryptedEnvelopes(parent)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// The fee manager relies on the state of the parent block to set the fee config
 	// because the fee config may be changed by the current block.
 	feeConfig, _, err := w.chain.GetFeeConfigAt(parent)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	gasLimit, err := customheader.GasLimit(chainExtra, feeConfig, parent, timestampMS)
 	if err != nil {
-		return nil, fmt.Errorf("calculating new gas limit: %w", err)
+		return nil, nil, fmt.Errorf("calculating new gas limit: %w", err)
 	}
 	header.GasLimit = gasLimit
 
 	baseFee, err := customheader.BaseFee(chainExtra, feeConfig, parent, timestampMS)
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate new base fee: %w", err)
+		return nil, nil, fmt.Errorf("failed to calculate new base fee: %w", err)
 	}
 	header.BaseFee = baseFee
 
 	// Apply EIP-4844, EIP-4788.
 	if w.chainConfig.IsCancun(header.Number, header.Time) {
-		var excessBlobGas uint64
-		if w.chainConfig.IsCancun(parent.Number, parent.Time) {
-			excessBlobGas = eip4844.CalcExcessBlobGas(*parent.ExcessBlobGas, *parent.BlobGasUsed)
-		} else {
-			// For the first post-fork block, both parent.data_gas_used and parent.excess_data_gas are evaluated as 0
-			excessBlobGas = eip4844.CalcExcessBlobGas(0, 0)
-		}
+		excessBlobGas := customheader.ExcessBlobGas(chainExtra, parent, header.Time)
 		header.BlobGasUsed = new(uint64)
 		header.ExcessBlobGas = &excessBlobGas
 		header.ParentBeaconRoot = w.beaconRoot
 	}
 
 	if w.coinbase == (common.Address{}) {
-		return nil, errors.New("cannot mine without etherbase")
+		return nil, nil, errors.New("cannot mine without etherbase")
 	}
 	header.Coinbase = w.coinbase
 
 	configuredCoinbase, isAllowFeeRecipient, err := w.chain.GetCoinbaseAt(parent)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get configured coinbase: %w", err)
+		return nil, nil, fmt.Errorf("failed to get configured coinbase: %w", err)
 	}
 
 	// if fee recipients are not allowed, then the coinbase is the configured coinbase
@@ -208,33 +381,79 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 	}
 
 	if err := w.engine.Prepare(w.chain, header); err != nil {
-		return nil, fmt.Errorf("failed to prepare header for mining: %w", err)
+		return nil, nil, fmt.Errorf("failed to prepare header for mining: %w", err)
 	}
 
 	env, err := w.createCurrentEnvironment(predicateContext, parent, header, feeConfig, tstart)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create new current environment: %w", err)
+		return nil, nil, fmt.Errorf("failed to create new current environment: %w", err)
 	}
 	if header.ParentBeaconRoot != nil {
 		context := core.NewEVMBlockContext(header, w.chain, nil)
 		vmenv := vm.NewEVM(context, vm.TxContext{}, env.state, w.chainConfig, vm.Config{})
 		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, vmenv, env.state)
 	}
-	// Ensure we always stop prefetcher after block building is complete.
-	defer func() {
-		if env.state == nil {
-			return
-		}
-		env.state.StopPrefetcher()
-	}()
 	// Configure any upgrades that should go into effect during this block.
 	blockContext := core.NewBlockContext(header.Number, header.Time)
 	err = core.ApplyUpgrades(w.chainConfig, &parent.Time, blockContext, env.state)
 	if err != nil {
 		log.Error("failed to configure precompiles mining new block", "parent", parent.Hash(), "number", header.Number, "timestamp", header.Time, "err", err)
+		env.state.StopPrefetcher()
+		return nil, nil, err
+	}
+
+	return env, decrypted, nil
+}
+
+// decryptedEnvelopes returns the transactions decrypted from the envelopes
+// committed in [parent], which the worker includes first in the block built on
+// top of it. The envelopes are only decrypted once [parent] is accepted, when
+// their order is final. The order of the decrypted transactions is a policy of
+// the block builders, which the other validators do not check.
+func (w *worker) decryptedEnvelopes(parent *types.Header) (types.Transactions, error) {
+	decrypter := w.eth.EnvelopeDecrypter()
+	if decrypter == nil {
+		return nil, nil
+	}
+	w.decryptedLock.Lock()
+	defer w.decryptedLock.Unlock()
+
+	hash := parent.Hash()
+	if hash == w.decryptedParent {
+		return w.decrypted, nil
+	}
+	number := parent.Number.Uint64()
+	block := w.chain.GetBlock(hash, number)
+	if block == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	if !core.HasEnvelopes(block) {
+		return nil, nil
+	}
+	if tip := w.chain.LastAcceptedBlock(); number > tip.NumberU64() || w.chain.GetCanonicalHash(number) != hash {
+		return nil, fmt.Errorf("%w: block %s not accepted", core.ErrEnvelopesNotDecrypted, hash)
+	}
+	txs, err := core.DecryptEnvelopes(w.chainConfig, decrypter, block)
+	if err != nil {
 		return nil, err
 	}
+	log.Debug("Decrypted envelopes", "number", number, "hash", hash, "txs", len(txs))
+	w.decryptedParent, w.decrypted = hash, txs
+	return txs, nil
+}
 
+// commitFirst commits the transactions that come first in the block: the
+// transactions [decrypted] from the envelopes of the parent, followed by the
+// envelopes of the encrypted pool and the pending bundles.
+func (w *worker) commitFirst(env *environment, decrypted types.Transactions) {
+	w.commitDecrypted(env, decrypted, env.header.Coinbase)
+	w.commitEnvelopes(env, env.header.Coinbase)
+	w.commitBundles(env, env.header.Coinbase)
+}
+
+// commitPending fills the block with the pending transactions of the pool,
+// until it is full or its deadline is reached.
+func (w *worker) commitPending(env *environment) {
 	// Retrieve the pending transactions pre-filtered by the 1559/4844 dynamic fees
 	filter := txpool.PendingFilter{
 		MinTip: uint256.MustFromBig(w.eth.TxPool().GasTip()),
@@ -243,7 +462,7 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 		filter.BaseFee = uint256.MustFromBig(env.header.BaseFee)
 	}
 	if env.header.ExcessBlobGas != nil {
-		filter.BlobFee = uint256.MustFromBig(eip4844.CalcBlobFee(*env.header.ExcessBlobGas))
+		filter.BlobFee = uint256.MustFromBig(customheader.BlobBaseFee(params.GetExtra(w.chainConfig), env.header))
 	}
 	filter.OnlyPlainTxs, filter.OnlyBlobTxs = true, false
 	pendingPlainTxs := w.eth.TxPool().Pending(filter)
@@ -264,21 +483,23 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 			localBlobTxs[account] = txs
 		}
 	}
+	// The tips of the transactions are computed on top of the base fee charged to their sender.
+	feePercentage := func(from common.Address) uint64 {
+		return core.FeePercentage(w.chainConfig, env.state, from, env.header.Time)
+	}
 	// Fill the block with all available pending transactions.
 	if len(localPlainTxs) > 0 || len(localBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, localPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, localBlobTxs, env.header.BaseFee)
+		plainTxs := w.ordering.NewTransactionSet(env.signer, localPlainTxs, env.header.BaseFee, feePercentage)
+		blobTxs := w.ordering.NewTransactionSet(env.signer, localBlobTxs, env.header.BaseFee, feePercentage)
 
 		w.commitTransactions(env, plainTxs, blobTxs, env.header.Coinbase)
 	}
 	if len(remotePlainTxs) > 0 || len(remoteBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, remotePlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, remoteBlobTxs, env.header.BaseFee)
+		plainTxs := w.ordering.NewTransactionSet(env.signer, remotePlainTxs, env.header.BaseFee, feePercentage)
+		blobTxs := w.ordering.NewTransactionSet(env.signer, remoteBlobTxs, env.header.BaseFee, feePercentage)
 
 		w.commitTransactions(env, plainTxs, blobTxs, env.header.Coinbase)
 	}
-
-	return w.commit(env)
 }
 
 func (w *worker) createCurrentEnvironment(predicateContext *precompileconfig.PredicateContext, parent *types.Header, header *types.Header, feeConfig commontype.FeeConfig, tstart time.Time) (*environment, error) {
@@ -331,7 +552,8 @@ func (w *worker) commitBlobTransaction(env *environment, tx *types.Transaction,
 	// isn't really a better place right now. The blob gas limit is checked at block validation time
 	// and not during execution. This means core.ApplyTransaction will not return an error if the
 	// tx has too many blobs. So we have to explicitly check it here.
-	if (env.blobs+len(sc.Blobs))*ethparams.BlobTxBlobGasPerBlob > ethparams.MaxBlobGasPerBlock {
+	maxBlobGas := params.GetExtra(w.chainConfig).MaxBlobGasPerBlock(env.header.Time)
+	if uint64((env.blobs+len(sc.Blobs))*ethparams.BlobTxBlobGasPerBlob) > maxBlobGas {
 		return nil, errors.New("max data blobs reached")
 	}
 	receipt, err := w.applyTransaction(env, tx, coinbase)
@@ -381,8 +603,15 @@ func (w *worker) applyTransaction(env *environment, tx *types.Transaction, coinb
 	return receipt, err
 }
 
-func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transactionsByPriceAndNonce, coinbase common.Address) {
+func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs TransactionSet, coinbase common.Address) {
+	maxBlobGas := params.GetExtra(w.chainConfig).MaxBlobGasPerBlock(env.header.Time)
 	for {
+		// If the time to build the block is exhausted, publish it with the
+		// transactions committed so far.
+		if env.pastDeadline() {
+			log.Debug("Build time exhausted, transactions left out", "number", env.header.Number, "txs", env.tcount)
+			break
+		}
 		// If we don't have enough gas for any further transactions then we're done.
 		if env.gasPool.Gas() < ethparams.TxGas {
 			log.Trace("Not enough gas for further transactions", "have", env.gasPool, "want", ethparams.TxGas)
@@ -390,14 +619,14 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		}
 		// If we don't have enough blob space for any further blob transactions,
 		// skip that list altogether
-		if !blobTxs.Empty() && env.blobs*ethparams.BlobTxBlobGasPerBlob >= ethparams.MaxBlobGasPerBlock {
+		if !blobTxs.Empty() && uint64(env.blobs*ethparams.BlobTxBlobGasPerBlob) >= maxBlobGas {
 			log.Trace("Not enough blob space for further blob transactions")
 			blobTxs.Clear()
 			// Fall though to pick up any plain txs
 		}
 		// If we don't have enough blob space for any further blob transactions,
 		// skip that list altogether
-		if !blobTxs.Empty() && env.blobs*ethparams.BlobTxBlobGasPerBlob >= ethparams.MaxBlobGasPerBlock {
+		if !blobTxs.Empty() && uint64(env.blobs*ethparams.BlobTxBlobGasPerBlob) >= maxBlobGas {
 			log.Trace("Not enough blob space for further blob transactions")
 			blobTxs.Clear()
 			// Fall though to pick up any plain txs
@@ -405,7 +634,7 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		// Retrieve the next transaction and abort if all done.
 		var (
 			ltx *txpool.LazyTransaction
-			txs *transactionsByPriceAndNonce
+			txs TransactionSet
 		)
 		pltx, ptip := plainTxs.Peek()
 		bltx, btip := blobTxs.Peek()
@@ -428,11 +657,13 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		// If we don't have enough space for the next transaction, skip the account.
 		if env.gasPool.Gas() < ltx.Gas {
 			log.Trace("Not enough gas left for transaction", "hash", ltx.Hash, "left", env.gasPool.Gas(), "needed", ltx.Gas)
+			w.inclusionErrors.Add(ltx.Hash, fmt.Errorf("%w: have %d, want %d", errBlockGasExceeded, env.gasPool.Gas(), ltx.Gas))
 			txs.Pop()
 			continue
 		}
-		if left := uint64(ethparams.MaxBlobGasPerBlock - env.blobs*ethparams.BlobTxBlobGasPerBlob); left < ltx.BlobGas {
+		if left := maxBlobGas - uint64(env.blobs*ethparams.BlobTxBlobGasPerBlob); left < ltx.BlobGas {
 			log.Trace("Not enough blob gas left for transaction", "hash", ltx.Hash, "left", left, "needed", ltx.BlobGas)
+			w.inclusionErrors.Add(ltx.Hash, fmt.Errorf("%w: have %d, want %d", errBlobGasExceeded, left, ltx.BlobGas))
 			txs.Pop()
 			continue
 		}
@@ -447,6 +678,7 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		// transaction that will fit.
 		if totalTxsSize := env.size + tx.Size(); totalTxsSize > targetTxsSize {
 			log.Trace("Skipping transaction that would exceed target size", "hash", tx.Hash(), "totalTxsSize", totalTxsSize, "txSize", tx.Size())
+			w.inclusionErrors.Add(ltx.Hash, fmt.Errorf("%w: %d > %d", errBlockSizeTarget, totalTxsSize, targetTxsSize))
 			txs.Pop()
 			continue
 		}
@@ -455,6 +687,17 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		// during transaction acceptance is the transaction pool.
 		from, _ := types.Sender(env.signer, tx)
 
+		// Skip the account if the conditions of the transaction do not hold on
+		// top of the transactions already in the block.
+		if ltx.Conditional != nil {
+			if err := w.checkConditional(env, ltx.Conditional); err != nil {
+				log.Trace("Skipping transaction with failed conditional", "hash", ltx.Hash, "sender", from, "err", err)
+				w.inclusionErrors.Add(ltx.Hash, err)
+				txs.Pop()
+				continue
+			}
+		}
+
 		// Check whether the tx is replay protected. If we're not in the EIP155 hf
 		// phase, start ignoring the sender until we do.
 		if tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
@@ -477,15 +720,180 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 			env.tcount++
 			txs.Shift()
 
+		case env.preparing && errors.Is(err, core.ErrMissingPredicateContext):
+			// Transactions with predicates are left for the build of the block,
+			// which provides the context verifying them.
+			log.Trace("Skipping transaction with predicates while preparing", "hash", ltx.Hash, "sender", from)
+			txs.Pop()
+
 		default:
 			// Transaction is regarded as invalid, drop all consecutive transactions from
 			// the same sender because of `nonce-too-high` clause.
 			log.Debug("Transaction failed, account skipped", "hash", ltx.Hash, "err", err)
+			w.inclusionErrors.Add(ltx.Hash, err)
 			txs.Pop()
 		}
 	}
 }
 
+// commitDecrypted includes the transactions decrypted from the envelopes of the
+// parent block first. They are committed in the order of their envelopes, and
+// only those that cannot be applied are left out, regardless of the build
+// deadline and of the size target of the block.
+func (w *worker) commitDecrypted(env *environment, decrypted types.Transactions, coinbase common.Address) {
+	for _, tx := range decrypted {
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		if _, err := w.commitTransaction(env, tx, coinbase); err != nil {
+			log.Debug("Decrypted transaction failed, omitted", "hash", tx.Hash(), "err", err)
+			decryptedOmittedMeter.Mark(1)
+			continue
+		}
+		env.tcount++
+		decryptedIncludedMeter.Mark(1)
+	}
+}
+
+// commitEnvelopes includes the envelope transactions of the encrypted pool in
+// arrival order. Their ciphertexts are only decrypted once the block is
+// accepted, so the order of the envelopes is fixed without knowing their
+// contents. Envelopes stay in the pool until their block is accepted, so that
+// they are included again if it is rejected.
+func (w *worker) commitEnvelopes(env *environment, coinbase common.Address) {
+	pool := w.eth.EncryptedPool()
+	if pool == nil || !params.GetExtra(w.chainConfig).IsEnvelopeEnabled(env.header.Time) {
+		return
+	}
+	for _, tx := range pool.Pending(w.clock.Time()) {
+		if err := w.commitEnvelopeTransaction(env, tx, coinbase); err != nil {
+			log.Debug("Envelope transaction failed", "hash", tx.Hash(), "err", err)
+			envelopeFailedMeter.Mark(1)
+			continue
+		}
+		envelopeIncludedMeter.Mark(1)
+	}
+}
+
+// commitEnvelopeTransaction commits the envelope transaction [tx] if it fits in
+// the block.
+func (w *worker) commitEnvelopeTransaction(env *environment, tx *types.Transaction, coinbase common.Address) error {
+	if env.gasPool.Gas() < tx.Gas() {
+		return errBlockGasExceeded
+	}
+	if env.size+tx.Size() > targetTxsSize {
+		return errBlockSizeTarget
+	}
+	env.state.SetTxContext(tx.Hash(), env.tcount)
+	if _, err := w.commitTransaction(env, tx, coinbase); err != nil {
+		return err
+	}
+	env.tcount++
+	return nil
+}
+
+// commitBundles includes the bundles of the bundle pool that can be included in
+// the block, each bundle being included only if all of its transactions succeed.
+// Included bundles are removed from the pool, as well as the bundles with a
+// transaction whose nonce or sender funds are invalid.
+func (w *worker) commitBundles(env *environment, coinbase common.Address) {
+	pool := w.eth.BundlePool()
+	if pool == nil {
+		return
+	}
+	for _, bundle := range pool.Pending(env.header.Number.Uint64(), env.header.Time) {
+		// The remaining bundles are retried in the next blocks.
+		if env.pastDeadline() {
+			log.Debug("Build time exhausted, bundles left out", "number", env.header.Number)
+			return
+		}
+		if err := w.commitBundle(env, bundle, coinbase); err != nil {
+			bundleFailedMeter.Mark(1)
+			if invalidBundleTx(err) {
+				log.Debug("Bundle invalid, evicted", "hash", bundle.Hash(), "err", err)
+				bundleEvictedMeter.Mark(1)
+				pool.Remove(bundle.Hash())
+				continue
+			}
+			log.Debug("Bundle failed, skipped", "hash", bundle.Hash(), "err", err)
+			continue
+		}
+		bundleIncludedMeter.Mark(1)
+		pool.Remove(bundle.Hash())
+	}
+}
+
+// invalidBundleTx returns whether [err], returned by [worker.commitBundle], is
+// caused by a transaction with an invalid nonce or whose sender lacks the funds
+// to pay for it. Such bundles are unlikely to become valid before they expire.
+func invalidBundleTx(err error) bool {
+	return errors.Is(err, core.ErrNonceTooLow) ||
+		errors.Is(err, core.ErrNonceTooHigh) ||
+		errors.Is(err, core.ErrInsufficientFunds) ||
+		errors.Is(err, core.ErrInsufficientFundsForTransfer)
+}
+
+// commitBundle runs the transactions of [bundle] back-to-back. If any of them
+// fails or reverts, the environment is restored as it was before the bundle.
+func (w *worker) commitBundle(env *environment, bundle *bundlepool.Bundle, coinbase common.Address) error {
+	// The state is finalised after each transaction, which invalidates the
+	// snapshots taken before, so the state is copied instead.
+	var (
+		state    = env.state.Copy()
+		gp       = env.gasPool.Gas()
+		gasUsed  = env.header.GasUsed
+		tcount   = env.tcount
+		txs      = len(env.txs)
+		receipts = len(env.receipts)
+		size     = env.size
+	)
+	revert := func() {
+		env.state.StopPrefetcher()
+		env.state = state
+		env.gasPool.SetGas(gp)
+		env.header.GasUsed = gasUsed
+		env.tcount = tcount
+		env.txs = env.txs[:txs]
+		env.receipts = env.receipts[:receipts]
+		env.size = size
+		for _, tx := range bundle.Txs {
+			env.predicateResults.Set(tx.Hash(), nil) // Delete results by setting to nil
+		}
+	}
+	for _, tx := range bundle.Txs {
+		if env.size+tx.Size() > targetTxsSize {
+			revert()
+			return errBundleTooLarge
+		}
+		if tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
+			revert()
+			return types.ErrInvalidChainId
+		}
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		if _, err := w.commitTransaction(env, tx, coinbase); err != nil {
+			revert()
+			return fmt.Errorf("transaction %s: %w", tx.Hash(), err)
+		}
+		env.tcount++
+		if env.receipts[len(env.receipts)-1].Status != types.ReceiptStatusSuccessful {
+			revert()
+			return fmt.Errorf("%w: %s", errBundleTxReverted, tx.Hash())
+		}
+	}
+	return nil
+}
+
+// checkConditional returns an error if [conditional] does not hold for the block
+// being built, on top of the transactions already committed to it.
+func (w *worker) checkConditional(env *environment, conditional *txpool.TransactionConditional) error {
+	if err := conditional.CheckBlock(env.header.Number.Uint64(), env.header.Time); err != nil {
+		return err
+	}
+	// The storage roots are only updated when the intermediate root is computed.
+	if conditional.ChecksStorageRoots() {
+		env.state.IntermediateRoot(w.chainConfig.IsEIP158(env.header.Number))
+	}
+	return conditional.CheckState(env.state)
+}
+
 // commit runs any post-transaction state modifications, assembles the final block
 // and commits new work if consensus engine is running.
 func (w *worker) commit(env *environment) (*types.Block, error) {
@@ -544,7 +952,15 @@ func (w *worker) handleResult(env *environment, block *types.Block, createdAt ti
 	log.Info("Commit new mining work", "number", block.Number(), "hash", hash,
 		"uncles", 0, "txs", env.tcount,
 		"gas", block.GasUsed(), "fees", feesInEther,
-		"elapsed", common.PrettyDuration(time.Since(env.start)))
+		"elapsed", common.PrettyDuration(time.Since(env.start)),
+		"deadlineReached", env.deadlineReached)
+
+	buildTimer.UpdateSince(env.buildStart)
+	buildTxsHistogram.Update(int64(len(block.Transactions())))
+	buildGasHistogram.Update(int64(block.GasUsed()))
+	if env.deadlineReached {
+		buildDeadlineMeter.Mark(1)
+	}
 
 	// Note: the miner no longer emits a NewMinedBlock event. Instead the caller
 	// is responsible for running any additional verification and then inserting
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package extras
+
+import (
+	"errors"
+	"fmt"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+var (
+	errMaxBlobGasPerBlockTooLow         = errors.New("maxBlobGasPerBlock must be greater than 0")
+	errMaxBlobGasPerBlockTooHigh        = errors.New("maxBlobGasPerBlock exceeds the maximum supported blob gas")
+	errMaxBlobGasPerBlockNotBlobs       = errors.New("maxBlobGasPerBlock must be a multiple of the blob gas per blob")
+	errTargetBlobGasPerBlockInvalid     = errors.New("targetBlobGasPerBlock must be greater than 0 and at most maxBlobGasPerBlock")
+	errMinBlobGasPriceTooLow            = errors.New("minBlobGasPrice must be greater than 0")
+	errBlobGasPriceUpdateFractionTooLow = errors.New("blobGasPriceUpdateFraction must be greater than 0")
+)
+
+// DefaultBlobConfig prices and limits blob gas as Ethereum does since Cancun.
+var DefaultBlobConfig = BlobConfig{
+	TargetBlobGasPerBlock:      ethparams.BlobTxTargetBlobGasPerBlock,
+	MaxBlobGasPerBlock:         ethparams.MaxBlobGasPerBlock,
+	MinBlobGasPrice:            ethparams.BlobTxMinBlobGasprice,
+	BlobGasPriceUpdateFraction: ethparams.BlobTxBlobGaspriceUpdateFraction,
+}
+
+// BlobConfig enables EIP-4844 blob transactions from the Helicon upgrade, with
+// the blob gas limits and pricing of the chain. The blob gas price follows the
+// excess blob gas as in EIP-4844, starting from MinBlobGasPrice.
+type BlobConfig struct {
+	TargetBlobGasPerBlock      uint64 `json:"targetBlobGasPerBlock"`
+	MaxBlobGasPerBlock         uint64 `json:"maxBlobGasPerBlock"`
+	MinBlobGasPrice            uint64 `json:"minBlobGasPrice"`
+	BlobGasPriceUpdateFraction uint64 `json:"blobGasPriceUpdateFraction"`
+}
+
+// Verify checks that the blob config is valid. The blob gas of a block is capped
+// at the blob gas supported by the blob pool, which is Ethereum's.
+func (c *BlobConfig) Verify() error {
+	switch {
+	case c.MaxBlobGasPerBlock == 0:
+		return errMaxBlobGasPerBlockTooLow
+	case c.MaxBlobGasPerBlock > ethparams.MaxBlobGasPerBlock:
+		return fmt.Errorf("%w: %d > %d", errMaxBlobGasPerBlockTooHigh, c.MaxBlobGasPerBlock, ethparams.MaxBlobGasPerBlock)
+	case c.MaxBlobGasPerBlock%ethparams.BlobTxBlobGasPerBlob != 0:
+		return fmt.Errorf("%w: %d", errMaxBlobGasPerBlockNotBlobs, c.MaxBlobGasPerBlock)
+	case c.TargetBlobGasPerBlock == 0 || c.TargetBlobGasPerBlock > c.MaxBlobGasPerBlock:
+		return fmt.Errorf("%w: targetBlobGasPerBlock = %d", errTargetBlobGasPerBlockInvalid, c.TargetBlobGasPerBlock)
+	case c.MinBlobGasPrice == 0:
+		return errMinBlobGasPriceTooLow
+	case c.BlobGasPriceUpdateFraction == 0:
+		return errBlobGasPriceUpdateFractionTooLow
+	}
+	return nil
+}
+
+// IsBlobEnabled returns whether blob transactions can be included in the block
+// with the given timestamp.
+func (c *ChainConfig) IsBlobEnabled(time uint64) bool {
+	return c.BlobConfig != nil && c.IsHelicon(time)
+}
+
+// MaxBlobGasPerBlock returns the maximum blob gas of the block with the given
+// timestamp, which is 0 if blob transactions are not enabled.
+func (c *ChainConfig) MaxBlobGasPerBlock(time uint64) uint64 {
+	if !c.IsBlobEnabled(time) {
+		return 0
+	}
+	return c.BlobConfig.MaxBlobGasPerBlock
+}
+
+// checkBlobConfigCompatible returns an error if the blob config of [newcfg]
+// differs from the active blob config of [c] at [headTimestamp].
+func (c *ChainConfig) checkBlobConfigCompatible(newcfg *ChainConfig, headTimestamp uint64) *ethparams.ConfigCompatError {
+	if !c.IsHelicon(headTimestamp) && !newcfg.IsHelicon(headTimestamp) {
+		return nil
+	}
+	if blobConfigEqual(c.BlobConfig, newcfg.BlobConfig) {
+		return nil
+	}
+	return ethparams.NewTimestampCompatError("Blob config", c.HeliconTimestamp, newcfg.HeliconTimestamp)
+}
+
+func blobConfigEqual(x, y *BlobConfig) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return *x == *y
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package extras
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/utils"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+func TestBlobConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		set         func(*BlobConfig)
+		expectedErr error
+	}{
+		{
+			name: "default",
+			set:  func(*BlobConfig) {},
+		},
+		{
+			name: "single_blob",
+			set: func(c *BlobConfig) {
+				c.MaxBlobGasPerBlock = ethparams.BlobTxBlobGasPerBlob
+				c.TargetBlobGasPerBlock = ethparams.BlobTxBlobGasPerBlob
+			},
+		},
+		{
+			name:        "zero_max",
+			set:         func(c *BlobConfig) { c.MaxBlobGasPerBlock = 0 },
+			expectedErr: errMaxBlobGasPerBlockTooLow,
+		},
+		{
+			name: "max_too_high",
+			set: func(c *BlobConfig) {
+				c.MaxBlobGasPerBlock = ethparams.MaxBlobGasPerBlock + ethparams.BlobTxBlobGasPerBlob
+			},
+			expectedErr: errMaxBlobGasPerBlockTooHigh,
+		},
+		{
+			name:        "max_not_blobs",
+			set:         func(c *BlobConfig) { c.MaxBlobGasPerBlock = ethparams.BlobTxBlobGasPerBlob + 1 },
+			expectedErr: errMaxBlobGasPerBlockNotBlobs,
+		},
+		{
+			name:        "zero_target",
+			set:         func(c *BlobConfig) { c.TargetBlobGasPerBlock = 0 },
+			expectedErr: errTargetBlobGasPerBlockInvalid,
+		},
+		{
+			name:        "target_above_max",
+			set:         func(c *BlobConfig) { c.TargetBlobGasPerBlock = c.MaxBlobGasPerBlock + 1 },
+			expectedErr: errTargetBlobGasPerBlockInvalid,
+		},
+		{
+			name:        "zero_min_price",
+			set:         func(c *BlobConfig) { c.MinBlobGasPrice = 0 },
+			expectedErr: errMinBlobGasPriceTooLow,
+		},
+		{
+			name:        "zero_update_fraction",
+			set:         func(c *BlobConfig) { c.BlobGasPriceUpdateFraction = 0 },
+			expectedErr: errBlobGasPriceUpdateFractionTooLow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultBlobConfig
+			test.set(&config)
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestIsBlobEnabled(t *testing.T) {
+	require := require.New(t)
+
+	blobConfig := DefaultBlobConfig
+	config := copyAndSet(TestGraniteChainConfig, func(c *ChainConfig) {
+		c.HeliconTimestamp = utils.NewUint64(10)
+		c.BlobConfig = &blobConfig
+	})
+	require.False(config.IsBlobEnabled(9))
+	require.Zero(config.MaxBlobGasPerBlock(9))
+	require.True(config.IsBlobEnabled(10))
+	require.Equal(blobConfig.MaxBlobGasPerBlock, config.MaxBlobGasPerBlock(10))
+
+	require.False(TestHeliconChainConfig.IsBlobEnabled(10))
+	require.Zero(TestHeliconChainConfig.MaxBlobGasPerBlock(10))
+}
+
+func TestCheckBlobConfigCompatible(t *testing.T) {
+	var (
+		blobConfig    = DefaultBlobConfig
+		changedConfig = DefaultBlobConfig
+	)
+	changedConfig.MinBlobGasPrice++
+
+	withBlobs := func(blobConfig *BlobConfig) *ChainConfig {
+		return copyAndSet(TestGraniteChainConfig, func(c *ChainConfig) {
+			c.HeliconTimestamp = utils.NewUint64(10)
+			c.BlobConfig = blobConfig
+		})
+	}
+	tests := []struct {
+		name          string
+		stored        *ChainConfig
+		new           *ChainConfig
+		headTimestamp uint64
+		expectedErr   bool
+	}{
+		{
+			name:          "unchanged",
+			stored:        withBlobs(&blobConfig),
+			new:           withBlobs(&blobConfig),
+			headTimestamp: 20,
+		},
+		{
+			name:          "changed_before_helicon",
+			stored:        withBlobs(&blobConfig),
+			new:           withBlobs(&changedConfig),
+			headTimestamp: 9,
+		},
+		{
+			name:          "enabled_before_helicon",
+			stored:        withBlobs(nil),
+			new:           withBlobs(&blobConfig),
+			headTimestamp: 9,
+		},
+		{
+			name:          "changed_after_helicon",
+			stored:        withBlobs(&blobConfig),
+			new:           withBlobs(&changedConfig),
+			headTimestamp: 10,
+			expectedErr:   true,
+		},
+		{
+			name:          "enabled_after_helicon",
+			stored:        withBlobs(nil),
+			new:           withBlobs(&blobConfig),
+			headTimestamp: 10,
+			expectedErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.stored.checkBlobConfigCompatible(test.new, test.headTimestamp)
+			if test.expectedErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -111,6 +111,9 @@ type ChainConfig struct {
 	AllowFeeRecipients bool                 `json:"allowFeeRecipients,omitempty"` // Allows fees to be collected by block builders.
 	GenesisPrecompiles Precompiles          `json:"-"`                            // Config for enabling precompiles from genesis. JSON encode/decode will be handled by the custom marshaler/unmarshaler.
 	UpgradeConfig      `json:"-"`           // Config specified in upgradeBytes (avalanche network upgrades or enable/disabling precompiles). Not serialized.
+
+	BlobConfig     *BlobConfig     `json:"blobConfig,omitempty"`     // Enables blob transactions from Helicon with the given limits and pricing.
+	EnvelopeConfig *EnvelopeConfig `json:"envelopeConfig,omitempty"` // Enables envelope transactions from Helicon with the given limits.
 }
 
 func (c *ChainConfig) CheckConfigCompatible(newConfig *ethparams.ChainConfig, headNumber *big.Int, headTimestamp uint64) *ethparams.ConfigCompatError {
@@ -144,6 +147,16 @@ func (c *ChainConfig) checkConfigCompatible(newcfg *ChainConfig, _ *big.Int, hea
 		return err
 	}
 
+	// Check that the blob config is not changed once blobs may have been included.
+	if err := c.checkBlobConfigCompatible(newcfg, headTimestamp); err != nil {
+		return err
+	}
+
+	// Check that the envelope config is not changed once envelopes may have been included.
+	if err := c.checkEnvelopeConfigCompatible(newcfg, headTimestamp); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -172,6 +185,22 @@ func (c *ChainConfig) Description() string {
 
 	banner += fmt.Sprintf("Allow Fee Recipients: %v\n", c.AllowFeeRecipients)
 
+	if c.BlobConfig != nil {
+		blobBytes, err := json.Marshal(c.BlobConfig)
+		if err != nil {
+			blobBytes = []byte("cannot marshal BlobConfig")
+		}
+		banner += fmt.Sprintf("Blob Config: %s\n", string(blobBytes))
+	}
+
+	if c.EnvelopeConfig != nil {
+		envelopeBytes, err := json.Marshal(c.EnvelopeConfig)
+		if err != nil {
+			envelopeBytes = []byte("cannot marshal EnvelopeConfig")
+		}
+		banner += fmt.Sprintf("Envelope Config: %s\n", string(envelopeBytes))
+	}
+
 	return banner
 }
 
@@ -317,6 +346,16 @@ func (c *ChainConfig) Verify() error {
 	if err := c.FeeConfig.Verify(); err != nil {
 		return fmt.Errorf("invalid fee config: %w", err)
 	}
+	if c.BlobConfig != nil {
+		if err := c.BlobConfig.Verify(); err != nil {
+			return fmt.Errorf("invalid blob config: %w", err)
+		}
+	}
+	if c.EnvelopeConfig != nil {
+		if err := c.EnvelopeConfig.Verify(); err != nil {
+			return fmt.Errorf("invalid envelope config: %w", err)
+		}
+	}
 
 	// Verify the precompile upgrades are internally consistent given the existing chainConfig.
 	if err := c.verifyPrecompileUpgrades(); err != nil {
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package extras
+
+import (
+	"errors"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+var (
+	errMaxEnvelopesPerBlockTooLow = errors.New("maxEnvelopesPerBlock must be greater than 0")
+	errMaxCiphertextSizeTooLow    = errors.New("maxCiphertextSize must be greater than 0")
+)
+
+// DefaultEnvelopeConfig limits the envelopes of a block to 256 ciphertexts of
+// at most 128 KiB.
+var DefaultEnvelopeConfig = EnvelopeConfig{
+	MaxEnvelopesPerBlock: 256,
+	MaxCiphertextSize:    128 * 1024,
+}
+
+// EnvelopeConfig enables envelope transactions from the Helicon upgrade. An
+// envelope is a transaction sent to the envelope address, carrying the
+// ciphertext of a transaction encrypted to the key held by the validator set.
+// Once enabled, every block is checked to include at most MaxEnvelopesPerBlock
+// envelopes, each transferring no value and carrying a non-empty ciphertext of
+// at most MaxCiphertextSize bytes. The transactions sealed in the envelopes are
+// decrypted and included by the block builders, and are not checked against
+// the envelopes by the other validators.
+type EnvelopeConfig struct {
+	MaxEnvelopesPerBlock uint64 `json:"maxEnvelopesPerBlock"`
+	MaxCiphertextSize    uint64 `json:"maxCiphertextSize"`
+}
+
+// Verify checks that the envelope config is valid.
+func (c *EnvelopeConfig) Verify() error {
+	switch {
+	case c.MaxEnvelopesPerBlock == 0:
+		return errMaxEnvelopesPerBlockTooLow
+	case c.MaxCiphertextSize == 0:
+		return errMaxCiphertextSizeTooLow
+	}
+	return nil
+}
+
+// IsEnvelopeEnabled returns whether the envelope rules apply to the block with
+// the given timestamp.
+func (c *ChainConfig) IsEnvelopeEnabled(time uint64) bool {
+	return c.EnvelopeConfig != nil && c.IsHelicon(time)
+}
+
+// checkEnvelopeConfigCompatible returns an error if the envelope config of
+// [newcfg] differs from the active envelope config of [c] at [headTimestamp].
+func (c *ChainConfig) checkEnvelopeConfigCompatible(newcfg *ChainConfig, headTimestamp uint64) *ethparams.ConfigCompatError {
+	if !c.IsHelicon(headTimestamp) && !newcfg.IsHelicon(headTimestamp) {
+		return nil
+	}
+	if envelopeConfigEqual(c.EnvelopeConfig, newcfg.EnvelopeConfig) {
+		return nil
+	}
+	return ethparams.NewTimestampCompatError("Envelope config", c.HeliconTimestamp, newcfg.HeliconTimestamp)
+}
+
+func envelopeConfigEqual(x, y *EnvelopeConfig) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return *x == *y
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package extras
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+func TestEnvelopeConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		set         func(*EnvelopeConfig)
+		expectedErr error
+	}{
+		{
+			name: "default",
+			set:  func(*EnvelopeConfig) {},
+		},
+		{
+			name:        "zero_max_envelopes",
+			set:         func(c *EnvelopeConfig) { c.MaxEnvelopesPerBlock = 0 },
+			expectedErr: errMaxEnvelopesPerBlockTooLow,
+		},
+		{
+			name:        "zero_max_ciphertext_size",
+			set:         func(c *EnvelopeConfig) { c.MaxCiphertextSize = 0 },
+			expectedErr: errMaxCiphertextSizeTooLow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultEnvelopeConfig
+			test.set(&config)
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestIsEnvelopeEnabled(t *testing.T) {
+	require := require.New(t)
+
+	envelopeConfig := DefaultEnvelopeConfig
+	config := copyAndSet(TestGraniteChainConfig, func(c *ChainConfig) {
+		c.HeliconTimestamp = utils.NewUint64(10)
+		c.EnvelopeConfig = &envelopeConfig
+	})
+	require.False(config.IsEnvelopeEnabled(9))
+	require.True(config.IsEnvelopeEnabled(10))
+
+	require.False(TestHeliconChainConfig.IsEnvelopeEnabled(10))
+}
+
+func TestCheckEnvelopeConfigCompatible(t *testing.T) {
+	var (
+		envelopeConfig = DefaultEnvelopeConfig
+		changedConfig  = DefaultEnvelopeConfig
+	)
+	changedConfig.MaxEnvelopesPerBlock++
+
+	withEnvelopes := func(envelopeConfig *EnvelopeConfig) *ChainConfig {
+		return copyAndSet(TestGraniteChainConfig, func(c *ChainConfig) {
+			c.HeliconTimestamp = utils.NewUint64(10)
+			c.EnvelopeConfig = envelopeConfig
+		})
+	}
+	tests := []struct {
+		name          string
+		stored        *ChainConfig
+		new           *ChainConfig
+		headTimestamp uint64
+		expectedErr   bool
+	}{
+		{
+			name:          "unchanged",
+			stored:        withEnvelopes(&envelopeConfig),
+			new:           withEnvelopes(&envelopeConfig),
+			headTimestamp: 20,
+		},
+		{
+			name:          "changed_before_helicon",
+			stored:        withEnvelopes(&envelopeConfig),
+			new:           withEnvelopes(&changedConfig),
+			headTimestamp: 9,
+		},
+		{
+			name:          "changed_after_helicon",
+			stored:        withEnvelopes(&envelopeConfig),
+			new:           withEnvelopes(&changedConfig),
+			headTimestamp: 10,
+			expectedErr:   true,
+		},
+		{
+			name:          "enabled_after_helicon",
+			stored:        withEnvelopes(nil),
+			new:           withEnvelopes(&envelopeConfig),
+			headTimestamp: 10,
+			expectedErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.stored.checkEnvelopeConfigCompatible(test.new, test.headTimestamp)
+			if test.expectedErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -213,6 +213,11 @@ type AvalancheRules struct {
 	IsHelicon   bool
 }
 
+// IsHeliconActivated is used by the fee manager precompile to determine whether fee discounts are available.
+func (a AvalancheRules) IsHeliconActivated() bool {
+	return a.IsHelicon
+}
+
 // IsGraniteActivated is used by the warp precompile to determine which gas costs to use.
 func (a AvalancheRules) IsGraniteActivated() bool {
 	return a.IsGranite
@@ -172,10 +172,31 @@ func (a accessibleState) GetRules() precompileconfig.Rules {
 	return chainConfigExtra.GetAvalancheRules(a.GetBlockContext().Timestamp())
 }
 
+func (a accessibleState) GetChainConfig() precompileconfig.ChainConfig {
+	return GetExtra(a.env.ChainConfig())
+}
+
 func (a accessibleState) GetSnowContext() *snow.Context {
 	return GetExtra(a.env.ChainConfig()).SnowCtx
 }
 
+// QueryPrecompile runs the query [name] of the precompile at [addr] against the read-only state.
+// The precompile must be active at the current block.
+func (a accessibleState) QueryPrecompile(addr common.Address, name string, input any, suppliedGas uint64) (any, uint64, error) {
+	if _, ok := GetRulesExtra(a.env.Rules()).Precompiles[addr]; !ok {
+		return nil, suppliedGas, fmt.Errorf("%w: %s", contract.ErrPrecompileNotActive, addr)
+	}
+	module, ok := modules.GetPrecompileModuleByAddress(addr)
+	if !ok {
+		return nil, suppliedGas, fmt.Errorf("%w: %s", contract.ErrPrecompileNotActive, addr)
+	}
+	query, ok := module.GetQuery(name)
+	if !ok {
+		return nil, suppliedGas, fmt.Errorf("%w: %s at %s", contract.ErrQueryNotFound, name, addr)
+	}
+	return query.Execute(a.env.ReadOnlyState(), input, suppliedGas)
+}
+
 func (a accessibleState) GetPrecompileEnv() vm.PrecompileEnvironment {
 	return a.env
 }
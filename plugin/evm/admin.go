@@ -4,16 +4,23 @@
 package evm
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/libevm/log"
 
 	"github.com/ava-labs/subnet-evm/plugin/evm/client"
+
+	vmsync "github.com/ava-labs/subnet-evm/plugin/evm/sync"
+	statesyncclient "github.com/ava-labs/subnet-evm/sync/client"
 )
 
+var errMissingArchivePath = errors.New("missing state sync archive path")
+
 // Admin is the API service for admin API calls
 type Admin struct {
 	vm       *VM
@@ -83,3 +90,62 @@ func (p *Admin) GetVMConfig(_ *http.Request, _ *struct{}, reply *client.ConfigRe
 	reply.Config = &p.vm.config
 	return nil
 }
+
+// ExportStateSyncArchive exports the state of the last state summary of the
+// chain to a state sync archive at the specified path, which nodes can state
+// sync from with the state-sync-archive option.
+// The export does not hold the VM lock, as the state is read the same way it
+// is served to syncing peers.
+func (p *Admin) ExportStateSyncArchive(r *http.Request, args *client.ExportStateSyncArchiveArgs, reply *client.ExportStateSyncArchiveReply) error {
+	log.Info("Admin: ExportStateSyncArchive called", "path", args.Path)
+
+	if args.Path == "" {
+		return errMissingArchivePath
+	}
+	ctx := r.Context()
+	stateSummary, err := p.vm.Server.GetLastStateSummary(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get last state summary: %w", err)
+	}
+	summary, err := p.vm.extensionConfig.SyncableParser.Parse(stateSummary.Bytes(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to parse last state summary: %w", err)
+	}
+
+	// Write to a temporary file first, so that an interrupted export does not
+	// leave a partial archive at [args.Path].
+	tmpPath := args.Path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create state sync archive: %w", err)
+	}
+	err = statesyncclient.ExportArchive(ctx, file, &statesyncclient.ArchiveExportConfig{
+		Codec:       p.vm.networkCodec,
+		Handler:     p.vm.syncRequestHandler,
+		BlockParser: p.vm,
+		Summary:     summary,
+		Blocks:      vmsync.ParentsToFetch,
+		RequestSize: p.vm.config.StateSyncRequestSize,
+	})
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, args.Path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to export state sync archive: %w", err)
+	}
+
+	log.Info("Admin: exported state sync archive", "path", args.Path, "summary", summary)
+	reply.Summary = client.StateSyncTarget{
+		Height:    summary.Height(),
+		BlockHash: summary.GetBlockHash(),
+		BlockRoot: summary.GetBlockRoot(),
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/log"
+	"github.com/ava-labs/libevm/metrics"
+	"github.com/holiman/uint256"
+
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
+)
+
+var (
+	missingBlobSidecarsMeter = metrics.NewRegisteredMeter("blobs/sidecars/missing", nil)
+	invalidBlobSidecarsMeter = metrics.NewRegisteredMeter("blobs/sidecars/invalid", nil)
+)
+
+// availableBlobSidecars returns the sidecars of the blob transactions of
+// [block] known to this node: the ones of [sent], the sidecars sent along with
+// the block, matching the versioned hashes of their transaction, or else the
+// ones held by the transaction pool. The sidecars are not committed to by the
+// block ID, so the validity of the block never depends on them. The sidecars
+// missing or not matching their transaction are only counted.
+func (vm *VM) availableBlobSidecars(block *types.Block, sent []customrawdb.BlobSidecar) []customrawdb.BlobSidecar {
+	byHash := make(map[common.Hash]*types.BlobTxSidecar, len(sent))
+	for _, sidecar := range sent {
+		byHash[sidecar.TxHash] = sidecar.Sidecar
+	}
+	var sidecars []customrawdb.BlobSidecar
+	for _, tx := range block.Transactions() {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		sidecar, ok := byHash[tx.Hash()]
+		if ok {
+			if err := txpool.ValidateBlobSidecar(tx.BlobHashes(), sidecar); err != nil {
+				log.Debug("Invalid blob sidecar", "block", block.Hash(), "tx", tx.Hash(), "err", err)
+				invalidBlobSidecarsMeter.Mark(1)
+				ok = false
+			}
+		}
+		if !ok {
+			if pooled := vm.txPool.Get(tx.Hash()); pooled != nil && pooled.BlobTxSidecar() != nil {
+				sidecar, ok = pooled.BlobTxSidecar(), true
+			}
+		}
+		if !ok {
+			log.Debug("Missing blob sidecar", "block", block.Hash(), "tx", tx.Hash())
+			missingBlobSidecarsMeter.Mark(1)
+			continue
+		}
+		sidecars = append(sidecars, customrawdb.BlobSidecar{
+			TxHash:  tx.Hash(),
+			Sidecar: sidecar,
+		})
+	}
+	return sidecars
+}
+
+// hasBlobTxs returns whether [block] includes blob transactions.
+func hasBlobTxs(block *types.Block) bool {
+	for _, tx := range block.Transactions() {
+		if tx.Type() == types.BlobTxType {
+			return true
+		}
+	}
+	return false
+}
+
+// withBlobSidecars returns a copy of [block] whose blob transactions carry their
+// [sidecars], so that the blobs are sent along with the block.
+func withBlobSidecars(block *types.Block, sidecars []customrawdb.BlobSidecar) *types.Block {
+	if len(sidecars) == 0 {
+		return block
+	}
+	byHash := make(map[common.Hash]*types.BlobTxSidecar, len(sidecars))
+	for _, sidecar := range sidecars {
+		byHash[sidecar.TxHash] = sidecar.Sidecar
+	}
+	body := block.Body()
+	txs := make([]*types.Transaction, len(body.Transactions))
+	for i, tx := range body.Transactions {
+		if sidecar, ok := byHash[tx.Hash()]; ok {
+			tx = withBlobTxSidecar(tx, sidecar)
+		}
+		txs[i] = tx
+	}
+	body.Transactions = txs
+	return block.WithBody(*body)
+}
+
+// withoutBlobSidecars returns a copy of [block] with the sidecars of its blob
+// transactions removed, and the removed sidecars. The transactions root and the
+// stored block only cover the transactions without their sidecars.
+func withoutBlobSidecars(block *types.Block) (*types.Block, []customrawdb.BlobSidecar) {
+	var sidecars []customrawdb.BlobSidecar
+	body := block.Body()
+	txs := make([]*types.Transaction, len(body.Transactions))
+	for i, tx := range body.Transactions {
+		if sidecar := tx.BlobTxSidecar(); sidecar != nil {
+			sidecars = append(sidecars, customrawdb.BlobSidecar{
+				TxHash:  tx.Hash(),
+				Sidecar: sidecar,
+			})
+			tx = tx.WithoutBlobTxSidecar()
+		}
+		txs[i] = tx
+	}
+	if len(sidecars) == 0 {
+		return block, nil
+	}
+	body.Transactions = txs
+	return block.WithBody(*body), sidecars
+}
+
+// withBlobTxSidecar returns a copy of the blob transaction [tx] carrying
+// [sidecar].
+func withBlobTxSidecar(tx *types.Transaction, sidecar *types.BlobTxSidecar) *types.Transaction {
+	v, r, s := tx.RawSignatureValues()
+	return types.NewTx(&types.BlobTx{
+		ChainID:    uint256.MustFromBig(tx.ChainId()),
+		Nonce:      tx.Nonce(),
+		GasTipCap:  uint256.MustFromBig(tx.GasTipCap()),
+		GasFeeCap:  uint256.MustFromBig(tx.GasFeeCap()),
+		Gas:        tx.Gas(),
+		To:         *tx.To(),
+		Value:      uint256.MustFromBig(tx.Value()),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+		BlobFeeCap: uint256.MustFromBig(tx.BlobGasFeeCap()),
+		BlobHashes: tx.BlobHashes(),
+		Sidecar:    sidecar,
+		V:          uint256.MustFromBig(v),
+		R:          uint256.MustFromBig(r),
+		S:          uint256.MustFromBig(s),
+	})
+}
+
+// writeBlobSidecars stores the [sidecars] of the accepted [block], and deletes
+// the sidecars of the blocks past the configured retention.
+func (vm *VM) writeBlobSidecars(block *types.Block, sidecars []customrawdb.BlobSidecar) error {
+	if params.GetExtra(vm.chainConfig).BlobConfig == nil {
+		return nil
+	}
+	if len(sidecars) > 0 {
+		if err := customrawdb.WriteBlobSidecars(vm.chaindb, block.NumberU64(), block.Hash(), sidecars); err != nil {
+			return err
+		}
+	}
+	retention := vm.config.BlobSidecarRetention
+	if retention == 0 || block.NumberU64() < retention {
+		return nil
+	}
+	return customrawdb.DeleteBlobSidecarsBelow(vm.chaindb, block.NumberU64()-retention+1)
+}
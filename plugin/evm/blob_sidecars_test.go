@@ -0,0 +1,210 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+	"time"
+
+	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/upgrade/upgradetest"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto/kzg4844"
+	"github.com/ava-labs/libevm/rlp"
+	"github.com/ava-labs/libevm/trie"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/eth"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
+	"github.com/ava-labs/subnet-evm/rpc"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+func newBlobTx(t *testing.T, chainID *big.Int, nonce uint64, blob byte) *types.Transaction {
+	t.Helper()
+
+	sidecar := &types.BlobTxSidecar{Blobs: []kzg4844.Blob{{blob}}}
+	commitment, err := kzg4844.BlobToCommitment(&sidecar.Blobs[0])
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(&sidecar.Blobs[0], commitment)
+	require.NoError(t, err)
+	sidecar.Commitments = []kzg4844.Commitment{commitment}
+	sidecar.Proofs = []kzg4844.Proof{proof}
+
+	tx := &types.BlobTx{
+		ChainID:    uint256.MustFromBig(chainID),
+		Nonce:      nonce,
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(uint64(testMinGasPrice)),
+		Gas:        ethparams.TxGas,
+		To:         testEthAddrs[1],
+		Value:      uint256.NewInt(1),
+		BlobFeeCap: uint256.NewInt(ethparams.BlobTxMinBlobGasprice),
+		BlobHashes: []common.Hash{kzg4844.CalcBlobHashV1(sha256.New(), &commitment)},
+		Sidecar:    sidecar,
+	}
+	return types.MustSignNewTx(testKeys[0].ToECDSA(), types.LatestSignerForChainID(chainID), tx)
+}
+
+func TestBlobSidecars(t *testing.T) {
+	for _, scheme := range schemes {
+		t.Run(scheme, func(t *testing.T) {
+			testBlobSidecars(t, scheme)
+		})
+	}
+}
+
+func testBlobSidecars(t *testing.T, scheme string) {
+	require := require.New(t)
+
+	fork := upgradetest.Helicon
+	chainConfig := params.Copy(params.TestHeliconChainConfig)
+	blobConfig := extras.DefaultBlobConfig
+	params.GetExtra(&chainConfig).BlobConfig = &blobConfig
+	tvm := newVM(t, testVMConfig{
+		fork:        &fork,
+		genesisJSON: toGenesisJSON(&chainConfig),
+		configJSON:  getConfig(scheme, `"blob-sidecar-retention": 2`),
+	})
+	defer func() {
+		require.NoError(tvm.vm.Shutdown(t.Context()))
+	}()
+
+	var (
+		chainID = tvm.vm.chainConfig.ChainID
+		api     = eth.NewEthereumAPI(tvm.vm.eth)
+		blocks  []*types.Block
+	)
+	for i := range 3 {
+		tx := newBlobTx(t, chainID, uint64(i), byte(i+1))
+		for _, err := range tvm.vm.txPool.Add([]*types.Transaction{tx}, true, true) {
+			require.NoError(err)
+		}
+
+		tvm.vm.clock.Set(tvm.vm.clock.Time().Add(2 * time.Second))
+		blk := issueAndAccept(t, tvm.vm)
+		ethBlock := blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
+		require.Len(ethBlock.Transactions(), 1)
+		require.Equal(uint64(ethparams.BlobTxBlobGasPerBlob), *ethBlock.BlobGasUsed())
+		blocks = append(blocks, ethBlock)
+		tvm.vm.blockChain.DrainAcceptorQueue()
+
+		sidecars, err := api.GetBlobSidecars(t.Context(), rpc.BlockNumberOrHashWithHash(ethBlock.Hash(), false))
+		require.NoError(err)
+		require.Len(sidecars, 1)
+		require.Equal(tx.Hash(), sidecars[0].TxHash)
+		require.Equal(tx.BlobTxSidecar().Blobs, sidecars[0].Blobs)
+	}
+
+	// The sidecars of the blocks past the retention are deleted.
+	for i, block := range blocks {
+		_, ok, err := customrawdb.ReadBlobSidecars(tvm.vm.chaindb, block.NumberU64(), block.Hash())
+		require.NoError(err)
+		require.Equal(i > 0, ok, "block %d", block.NumberU64())
+	}
+	_, err := api.GetBlobSidecars(t.Context(), rpc.BlockNumberOrHashWithHash(blocks[0].Hash(), false))
+	require.ErrorContains(err, "blob sidecars unavailable")
+}
+
+func TestBlobSidecarsSentWithBlock(t *testing.T) {
+	for _, scheme := range schemes {
+		t.Run(scheme, func(t *testing.T) {
+			testBlobSidecarsSentWithBlock(t, scheme)
+		})
+	}
+}
+
+func testBlobSidecarsSentWithBlock(t *testing.T, scheme string) {
+	require := require.New(t)
+
+	fork := upgradetest.Helicon
+	chainConfig := params.Copy(params.TestHeliconChainConfig)
+	blobConfig := extras.DefaultBlobConfig
+	params.GetExtra(&chainConfig).BlobConfig = &blobConfig
+	tvmConfig := testVMConfig{
+		fork:        &fork,
+		genesisJSON: toGenesisJSON(&chainConfig),
+		configJSON:  getConfig(scheme, ""),
+	}
+	vm1 := newVM(t, tvmConfig).vm
+	vm2 := newVM(t, tvmConfig).vm
+	defer func() {
+		require.NoError(vm1.Shutdown(t.Context()))
+		require.NoError(vm2.Shutdown(t.Context()))
+	}()
+
+	// Only the builder holds the blob transaction in its pool.
+	tx := newBlobTx(t, vm1.chainConfig.ChainID, 0, 1)
+	for _, err := range vm1.txPool.Add([]*types.Transaction{tx}, true, true) {
+		require.NoError(err)
+	}
+	vm1.clock.Set(vm1.clock.Time().Add(2 * time.Second))
+	vm2.clock.Set(vm1.clock.Time())
+
+	msg, err := vm1.WaitForEvent(t.Context())
+	require.NoError(err)
+	require.Equal(commonEng.PendingTxs, msg)
+	vm1Blk, err := vm1.BuildBlock(t.Context())
+	require.NoError(err)
+	built := vm1Blk.(*chain.BlockWrapper).Block.(*wrappedBlock)
+
+	// The sidecars are not part of the block ID, so blocks sent without the
+	// sidecars, or with sidecars not matching the versioned hashes, are still
+	// valid, and the sidecars are dropped.
+	otherSidecar := newBlobTx(t, vm1.chainConfig.ChainID, 0, 2).BlobTxSidecar()
+	tests := []struct {
+		name  string
+		block *types.Block
+	}{
+		{
+			name:  "missing sidecar",
+			block: built.ethBlock,
+		},
+		{
+			name: "mismatched sidecar",
+			block: withBlobSidecars(built.ethBlock, []customrawdb.BlobSidecar{
+				{TxHash: tx.Hash(), Sidecar: otherSidecar},
+			}),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bytes, err := rlp.EncodeToBytes(test.block)
+			require.NoError(err)
+			blk, err := vm2.parseBlock(t.Context(), bytes)
+			require.NoError(err)
+			require.Equal(built.ID(), blk.ID())
+			require.NoError(blk.Verify(t.Context()))
+			require.Empty(blk.(*wrappedBlock).blobSidecars)
+		})
+	}
+
+	// The sidecars sent along with the block are verified and stored.
+	vm2Blk, err := vm2.ParseBlock(t.Context(), vm1Blk.Bytes())
+	require.NoError(err)
+	require.NoError(vm2Blk.Verify(t.Context()))
+	require.NoError(vm2Blk.Accept(t.Context()))
+	vm2.blockChain.DrainAcceptorQueue()
+
+	ethBlock := vm2Blk.(*chain.BlockWrapper).Block.(*wrappedBlock).ethBlock
+	require.Equal(built.ethBlock.TxHash(), types.DeriveSha(ethBlock.Transactions(), trie.NewStackTrie(nil)))
+	sidecars, err := eth.NewEthereumAPI(vm2.eth).GetBlobSidecars(t.Context(), rpc.BlockNumberOrHashWithHash(ethBlock.Hash(), false))
+	require.NoError(err)
+	require.Len(sidecars, 1)
+	require.Equal(tx.Hash(), sidecars[0].TxHash)
+	require.Equal(tx.BlobTxSidecar().Blobs, sidecars[0].Blobs)
+
+	// Accepted blocks are served with their sidecars.
+	accepted, err := vm2.GetBlock(t.Context(), vm2Blk.ID())
+	require.NoError(err)
+	require.Equal(vm1Blk.Bytes(), accepted.Bytes())
+}
@@ -13,11 +13,13 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/event"
 	"github.com/ava-labs/libevm/log"
 	"github.com/holiman/uint256"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/encryptedpool"
 	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
 
@@ -34,7 +36,19 @@ type blockBuilder struct {
 	clock *mockable.Clock
 	ctx   *snow.Context
 
-	txPool *txpool.TxPool
+	chain         *core.BlockChain
+	txPool        *txpool.TxPool
+	encryptedPool *encryptedpool.EncryptedPool // nil if the encrypted mempool is disabled
+
+	// prepare commits pending transactions to the next block for a slice of
+	// [buildSlice], while waiting to build it. Blocks are not prepared if
+	// [buildSlice] is 0.
+	prepare    func(timestamp time.Time) error
+	buildSlice time.Duration
+
+	// decryptedEnvelopes returns the transactions decrypted from the envelopes
+	// of the given parent, see [miner.Miner.DecryptedEnvelopes].
+	decryptedEnvelopes func(parent *types.Header) (types.Transactions, error)
 
 	shutdownChan <-chan struct{}
 	shutdownWg   *sync.WaitGroup
@@ -55,11 +69,16 @@ type blockBuilder struct {
 func (vm *VM) NewBlockBuilder() *blockBuilder {
 	b := &blockBuilder{
 		ctx:          vm.ctx,
+		chain:        vm.blockChain,
 		txPool:       vm.txPool,
 		shutdownChan: vm.shutdownChan,
 		shutdownWg:   &vm.shutdownWg,
 		clock:        vm.clock,
+		prepare:      vm.miner.PrepareBlock,
+		buildSlice:   vm.config.MinerBuildSlice.Duration,
 	}
+	b.encryptedPool = vm.eth.EncryptedPool()
+	b.decryptedEnvelopes = vm.miner.DecryptedEnvelopes
 	b.pendingSignal = lock.NewCond(&b.buildBlockLock)
 	return b
 }
@@ -72,9 +91,19 @@ func (b *blockBuilder) handleGenerateBlock(currentParentHash common.Hash) {
 	b.lastBuildParentHash = currentParentHash
 }
 
-// needToBuild returns true if there are outstanding transactions to be issued
-// into a block.
+// needToBuild returns true if there are outstanding transactions or envelopes
+// to be issued into a block. No block can be built on top of a block including
+// envelopes until it is accepted and its envelopes are decrypted.
 func (b *blockBuilder) needToBuild() bool {
+	if b.encryptedPool != nil {
+		decrypted, err := b.decryptedEnvelopes(b.chain.CurrentHeader())
+		if err != nil {
+			return false
+		}
+		if len(decrypted) > 0 || b.encryptedPool.Len() > 0 {
+			return true
+		}
+	}
 	size := b.txPool.PendingSize(txpool.PendingFilter{
 		MinTip: uint256.MustFromBig(b.txPool.GasTip()),
 	})
@@ -100,16 +129,32 @@ func (b *blockBuilder) awaitSubmittedTxs() {
 	events := make(chan core.NewTxPoolReorgEvent)
 	sub := b.txPool.SubscribeNewReorgEvent(events)
 
+	// envelopeChan is nil, and never ready, if the encrypted mempool is disabled.
+	var (
+		envelopeChan chan encryptedpool.NewEnvelopeEvent
+		envelopeSub  event.Subscription
+	)
+	if b.encryptedPool != nil {
+		envelopeChan = make(chan encryptedpool.NewEnvelopeEvent)
+		envelopeSub = b.encryptedPool.SubscribeEnvelopes(envelopeChan)
+	}
+
 	b.shutdownWg.Add(1)
 	go b.ctx.Log.RecoverAndPanic(func() {
 		defer b.shutdownWg.Done()
 		defer sub.Unsubscribe()
+		if envelopeSub != nil {
+			defer envelopeSub.Unsubscribe()
+		}
 
 		for {
 			select {
 			case <-txSubmitChan:
 				log.Trace("New tx detected, trying to generate a block")
 				b.signalCanBuild()
+			case <-envelopeChan:
+				log.Trace("New envelope detected, trying to generate a block")
+				b.signalCanBuild()
 			case <-b.shutdownChan:
 				return
 			case event := <-events:
@@ -143,11 +188,38 @@ func (b *blockBuilder) waitForEvent(ctx context.Context, currentHeader *types.He
 	b.ctx.Log.Debug("Last time we built a block was too recent, waiting",
 		zap.Duration("timeUntilNextBuild", timeUntilNextBuild),
 	)
-	select {
-	case <-ctx.Done():
-		return 0, ctx.Err()
-	case <-time.After(timeUntilNextBuild):
-		return commonEng.PendingTxs, nil
+	return b.waitToBuild(ctx, time.After(timeUntilNextBuild), minNextBlockTime(currentHeader))
+}
+
+// waitToBuild waits until [ready] fires. Meanwhile, if enabled, it prepares the
+// next block in slices of [buildSlice], timestamped no earlier than
+// [timestamp], so that only the transactions arriving after the last slice are
+// left to commit once it is built. A slice in progress delays the build by at
+// most [buildSlice].
+func (b *blockBuilder) waitToBuild(ctx context.Context, ready <-chan time.Time, timestamp time.Time) (commonEng.Message, error) {
+	var slices <-chan time.Time
+	if b.buildSlice > 0 && b.prepare != nil {
+		ticker := time.NewTicker(b.buildSlice)
+		defer ticker.Stop()
+		slices = ticker.C
+		b.prepareSlice(timestamp)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ready:
+			return commonEng.PendingTxs, nil
+		case <-slices:
+			b.prepareSlice(timestamp)
+		}
+	}
+}
+
+// prepareSlice prepares the next block for a slice of [buildSlice].
+func (b *blockBuilder) prepareSlice(timestamp time.Time) {
+	if err := b.prepare(timestamp); err != nil {
+		b.ctx.Log.Debug("Failed to prepare block", zap.Error(err))
 	}
 }
 
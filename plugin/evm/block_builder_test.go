@@ -4,6 +4,7 @@
 package evm
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -14,6 +15,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+
+	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
 )
 
 func TestCalculateBlockBuildingDelay(t *testing.T) {
@@ -140,6 +143,60 @@ func TestCalculateBlockBuildingDelay(t *testing.T) {
 	}
 }
 
+func TestWaitToBuild(t *testing.T) {
+	timestamp := time.Unix(1000, 0)
+	tests := []struct {
+		name         string
+		buildSlice   time.Duration
+		wantPrepared bool
+	}{
+		{
+			name:         "prepares_in_slices",
+			buildSlice:   time.Millisecond,
+			wantPrepared: true,
+		},
+		{
+			name: "disabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var prepared []time.Time
+			b := &blockBuilder{
+				prepare: func(timestamp time.Time) error {
+					prepared = append(prepared, timestamp)
+					return nil
+				},
+				buildSlice: tt.buildSlice,
+			}
+			msg, err := b.waitToBuild(context.Background(), time.After(20*time.Millisecond), timestamp)
+			require.NoError(err)
+			require.Equal(commonEng.PendingTxs, msg)
+			if !tt.wantPrepared {
+				require.Empty(prepared)
+				return
+			}
+			require.Greater(len(prepared), 1)
+			for _, got := range prepared {
+				require.Equal(timestamp, got)
+			}
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		b := &blockBuilder{
+			prepare:    func(time.Time) error { return nil },
+			buildSlice: time.Millisecond,
+		}
+		_, err := b.waitToBuild(ctx, nil, timestamp)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
 func createGraniteTestHeader(parentHash common.Hash, timeMilliseconds uint64, minDelayExcess acp226.DelayExcess) *types.Header {
 	header := &types.Header{
 		Time: timeMilliseconds / 1000,
@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/libevm/common"
 	"golang.org/x/exp/slog"
 
 	"github.com/ava-labs/subnet-evm/plugin/evm/config"
@@ -30,6 +31,45 @@ type CurrentValidator struct {
 	UptimeSeconds    uint64     `json:"uptimeSeconds"`
 }
 
+// StateSyncTarget is the summary of the block a state sync is syncing to.
+type StateSyncTarget struct {
+	Height    uint64      `json:"height"`
+	BlockHash common.Hash `json:"blockHash"`
+	BlockRoot common.Hash `json:"blockRoot"`
+}
+
+// TrieSyncProgress is the number of leafs fetched for a trie being synced.
+type TrieSyncProgress struct {
+	Root         common.Hash `json:"root"`
+	LeafsFetched uint64      `json:"leafsFetched"`
+}
+
+// PeerSyncThroughput is the throughput of the responses of a peer to state sync
+// requests.
+type PeerSyncThroughput struct {
+	NodeID         ids.NodeID `json:"nodeID"`
+	Requests       uint64     `json:"requests"`
+	Failures       uint64     `json:"failures"`
+	BytesReceived  uint64     `json:"bytesReceived"`
+	BytesPerSecond float64    `json:"bytesPerSecond"`
+}
+
+// StateSyncProgress is the progress of the last state sync started by the node.
+type StateSyncProgress struct {
+	Syncing                 bool                 `json:"syncing"`
+	Target                  *StateSyncTarget     `json:"target,omitempty"`
+	LeafsFetched            uint64               `json:"leafsFetched"`
+	LeafsPerSecond          float64              `json:"leafsPerSecond"`
+	TriesSynced             int                  `json:"triesSynced"`
+	TriesInProgress         []TrieSyncProgress   `json:"triesInProgress"`
+	OutstandingStorageTries int                  `json:"outstandingStorageTries"`
+	OutstandingCodeHashes   int                  `json:"outstandingCodeHashes"`
+	Peers                   []PeerSyncThroughput `json:"peers"`
+	// SecondsRemaining is the estimated time to finish the sync, or 0 if it
+	// is not estimated yet.
+	SecondsRemaining uint64 `json:"secondsRemaining"`
+}
+
 // Client interface for interacting with EVM [chain]
 type Client interface {
 	StartCPUProfiler(ctx context.Context, options ...rpc.Option) error
@@ -38,13 +78,16 @@ type Client interface {
 	LockProfile(ctx context.Context, options ...rpc.Option) error
 	SetLogLevel(ctx context.Context, level slog.Level, options ...rpc.Option) error
 	GetVMConfig(ctx context.Context, options ...rpc.Option) (*config.Config, error)
+	ExportStateSyncArchive(ctx context.Context, path string, options ...rpc.Option) (*StateSyncTarget, error)
 	GetCurrentValidators(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) ([]CurrentValidator, error)
+	GetStateSyncProgress(ctx context.Context, options ...rpc.Option) (*StateSyncProgress, error)
 }
 
 // Client implementation for interacting with EVM [chain]
 type client struct {
 	adminRequester      rpc.EndpointRequester
 	validatorsRequester rpc.EndpointRequester
+	stateSyncRequester  rpc.EndpointRequester
 }
 
 // NewClient returns a Client for interacting with EVM [chain]
@@ -62,6 +105,9 @@ func NewClientWithURL(url string) Client {
 		validatorsRequester: rpc.NewEndpointRequester(
 			url + "/validators",
 		),
+		stateSyncRequester: rpc.NewEndpointRequester(
+			url + "/statesync",
+		),
 	}
 }
 
@@ -103,6 +149,24 @@ func (c *client) GetVMConfig(ctx context.Context, options ...rpc.Option) (*confi
 	return res.Config, err
 }
 
+type ExportStateSyncArchiveArgs struct {
+	Path string `json:"path"`
+}
+
+type ExportStateSyncArchiveReply struct {
+	Summary StateSyncTarget `json:"summary"`
+}
+
+// ExportStateSyncArchive exports the state of the last state summary to a state
+// sync archive at [path] on the node, and returns the exported summary.
+func (c *client) ExportStateSyncArchive(ctx context.Context, path string, options ...rpc.Option) (*StateSyncTarget, error) {
+	res := &ExportStateSyncArchiveReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.exportStateSyncArchive", &ExportStateSyncArchiveArgs{
+		Path: path,
+	}, res, options...)
+	return &res.Summary, err
+}
+
 type GetCurrentValidatorsRequest struct {
 	NodeIDs []ids.NodeID `json:"nodeIDs"`
 }
@@ -119,3 +183,11 @@ func (c *client) GetCurrentValidators(ctx context.Context, nodeIDs []ids.NodeID,
 	}, res, options...)
 	return res.Validators, err
 }
+
+// GetStateSyncProgress returns the progress of the last state sync started by
+// the node
+func (c *client) GetStateSyncProgress(ctx context.Context, options ...rpc.Option) (*StateSyncProgress, error) {
+	res := &StateSyncProgress{}
+	err := c.stateSyncRequester.SendRequest(ctx, "statesync.getProgress", struct{}{}, res, options...)
+	return res, err
+}
@@ -36,6 +36,7 @@ type Config struct {
 	AdminAPIEnabled      bool   `json:"admin-api-enabled"`
 	AdminAPIDir          string `json:"admin-api-dir"`
 	WarpAPIEnabled       bool   `json:"warp-api-enabled"`
+	StateSyncAPIEnabled  bool   `json:"state-sync-api-enabled"`
 
 	// EnabledEthAPIs is a list of Ethereum services that should be enabled
 	// If none is specified, then we use the default list [defaultEnabledAPIs]
@@ -50,6 +51,17 @@ type Config struct {
 	RPCGasCap   uint64  `json:"rpc-gas-cap"`
 	RPCTxFeeCap float64 `json:"rpc-tx-fee-cap"`
 
+	// Gas price oracle strategy used by eth_maxPriorityFeePerGas and eth_suggestFees
+	// ("percentile", "mempool" or "ema")
+	GasPriceOracleStrategy string `json:"gas-price-oracle-strategy"`
+
+	// Policy ordering the transactions of the blocks built by this node
+	// ("price", "arrival", "priority" or "fair-share")
+	MinerOrderingPolicy    string           `json:"miner-ordering-policy"`
+	MinerPriorityAddresses []common.Address `json:"miner-priority-addresses"` // Accounts in the priority lane of the "priority" policy
+	MinerMaxBuildTime      Duration         `json:"miner-max-build-time"`     // Time after which a block is built with the transactions committed so far, or 0 for no limit
+	MinerBuildSlice        Duration         `json:"miner-build-slice"`        // Time of each slice spent preparing the next block while waiting to build it, or 0 to not prepare blocks
+
 	// Cache settings
 	TrieCleanCache            int `json:"trie-clean-cache"`            // Size of the trie clean cache (MB)
 	TrieDirtyCache            int `json:"trie-dirty-cache"`            // Size of the trie dirty cache (MB)
@@ -88,6 +100,28 @@ type Config struct {
 	TxPoolAccountQueue uint64   `json:"tx-pool-account-queue"`
 	TxPoolGlobalQueue  uint64   `json:"tx-pool-global-queue"`
 	TxPoolLifetime     Duration `json:"tx-pool-lifetime"`
+	TxPoolPersist      bool     `json:"tx-pool-persist"`
+
+	// Transaction Admission Rate Limits
+	TxPoolSenderRateLimit float64 `json:"tx-pool-sender-rate-limit"`
+	TxPoolSenderBurst     int     `json:"tx-pool-sender-burst"`
+	TxPoolOriginRateLimit float64 `json:"tx-pool-origin-rate-limit"`
+	TxPoolOriginBurst     int     `json:"tx-pool-origin-burst"`
+
+	// Bundle Pool Settings
+	BundlePoolMaxBundles   int      `json:"bundle-pool-max-bundles"`
+	BundlePoolMaxBundleTxs int      `json:"bundle-pool-max-bundle-txs"`
+	BundlePoolLifetime     Duration `json:"bundle-pool-lifetime"`
+
+	// Encrypted Mempool Settings
+	EncryptedMempoolEnabled              bool     `json:"encrypted-mempool-enabled"`
+	EncryptedMempoolLocalKey             string   `json:"encrypted-mempool-local-key"` // Hex-encoded AES-256 key decrypting the envelopes, for tests and local networks only
+	EncryptedMempoolMaxEnvelopesPerBlock int      `json:"encrypted-mempool-max-envelopes-per-block"`
+	EncryptedMempoolLifetime             Duration `json:"encrypted-mempool-lifetime"`
+
+	// Private Transaction Settings
+	PrivateTxLifetime Duration `json:"private-tx-lifetime"` // Time transactions sent with eth_sendPrivateRawTransaction stay private
+	PrivateTxRelease  bool     `json:"private-tx-release"`  // Release expired private transactions to public gossip instead of dropping them
 
 	APIMaxDuration           Duration      `json:"api-max-duration"`
 	WSCPURefillRate          Duration      `json:"ws-cpu-refill-rate"`
@@ -136,6 +170,7 @@ type Config struct {
 	StateSyncCommitInterval  uint64 `json:"state-sync-commit-interval"`
 	StateSyncMinBlocks       uint64 `json:"state-sync-min-blocks"`
 	StateSyncRequestSize     uint16 `json:"state-sync-request-size"`
+	StateSyncArchive         string `json:"state-sync-archive"` // Path to a state sync archive to sync from instead of peers
 
 	// Database Settings
 	InspectDatabase bool `json:"inspect-database"` // Inspects the database on startup if enabled.
@@ -166,6 +201,16 @@ type Config struct {
 	// TransactionHistory can be still used to control unindexing old transactions.
 	SkipTxIndexing bool `json:"skip-tx-indexing"`
 
+	// FeeConfigHistoryEnabled indexes the fee config changes of accepted blocks
+	// to serve eth_feeConfigHistory.
+	FeeConfigHistoryEnabled bool `json:"fee-config-history-enabled"`
+
+	// BlobSidecarRetention is the number of accepted blocks from head whose blob
+	// sidecars are kept:
+	//  * 0:   means no limit
+	//  * N:   means the sidecars of the blocks [HEAD-N+1, HEAD] are kept
+	BlobSidecarRetention uint64 `json:"blob-sidecar-retention"`
+
 	// WarpOffChainMessages encodes off-chain messages (unrelated to any on-chain event ie. block or AddressedCall)
 	// that the node should be willing to sign.
 	// Note: only supports AddressedCall payloads as defined here:
@@ -258,6 +303,9 @@ func (c *Config) validate(uint32) error {
 	if c.PushGossipPercentStake < 0 || c.PushGossipPercentStake > 1 {
 		return fmt.Errorf("push-gossip-percent-stake is %f but must be in the range [0, 1]", c.PushGossipPercentStake)
 	}
+	if c.EncryptedMempoolEnabled && c.EncryptedMempoolLocalKey == "" {
+		return errors.New("cannot enable the encrypted mempool without encrypted-mempool-local-key")
+	}
 	return nil
 }
 
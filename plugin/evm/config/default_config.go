@@ -39,6 +39,8 @@ func NewDefaultConfig() Config {
 		SnapshotWait:              false,
 		RPCGasCap:                 50_000_000, // 50M Gas Limit
 		RPCTxFeeCap:               100,        // 100 AVAX
+		GasPriceOracleStrategy:    "percentile",
+		MinerOrderingPolicy:       "price",
 		MetricsExpensiveEnabled:   true,
 		// Default to no maximum API call duration
 		APIMaxDuration: timeToDuration(0),
@@ -79,6 +81,8 @@ func NewDefaultConfig() Config {
 		StateHistory:         uint64(32),
 		// Estimated block count in 24 hours with 2s block accept period
 		HistoricalProofQueryWindow: uint64(24 * time.Hour / (2 * time.Second)),
+		// Estimated block count in 2 weeks with 2s block accept period
+		BlobSidecarRetention: uint64(14 * 24 * time.Hour / (2 * time.Second)),
 		// Mempool settings
 		TxPoolPriceLimit:   1,
 		TxPoolPriceBump:    10,
@@ -87,11 +91,24 @@ func NewDefaultConfig() Config {
 		TxPoolAccountQueue: 64,
 		TxPoolGlobalQueue:  1024,
 		TxPoolLifetime:     timeToDuration(10 * time.Minute),
+		// Transaction admission rate limits
+		TxPoolSenderBurst: 16,
+		TxPoolOriginBurst: 256,
+		// Bundle pool settings
+		BundlePoolMaxBundles:   256,
+		BundlePoolMaxBundleTxs: 16,
+		BundlePoolLifetime:     timeToDuration(time.Minute),
+		// Encrypted mempool settings
+		EncryptedMempoolMaxEnvelopesPerBlock: 256,
+		EncryptedMempoolLifetime:             timeToDuration(10 * time.Minute),
+		// Private tx settings
+		PrivateTxLifetime: timeToDuration(5 * time.Minute),
 		// RPC settings
 		BatchRequestLimit:    1000,
 		BatchResponseMaxSize: 25 * 1000 * 1000, // 25MB
 		// Subnet EVM API settings
 		ValidatorsAPIEnabled: true,
+		StateSyncAPIEnabled:  true,
 		// Database settings
 		DatabaseType: pebbledb.Name,
 		// Additional settings with sensible defaults
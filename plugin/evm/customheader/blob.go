@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package customheader
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/consensus/misc/eip4844"
+	"github.com/ava-labs/libevm/core/types"
+
+	"github.com/ava-labs/subnet-evm/params/extras"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+var (
+	errBlobGasUsedNil          = errors.New("blob gas used should not be nil")
+	errExcessBlobGasNil        = errors.New("excess blob gas should not be nil")
+	errBlobGasUsedExceedsLimit = errors.New("blob gas used exceeds the maximum blob gas per block")
+	errBlobGasUsedNotBlobs     = errors.New("blob gas used is not a multiple of the blob gas per blob")
+	errIncorrectExcessBlobGas  = errors.New("incorrect excess blob gas")
+)
+
+// ExcessBlobGas calculates the excess blob gas of the block built on [parent]
+// at [timestamp].
+//
+// The excess blob gas is 0 until blob transactions are enabled.
+func ExcessBlobGas(
+	config *extras.ChainConfig,
+	parent *types.Header,
+	timestamp uint64,
+) uint64 {
+	if !config.IsBlobEnabled(timestamp) || !config.IsBlobEnabled(parent.Time) {
+		return 0
+	}
+	var parentExcessBlobGas, parentBlobGasUsed uint64
+	if parent.ExcessBlobGas != nil {
+		parentExcessBlobGas = *parent.ExcessBlobGas
+	}
+	if parent.BlobGasUsed != nil {
+		parentBlobGasUsed = *parent.BlobGasUsed
+	}
+	excessBlobGas := parentExcessBlobGas + parentBlobGasUsed
+	if target := config.BlobConfig.TargetBlobGasPerBlock; excessBlobGas > target {
+		return excessBlobGas - target
+	}
+	return 0
+}
+
+// BlobBaseFee returns the price of a unit of blob gas in [header], or nil if the
+// header has no excess blob gas.
+//
+// Prior to blob transactions being enabled, the price is Ethereum's.
+func BlobBaseFee(config *extras.ChainConfig, header *types.Header) *big.Int {
+	if header.ExcessBlobGas == nil {
+		return nil
+	}
+	if !config.IsBlobEnabled(header.Time) {
+		return eip4844.CalcBlobFee(*header.ExcessBlobGas)
+	}
+	return fakeExponential(
+		new(big.Int).SetUint64(config.BlobConfig.MinBlobGasPrice),
+		new(big.Int).SetUint64(*header.ExcessBlobGas),
+		new(big.Int).SetUint64(config.BlobConfig.BlobGasPriceUpdateFraction),
+	)
+}
+
+// VerifyBlobGas verifies that the blob gas used and the excess blob gas of
+// [header] are consistent with [parent]. Assumes Cancun is active, which
+// requires both fields to be set.
+func VerifyBlobGas(
+	config *extras.ChainConfig,
+	parent *types.Header,
+	header *types.Header,
+) error {
+	switch {
+	case header.BlobGasUsed == nil:
+		return fmt.Errorf("%w: %s", errBlobGasUsedNil, header.Hash())
+	case header.ExcessBlobGas == nil:
+		return fmt.Errorf("%w: %s", errExcessBlobGasNil, header.Hash())
+	}
+	if maxBlobGas := config.MaxBlobGasPerBlock(header.Time); *header.BlobGasUsed > maxBlobGas {
+		return fmt.Errorf("%w: %d > %d", errBlobGasUsedExceedsLimit, *header.BlobGasUsed, maxBlobGas)
+	}
+	if *header.BlobGasUsed%ethparams.BlobTxBlobGasPerBlob != 0 {
+		return fmt.Errorf("%w: %d", errBlobGasUsedNotBlobs, *header.BlobGasUsed)
+	}
+	if expected := ExcessBlobGas(config, parent, header.Time); *header.ExcessBlobGas != expected {
+		return fmt.Errorf("%w: expected %d, found %d",
+			errIncorrectExcessBlobGas,
+			expected,
+			*header.ExcessBlobGas,
+		)
+	}
+	return nil
+}
+
+// fakeExponential approximates factor * e ** (numerator / denominator) using
+// Taylor expansion, as specified by EIP-4844.
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	var (
+		output = new(big.Int)
+		accum  = new(big.Int).Mul(factor, denominator)
+	)
+	for i := 1; accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+
+		accum.Mul(accum, numerator)
+		accum.Div(accum, denominator)
+		accum.Div(accum, big.NewInt(int64(i)))
+	}
+	return output.Div(output, denominator)
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package customheader
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/consensus/misc/eip4844"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/utils"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+func blobChainConfig(heliconTimestamp uint64) *extras.ChainConfig {
+	config := *extras.TestGraniteChainConfig
+	config.HeliconTimestamp = utils.NewUint64(heliconTimestamp)
+	blobConfig := extras.DefaultBlobConfig
+	blobConfig.TargetBlobGasPerBlock = 2 * ethparams.BlobTxBlobGasPerBlob
+	blobConfig.MinBlobGasPrice = 10
+	config.BlobConfig = &blobConfig
+	return &config
+}
+
+func TestExcessBlobGas(t *testing.T) {
+	const target = 2 * ethparams.BlobTxBlobGasPerBlob
+	tests := []struct {
+		name      string
+		config    *extras.ChainConfig
+		parent    *types.Header
+		timestamp uint64
+		want      uint64
+	}{
+		{
+			name:      "blobs_not_configured",
+			config:    extras.TestHeliconChainConfig,
+			parent:    &types.Header{BlobGasUsed: utils.NewUint64(3 * target)},
+			timestamp: 1,
+			want:      0,
+		},
+		{
+			name:      "parent_before_helicon",
+			config:    blobChainConfig(10),
+			parent:    &types.Header{Time: 9, BlobGasUsed: utils.NewUint64(3 * target)},
+			timestamp: 10,
+			want:      0,
+		},
+		{
+			name:      "nil_parent_fields",
+			config:    blobChainConfig(0),
+			parent:    &types.Header{},
+			timestamp: 1,
+			want:      0,
+		},
+		{
+			name:   "below_target",
+			config: blobChainConfig(0),
+			parent: &types.Header{
+				ExcessBlobGas: utils.NewUint64(0),
+				BlobGasUsed:   utils.NewUint64(target - ethparams.BlobTxBlobGasPerBlob),
+			},
+			timestamp: 1,
+			want:      0,
+		},
+		{
+			name:   "above_target",
+			config: blobChainConfig(0),
+			parent: &types.Header{
+				ExcessBlobGas: utils.NewUint64(ethparams.BlobTxBlobGasPerBlob),
+				BlobGasUsed:   utils.NewUint64(target + ethparams.BlobTxBlobGasPerBlob),
+			},
+			timestamp: 1,
+			want:      2 * ethparams.BlobTxBlobGasPerBlob,
+		},
+		{
+			name:   "excess_consumed",
+			config: blobChainConfig(0),
+			parent: &types.Header{
+				ExcessBlobGas: utils.NewUint64(3 * ethparams.BlobTxBlobGasPerBlob),
+				BlobGasUsed:   utils.NewUint64(0),
+			},
+			timestamp: 1,
+			want:      ethparams.BlobTxBlobGasPerBlob,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, ExcessBlobGas(test.config, test.parent, test.timestamp))
+		})
+	}
+}
+
+func TestBlobBaseFee(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(BlobBaseFee(blobChainConfig(0), &types.Header{}))
+
+	// Before blob transactions are enabled, the price is Ethereum's.
+	header := &types.Header{ExcessBlobGas: utils.NewUint64(10 * ethparams.BlobTxBlobGaspriceUpdateFraction)}
+	require.Equal(eip4844.CalcBlobFee(*header.ExcessBlobGas), BlobBaseFee(extras.TestHeliconChainConfig, header))
+
+	config := blobChainConfig(0)
+	require.Equal(big.NewInt(10), BlobBaseFee(config, &types.Header{ExcessBlobGas: utils.NewUint64(0)}))
+
+	// The price is multiplied by e for every update fraction of excess blob gas.
+	fee := BlobBaseFee(config, &types.Header{ExcessBlobGas: utils.NewUint64(ethparams.BlobTxBlobGaspriceUpdateFraction)})
+	require.Equal(big.NewInt(27), fee)
+}
+
+func TestVerifyBlobGas(t *testing.T) {
+	config := blobChainConfig(0)
+	parent := &types.Header{
+		ExcessBlobGas: utils.NewUint64(0),
+		BlobGasUsed:   utils.NewUint64(3 * ethparams.BlobTxBlobGasPerBlob),
+	}
+	tests := []struct {
+		name        string
+		config      *extras.ChainConfig
+		header      *types.Header
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			config: config,
+			header: &types.Header{
+				Time:          1,
+				ExcessBlobGas: utils.NewUint64(ethparams.BlobTxBlobGasPerBlob),
+				BlobGasUsed:   utils.NewUint64(config.BlobConfig.MaxBlobGasPerBlock),
+			},
+		},
+		{
+			name:        "nil_blob_gas_used",
+			config:      config,
+			header:      &types.Header{Time: 1, ExcessBlobGas: utils.NewUint64(ethparams.BlobTxBlobGasPerBlob)},
+			expectedErr: errBlobGasUsedNil,
+		},
+		{
+			name:        "nil_excess_blob_gas",
+			config:      config,
+			header:      &types.Header{Time: 1, BlobGasUsed: utils.NewUint64(0)},
+			expectedErr: errExcessBlobGasNil,
+		},
+		{
+			name:   "exceeds_limit",
+			config: config,
+			header: &types.Header{
+				Time:          1,
+				ExcessBlobGas: utils.NewUint64(ethparams.BlobTxBlobGasPerBlob),
+				BlobGasUsed:   utils.NewUint64(config.BlobConfig.MaxBlobGasPerBlock + ethparams.BlobTxBlobGasPerBlob),
+			},
+			expectedErr: errBlobGasUsedExceedsLimit,
+		},
+		{
+			name:   "blobs_not_enabled",
+			config: extras.TestHeliconChainConfig,
+			header: &types.Header{
+				Time:          1,
+				ExcessBlobGas: utils.NewUint64(0),
+				BlobGasUsed:   utils.NewUint64(ethparams.BlobTxBlobGasPerBlob),
+			},
+			expectedErr: errBlobGasUsedExceedsLimit,
+		},
+		{
+			name:   "not_blobs",
+			config: config,
+			header: &types.Header{
+				Time:          1,
+				ExcessBlobGas: utils.NewUint64(ethparams.BlobTxBlobGasPerBlob),
+				BlobGasUsed:   utils.NewUint64(1),
+			},
+			expectedErr: errBlobGasUsedNotBlobs,
+		},
+		{
+			name:   "incorrect_excess_blob_gas",
+			config: config,
+			header: &types.Header{
+				Time:          1,
+				ExcessBlobGas: utils.NewUint64(0),
+				BlobGasUsed:   utils.NewUint64(0),
+			},
+			expectedErr: errIncorrectExcessBlobGas,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, VerifyBlobGas(test.config, parent, test.header), test.expectedErr)
+		})
+	}
+}
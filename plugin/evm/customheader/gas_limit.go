@@ -6,6 +6,7 @@ package customheader
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/libevm/core/types"
@@ -34,6 +35,8 @@ func GasLimit(
 ) (uint64, error) {
 	timestamp := timeMS / 1000
 	switch {
+	case config.IsSubnetEVM(timestamp) && feeConfig.ElasticGasLimit != nil:
+		return elasticGasLimit(config, feeConfig, parent, timestamp)
 	case config.IsSubnetEVM(timestamp):
 		return feeConfig.GasLimit.Uint64(), nil
 	default:
@@ -77,6 +80,20 @@ func VerifyGasLimit(
 	header *types.Header,
 ) error {
 	switch {
+	case config.IsSubnetEVM(header.Time) && feeConfig.ElasticGasLimit != nil:
+		// The elastic gas limit is fully determined by the parent, so requiring
+		// the expected value bounds the change to the maximum step.
+		expectedGasLimit, err := GasLimit(config, feeConfig, parent, customtypes.HeaderTimeMilliseconds(header))
+		if err != nil {
+			return fmt.Errorf("calculating gas limit: %w", err)
+		}
+		if header.GasLimit != expectedGasLimit {
+			return fmt.Errorf("%w: expected to be %d with the elastic gas limit, but found %d",
+				errInvalidGasLimit,
+				expectedGasLimit,
+				header.GasLimit,
+			)
+		}
 	case config.IsSubnetEVM(header.Time):
 		expectedGasLimit := feeConfig.GasLimit.Uint64()
 		if header.GasLimit != expectedGasLimit {
@@ -121,3 +138,62 @@ func GasCapacity(
 ) (uint64, error) {
 	return GasLimit(config, feeConfig, parent, timeMS)
 }
+
+// elasticGasLimit calculates the gas limit of the child block of [parent] when
+// [feeConfig] has an elastic gas limit. The gas limit of the parent moves towards
+// [commontype.ElasticGasLimitConfig.MaxGasLimit] if the gas consumed within the
+// rolling window is above the target gas, and towards
+// [commontype.ElasticGasLimitConfig.MinGasLimit] if it is below. The change is
+// proportional to the distance from the target and is capped to
+// parentGasLimit / GasLimitChangeDenominator.
+//
+// elasticGasLimit should only be called if timestamp >= config.SubnetEVMTimestamp
+func elasticGasLimit(
+	config *extras.ChainConfig,
+	feeConfig commontype.FeeConfig,
+	parent *types.Header,
+	timestamp uint64,
+) (uint64, error) {
+	// If the current block is the first Subnet-EVM block, or it is the genesis
+	// block, start from the configured gas limit.
+	elastic := feeConfig.ElasticGasLimit
+	if !config.IsSubnetEVM(parent.Time) || parent.Number.Sign() == 0 {
+		return clampGasLimit(feeConfig.GasLimit.Uint64(), elastic), nil
+	}
+
+	dynamicFeeWindow, err := feeWindow(config, parent, timestamp)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		gasLimit  = clampGasLimit(parent.GasLimit, elastic)
+		targetGas = feeConfig.TargetGas.Uint64()
+		totalGas  = dynamicFeeWindow.Sum()
+		maxStep   = max(gasLimit/elastic.GasLimitChangeDenominator, 1)
+	)
+	if totalGas == targetGas || targetGas == 0 {
+		return gasLimit, nil
+	}
+
+	// step = maxStep * min(|totalGas - targetGas|, targetGas) / targetGas
+	num := new(big.Int).SetUint64(min(math.AbsDiff(totalGas, targetGas), targetGas))
+	num.Mul(num, new(big.Int).SetUint64(maxStep))
+	num.Div(num, new(big.Int).SetUint64(targetGas))
+	step := max(num.Uint64(), 1)
+
+	if totalGas > targetGas {
+		gasLimit, err = math.Add(gasLimit, step)
+		if err != nil {
+			gasLimit = elastic.MaxGasLimit
+		}
+	} else {
+		gasLimit -= min(step, gasLimit)
+	}
+	return clampGasLimit(gasLimit, elastic), nil
+}
+
+// clampGasLimit returns [gasLimit] within the bounds of [elastic].
+func clampGasLimit(gasLimit uint64, elastic *commontype.ElasticGasLimitConfig) uint64 {
+	return min(max(gasLimit, elastic.MinGasLimit), elastic.MaxGasLimit)
+}
@@ -4,6 +4,7 @@
 package customheader
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ava-labs/libevm/core/types"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/plugin/evm/upgrade/subnetevm"
 
 	ethparams "github.com/ava-labs/libevm/params"
 )
@@ -147,6 +149,95 @@ func VerifyGasLimitTest(t *testing.T, feeConfig commontype.FeeConfig) {
 	}
 }
 
+func TestElasticGasLimit(t *testing.T) {
+	feeConfig := testFeeConfig
+	feeConfig.ElasticGasLimit = &commontype.ElasticGasLimitConfig{
+		MinGasLimit:               8_000_000,
+		MaxGasLimit:               16_000_000,
+		GasLimitChangeDenominator: 1024,
+	}
+	newParent := func(number uint64, gasLimit uint64, gasUsed uint64) *types.Header {
+		return &types.Header{
+			Number:   new(big.Int).SetUint64(number),
+			Time:     1,
+			GasLimit: gasLimit,
+			GasUsed:  gasUsed,
+			Extra:    (&subnetevm.Window{}).Bytes(),
+		}
+	}
+	tests := []struct {
+		name   string
+		parent *types.Header
+		want   uint64
+	}{
+		{
+			name:   "genesis_parent",
+			parent: newParent(0, 1, 0),
+			want:   12_000_000,
+		},
+		{
+			name:   "at_target",
+			parent: newParent(1, 12_000_000, 10_000_000),
+			want:   12_000_000,
+		},
+		{
+			name:   "above_target",
+			parent: newParent(1, 12_000_000, 15_000_000),
+			want:   12_005_859, // 12_000_000 + 12_000_000/1024 * 5_000_000/10_000_000
+		},
+		{
+			name:   "twice_target",
+			parent: newParent(1, 12_000_000, 20_000_000),
+			want:   12_011_718, // 12_000_000 + 12_000_000/1024
+		},
+		{
+			name:   "far_above_target",
+			parent: newParent(1, 12_000_000, 50_000_000),
+			want:   12_011_718, // step is capped at 12_000_000/1024
+		},
+		{
+			name:   "empty",
+			parent: newParent(1, 12_000_000, 0),
+			want:   11_988_282, // 12_000_000 - 12_000_000/1024
+		},
+		{
+			name:   "max_bound",
+			parent: newParent(1, 16_000_000, 20_000_000),
+			want:   16_000_000,
+		},
+		{
+			name:   "min_bound",
+			parent: newParent(1, 8_000_000, 0),
+			want:   8_000_000,
+		},
+		{
+			name:   "parent_above_max_bound",
+			parent: newParent(1, 20_000_000, 10_000_000),
+			want:   16_000_000,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := &extras.ChainConfig{
+				NetworkUpgrades: extras.TestSubnetEVMChainConfig.NetworkUpgrades,
+			}
+			timeMS := test.parent.Time * 1000
+			got, err := GasLimit(config, feeConfig, test.parent, timeMS)
+			require.NoError(err)
+			require.Equal(test.want, got)
+
+			header := &types.Header{Time: test.parent.Time, GasLimit: got}
+			require.NoError(VerifyGasLimit(config, feeConfig, test.parent, header))
+			header.GasLimit = got + 1
+			require.ErrorIs(VerifyGasLimit(config, feeConfig, test.parent, header), errInvalidGasLimit)
+			header.GasLimit = got - 1
+			require.ErrorIs(VerifyGasLimit(config, feeConfig, test.parent, header), errInvalidGasLimit)
+		})
+	}
+}
+
 func TestGasCapacity(t *testing.T) {
 	t.Run("normal", func(t *testing.T) {
 		GasCapacityTest(t, testFeeConfig)
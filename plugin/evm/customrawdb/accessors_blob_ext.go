@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package customrawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/ethdb"
+	"github.com/ava-labs/libevm/rlp"
+
+	ethrawdb "github.com/ava-labs/libevm/core/rawdb"
+)
+
+// BlobSidecar is the sidecar of a blob transaction included in an accepted block.
+type BlobSidecar struct {
+	TxHash  common.Hash
+	Sidecar *types.BlobTxSidecar
+}
+
+// blobSidecarsKey = blobSidecarsPrefix + blockNumber (uint64 big endian) + blockHash
+func blobSidecarsKey(blockNumber uint64, blockHash common.Hash) []byte {
+	key := make([]byte, blobSidecarsKeyLength)
+	copy(key, blobSidecarsPrefix)
+	binary.BigEndian.PutUint64(key[len(blobSidecarsPrefix):], blockNumber)
+	copy(key[len(blobSidecarsPrefix)+wrappers.LongLen:], blockHash.Bytes())
+	return key
+}
+
+// WriteBlobSidecars writes the sidecars of the blob transactions of the block
+// with the given number and hash.
+func WriteBlobSidecars(db ethdb.KeyValueWriter, blockNumber uint64, blockHash common.Hash, sidecars []BlobSidecar) error {
+	data, err := rlp.EncodeToBytes(sidecars)
+	if err != nil {
+		return err
+	}
+	return db.Put(blobSidecarsKey(blockNumber, blockHash), data)
+}
+
+// ReadBlobSidecars reads the sidecars of the blob transactions of the block with
+// the given number and hash. If they are not stored, false is returned.
+func ReadBlobSidecars(db ethdb.KeyValueReader, blockNumber uint64, blockHash common.Hash) ([]BlobSidecar, bool, error) {
+	key := blobSidecarsKey(blockNumber, blockHash)
+	has, err := db.Has(key)
+	if err != nil || !has {
+		return nil, false, err
+	}
+	data, err := db.Get(key)
+	if err != nil {
+		return nil, false, err
+	}
+	var sidecars []BlobSidecar
+	if err := rlp.DecodeBytes(data, &sidecars); err != nil {
+		return nil, false, fmt.Errorf("failed to decode blob sidecars: %w", err)
+	}
+	return sidecars, true, nil
+}
+
+// DeleteBlobSidecarsBelow deletes the blob sidecars of the blocks with a number
+// lower than [blockNumber].
+func DeleteBlobSidecarsBelow(db ethdb.KeyValueStore, blockNumber uint64) error {
+	it := ethrawdb.NewKeyLengthIterator(db.NewIterator(blobSidecarsPrefix, nil), blobSidecarsKeyLength)
+	defer it.Release()
+
+	batch := db.NewBatch()
+	for it.Next() {
+		if binary.BigEndian.Uint64(it.Key()[len(blobSidecarsPrefix):]) >= blockNumber {
+			break
+		}
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package customrawdb
+
+import (
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto/kzg4844"
+	"github.com/stretchr/testify/require"
+
+	ethrawdb "github.com/ava-labs/libevm/core/rawdb"
+)
+
+func TestBlobSidecars(t *testing.T) {
+	require := require.New(t)
+	db := ethrawdb.NewMemoryDatabase()
+
+	_, ok, err := ReadBlobSidecars(db, 1, common.Hash{1})
+	require.NoError(err)
+	require.False(ok)
+
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       []kzg4844.Blob{{1}},
+		Commitments: []kzg4844.Commitment{{2}},
+		Proofs:      []kzg4844.Proof{{3}},
+	}
+	want := []BlobSidecar{{TxHash: common.Hash{4}, Sidecar: sidecar}}
+	for number := uint64(1); number <= 3; number++ {
+		require.NoError(WriteBlobSidecars(db, number, common.Hash{byte(number)}, want))
+	}
+
+	sidecars, ok, err := ReadBlobSidecars(db, 2, common.Hash{2})
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(want, sidecars)
+
+	// Sidecars are keyed by block hash as well as number.
+	_, ok, err = ReadBlobSidecars(db, 2, common.Hash{1})
+	require.NoError(err)
+	require.False(ok)
+
+	require.NoError(DeleteBlobSidecarsBelow(db, 3))
+	for number := uint64(1); number <= 3; number++ {
+		_, ok, err := ReadBlobSidecars(db, number, common.Hash{byte(number)})
+		require.NoError(err)
+		require.Equal(number == 3, ok)
+	}
+}
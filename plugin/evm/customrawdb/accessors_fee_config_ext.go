@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package customrawdb
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/ethdb"
+	"github.com/ava-labs/libevm/rlp"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+
+	ethrawdb "github.com/ava-labs/libevm/core/rawdb"
+)
+
+// FeeConfigChange is a change of the fee config made in an accepted block, either by
+// a transaction calling the fee manager precompile or by a network upgrade activating
+// or deactivating it.
+type FeeConfigChange struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	// TxHash is the hash of the transaction that changed the fee config, or the
+	// empty hash if the change was made by a network upgrade.
+	TxHash       common.Hash          `json:"txHash"`
+	Sender       common.Address       `json:"sender"`
+	OldFeeConfig commontype.FeeConfig `json:"oldFeeConfig"`
+	NewFeeConfig commontype.FeeConfig `json:"newFeeConfig"`
+}
+
+// feeConfigChangesKey = feeConfigChangesPrefix + blockNumber (uint64 big endian)
+func feeConfigChangesKey(blockNumber uint64) []byte {
+	key := make([]byte, feeConfigChangesKeyLength)
+	copy(key, feeConfigChangesPrefix)
+	binary.BigEndian.PutUint64(key[len(feeConfigChangesPrefix):], blockNumber)
+	return key
+}
+
+// WriteFeeConfigChanges writes the fee config changes made in the block at [blockNumber].
+func WriteFeeConfigChanges(db ethdb.KeyValueWriter, blockNumber uint64, changes []FeeConfigChange) error {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	return db.Put(feeConfigChangesKey(blockNumber), data)
+}
+
+// ReadFeeConfigChanges returns the fee config changes made in the blocks between
+// [from] and [to] inclusive, ordered by block number.
+func ReadFeeConfigChanges(db ethdb.Iteratee, from, to uint64) ([]FeeConfigChange, error) {
+	start := feeConfigChangesKey(from)[len(feeConfigChangesPrefix):]
+	it := ethrawdb.NewKeyLengthIterator(db.NewIterator(feeConfigChangesPrefix, start), feeConfigChangesKeyLength)
+	defer it.Release()
+
+	var changes []FeeConfigChange
+	for it.Next() {
+		if binary.BigEndian.Uint64(it.Key()[len(feeConfigChangesPrefix):]) > to {
+			break
+		}
+		var blockChanges []FeeConfigChange
+		if err := json.Unmarshal(it.Value(), &blockChanges); err != nil {
+			return nil, fmt.Errorf("failed to decode fee config changes: %w", err)
+		}
+		changes = append(changes, blockChanges...)
+	}
+	return changes, it.Error()
+}
+
+// WriteFeeConfigHistoryTail writes the first block number indexed in the fee config history.
+func WriteFeeConfigHistoryTail(db ethdb.KeyValueWriter, blockNumber uint64) error {
+	data, err := rlp.EncodeToBytes(blockNumber)
+	if err != nil {
+		return err
+	}
+	return db.Put(feeConfigHistoryTailKey, data)
+}
+
+// DeleteFeeConfigHistoryTail deletes the first block number indexed in the fee config history.
+func DeleteFeeConfigHistoryTail(db ethdb.KeyValueWriter) error {
+	return db.Delete(feeConfigHistoryTailKey)
+}
+
+// ReadFeeConfigHistoryTail reads the first block number indexed in the fee config history.
+// If the history has not been initialized, false is returned.
+func ReadFeeConfigHistoryTail(db ethdb.KeyValueReader) (uint64, bool, error) {
+	has, err := db.Has(feeConfigHistoryTailKey)
+	if err != nil || !has {
+		return 0, false, err
+	}
+	data, err := db.Get(feeConfigHistoryTailKey)
+	if err != nil {
+		return 0, false, err
+	}
+	var blockNumber uint64
+	if err := rlp.DecodeBytes(data, &blockNumber); err != nil {
+		return 0, false, err
+	}
+	return blockNumber, true, nil
+}
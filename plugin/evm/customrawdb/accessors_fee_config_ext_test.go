@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package customrawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+
+	ethrawdb "github.com/ava-labs/libevm/core/rawdb"
+)
+
+func TestFeeConfigChanges(t *testing.T) {
+	require := require.New(t)
+	db := ethrawdb.NewMemoryDatabase()
+
+	_, ok, err := ReadFeeConfigHistoryTail(db)
+	require.NoError(err)
+	require.False(ok)
+	require.NoError(WriteFeeConfigHistoryTail(db, 3))
+	tail, ok, err := ReadFeeConfigHistoryTail(db)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(uint64(3), tail)
+
+	feeConfig := commontype.FeeConfig{
+		GasLimit:                 big.NewInt(8_000_000),
+		TargetBlockRate:          2,
+		MinBaseFee:               big.NewInt(25_000_000_000),
+		TargetGas:                big.NewInt(15_000_000),
+		BaseFeeChangeDenominator: big.NewInt(36),
+		MinBlockGasCost:          big.NewInt(0),
+		MaxBlockGasCost:          big.NewInt(1_000_000),
+		BlockGasCostStep:         big.NewInt(200_000),
+	}
+	changes := make(map[uint64][]FeeConfigChange)
+	for _, number := range []uint64{5, 7, 256} {
+		newFeeConfig := feeConfig
+		newFeeConfig.MinBaseFee = new(big.Int).SetUint64(number)
+		changes[number] = []FeeConfigChange{{
+			BlockNumber:  number,
+			TxHash:       common.Hash{byte(number)},
+			Sender:       common.Address{byte(number)},
+			OldFeeConfig: feeConfig,
+			NewFeeConfig: newFeeConfig,
+		}}
+		require.NoError(WriteFeeConfigChanges(db, number, changes[number]))
+	}
+	// A key sharing the prefix with a different length must be ignored.
+	require.NoError(db.Put(append(feeConfigChangesKey(6), 0x00), []byte("foo")))
+
+	read, err := ReadFeeConfigChanges(db, 5, 256)
+	require.NoError(err)
+	require.Equal([]FeeConfigChange{changes[5][0], changes[7][0], changes[256][0]}, read)
+
+	read, err = ReadFeeConfigChanges(db, 6, 255)
+	require.NoError(err)
+	require.Equal([]FeeConfigChange{changes[7][0]}, read)
+
+	read, err = ReadFeeConfigChanges(db, 8, 255)
+	require.NoError(err)
+	require.Empty(read)
+}
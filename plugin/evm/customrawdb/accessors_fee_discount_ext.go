@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package customrawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/ethdb"
+	"github.com/ava-labs/libevm/rlp"
+)
+
+// DiscountedGasPrice is the effective gas price paid by a transaction whose
+// sender was charged a discounted base fee.
+type DiscountedGasPrice struct {
+	TxIndex  uint64
+	GasPrice *big.Int
+}
+
+// discountedGasPricesKey = discountedGasPricesPrefix + blockNumber (uint64 big endian) + blockHash
+func discountedGasPricesKey(blockNumber uint64, blockHash common.Hash) []byte {
+	key := make([]byte, discountedGasPricesKeyLength)
+	copy(key, discountedGasPricesPrefix)
+	binary.BigEndian.PutUint64(key[len(discountedGasPricesPrefix):], blockNumber)
+	copy(key[len(discountedGasPricesPrefix)+wrappers.LongLen:], blockHash.Bytes())
+	return key
+}
+
+// WriteDiscountedGasPrices writes the effective gas prices of the transactions of
+// the block with the given number and hash that were charged a discounted base fee.
+func WriteDiscountedGasPrices(db ethdb.KeyValueWriter, blockNumber uint64, blockHash common.Hash, prices []DiscountedGasPrice) error {
+	data, err := rlp.EncodeToBytes(prices)
+	if err != nil {
+		return err
+	}
+	return db.Put(discountedGasPricesKey(blockNumber, blockHash), data)
+}
+
+// ReadDiscountedGasPrices reads the effective gas prices of the transactions of the
+// block with the given number and hash that were charged a discounted base fee.
+// It returns nil if no transaction of the block was discounted.
+func ReadDiscountedGasPrices(db ethdb.KeyValueReader, blockNumber uint64, blockHash common.Hash) ([]DiscountedGasPrice, error) {
+	key := discountedGasPricesKey(blockNumber, blockHash)
+	has, err := db.Has(key)
+	if err != nil || !has {
+		return nil, err
+	}
+	data, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var prices []DiscountedGasPrice
+	if err := rlp.DecodeBytes(data, &prices); err != nil {
+		return nil, fmt.Errorf("failed to decode discounted gas prices: %w", err)
+	}
+	return prices, nil
+}
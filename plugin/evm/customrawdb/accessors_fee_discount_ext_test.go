@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package customrawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/stretchr/testify/require"
+
+	ethrawdb "github.com/ava-labs/libevm/core/rawdb"
+)
+
+func TestDiscountedGasPrices(t *testing.T) {
+	require := require.New(t)
+	db := ethrawdb.NewMemoryDatabase()
+
+	prices, err := ReadDiscountedGasPrices(db, 1, common.Hash{1})
+	require.NoError(err)
+	require.Nil(prices)
+
+	want := []DiscountedGasPrice{
+		{TxIndex: 0, GasPrice: big.NewInt(10)},
+		{TxIndex: 3, GasPrice: big.NewInt(25)},
+	}
+	require.NoError(WriteDiscountedGasPrices(db, 1, common.Hash{1}, want))
+
+	prices, err = ReadDiscountedGasPrices(db, 1, common.Hash{1})
+	require.NoError(err)
+	require.Equal(want, prices)
+
+	// Prices are keyed by block hash as well as number.
+	prices, err = ReadDiscountedGasPrices(db, 1, common.Hash{2})
+	require.NoError(err)
+	require.Nil(prices)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package customrawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/ethdb"
+	"github.com/ava-labs/libevm/rlp"
+
+	ethrawdb "github.com/ava-labs/libevm/core/rawdb"
+)
+
+// PoolTransaction is a transaction of the transaction pool saved to survive
+// node restarts.
+type PoolTransaction struct {
+	Tx    *types.Transaction
+	Local bool
+}
+
+// txPoolTransactionKey = txPoolTransactionPrefix + index (uint64 big endian)
+func txPoolTransactionKey(index uint64) []byte {
+	key := make([]byte, txPoolTransactionKeyLength)
+	copy(key, txPoolTransactionPrefix)
+	binary.BigEndian.PutUint64(key[len(txPoolTransactionPrefix):], index)
+	return key
+}
+
+// WritePoolTransactions atomically replaces the saved transactions of the
+// transaction pool with [txs].
+func WritePoolTransactions(db ethdb.KeyValueStore, txs []PoolTransaction) error {
+	batch := db.NewBatch()
+	it := ethrawdb.NewKeyLengthIterator(db.NewIterator(txPoolTransactionPrefix, nil), txPoolTransactionKeyLength)
+	for it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			it.Release()
+			return err
+		}
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return err
+	}
+	for i, tx := range txs {
+		data, err := rlp.EncodeToBytes(&tx)
+		if err != nil {
+			return fmt.Errorf("failed to encode pool transaction %s: %w", tx.Tx.Hash(), err)
+		}
+		if err := batch.Put(txPoolTransactionKey(uint64(i)), data); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// ReadPoolTransactions returns the saved transactions of the transaction pool,
+// in the order they were written.
+func ReadPoolTransactions(db ethdb.Iteratee) ([]PoolTransaction, error) {
+	it := ethrawdb.NewKeyLengthIterator(db.NewIterator(txPoolTransactionPrefix, nil), txPoolTransactionKeyLength)
+	defer it.Release()
+
+	var txs []PoolTransaction
+	for it.Next() {
+		var tx PoolTransaction
+		if err := rlp.DecodeBytes(it.Value(), &tx); err != nil {
+			return nil, fmt.Errorf("failed to decode pool transaction: %w", err)
+		}
+		txs = append(txs, tx)
+	}
+	return txs, it.Error()
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package customrawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/stretchr/testify/require"
+
+	ethrawdb "github.com/ava-labs/libevm/core/rawdb"
+)
+
+func TestPoolTransactions(t *testing.T) {
+	require := require.New(t)
+	db := ethrawdb.NewMemoryDatabase()
+
+	txs, err := ReadPoolTransactions(db)
+	require.NoError(err)
+	require.Empty(txs)
+
+	newTx := func(nonce uint64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     nonce,
+			To:        &common.Address{1},
+			Gas:       21_000,
+			GasFeeCap: big.NewInt(2),
+			GasTipCap: big.NewInt(1),
+		})
+	}
+	want := []PoolTransaction{
+		{Tx: newTx(0), Local: true},
+		{Tx: newTx(1)},
+		{Tx: newTx(2)},
+	}
+	require.NoError(WritePoolTransactions(db, want))
+	txs, err = ReadPoolTransactions(db)
+	require.NoError(err)
+	require.Len(txs, len(want))
+	for i, tx := range txs {
+		require.Equal(want[i].Tx.Hash(), tx.Tx.Hash())
+		require.Equal(want[i].Local, tx.Local)
+	}
+
+	// Writing the transactions again replaces the saved ones.
+	require.NoError(WritePoolTransactions(db, want[2:]))
+	txs, err = ReadPoolTransactions(db)
+	require.NoError(err)
+	require.Len(txs, 1)
+	require.Equal(want[2].Tx.Hash(), txs[0].Tx.Hash())
+
+	require.NoError(WritePoolTransactions(db, nil))
+	txs, err = ReadPoolTransactions(db)
+	require.NoError(err)
+	require.Empty(txs)
+}
@@ -17,21 +17,24 @@ import (
 func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 	type stat = rawdb.DatabaseStat
 	stats := []struct {
+		database  string
 		name      string
 		keyLen    int
 		keyPrefix []byte
 		stat      *stat
 	}{
-		{"Trie segments", syncSegmentsKeyLength, syncSegmentsPrefix, &stat{}},
-		{"Storage tries to fetch", syncStorageTriesKeyLength, syncStorageTriesPrefix, &stat{}},
-		{"Code to fetch", codeToFetchKeyLength, CodeToFetchPrefix, &stat{}},
-		{"Block numbers synced to", syncPerformedKeyLength, syncPerformedPrefix, &stat{}},
+		{"State sync", "Trie segments", syncSegmentsKeyLength, syncSegmentsPrefix, &stat{}},
+		{"State sync", "Storage tries to fetch", syncStorageTriesKeyLength, syncStorageTriesPrefix, &stat{}},
+		{"State sync", "Code to fetch", codeToFetchKeyLength, CodeToFetchPrefix, &stat{}},
+		{"State sync", "Block numbers synced to", syncPerformedKeyLength, syncPerformedPrefix, &stat{}},
+		{"Key-Value store", "Fee config changes", feeConfigChangesKeyLength, feeConfigChangesPrefix, &stat{}},
 	}
 
 	options := []rawdb.InspectDatabaseOption{
 		rawdb.WithDatabaseMetadataKeys(func(key []byte) bool {
 			return bytes.Equal(key, snapshotBlockHashKey) ||
 				bytes.Equal(key, syncRootKey) ||
+				bytes.Equal(key, feeConfigHistoryTailKey) ||
 				(bytes.HasPrefix(key, upgradeConfigPrefix) && len(key) == len(upgradeConfigPrefix)+common.HashLength)
 		}),
 		rawdb.WithDatabaseStatRecorder(func(key []byte, size common.StorageSize) bool {
@@ -55,7 +58,7 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 				}
 			}
 			for _, s := range stats {
-				newRows = append(newRows, []string{"State sync", s.name, s.stat.Size(), s.stat.Count()})
+				newRows = append(newRows, []string{s.database, s.name, s.stat.Size(), s.stat.Count()})
 			}
 			return newRows
 		}),
@@ -28,6 +28,8 @@ func ExampleInspectDatabase() {
 	AddCodeToFetch(db, common.Hash{})
 	// Block numbers synced to: 22 + 1 = 23 bytes
 	_ = WriteSyncPerformed(db, 0)
+	// Fee config changes: 26 + 2 = 28 bytes
+	_ = WriteFeeConfigChanges(db, 0, []FeeConfigChange{})
 
 	keyPrefix := []byte(nil)
 	keyStart := []byte(nil)
@@ -63,8 +65,9 @@ func ExampleInspectDatabase() {
 	// | State sync      | Storage tries to fetch  | 77.00 B  |     1 |
 	// | State sync      | Code to fetch           | 34.00 B  |     1 |
 	// | State sync      | Block numbers synced to | 23.00 B  |     1 |
+	// | Key-Value store | Fee config changes      | 28.00 B  |     1 |
 	// +-----------------+-------------------------+----------+-------+
-	// |                            TOTAL          | 305.00 B |       |
+	// |                            TOTAL          | 333.00 B |       |
 	// +-----------------+-------------------------+----------+-------+
 }
 
@@ -24,6 +24,19 @@ var (
 	upgradeConfigPrefix = []byte("upgrade-config-")
 )
 
+// Fee config history keys and prefixes
+var (
+	// feeConfigChangesPrefix is the prefix for the fee config changes made in accepted blocks.
+	// feeConfigChangesPrefix + block number as uint64 -> JSON encoded list of fee config changes
+	feeConfigChangesPrefix = []byte("fee-config-changes")
+	// feeConfigHistoryTailKey tracks the first block number indexed in the fee config history.
+	feeConfigHistoryTailKey = []byte("FeeConfigHistoryTail")
+)
+
+// feeConfigChangesKeyLength is the length of the key for the fee config changes of a block,
+// and is equal to [feeConfigChangesPrefix] + block number as uint64.
+var feeConfigChangesKeyLength = len(feeConfigChangesPrefix) + wrappers.LongLen
+
 // State sync progress keys and prefixes
 var (
 	// syncRootKey indicates the root of the main account trie currently being synced
@@ -54,6 +67,37 @@ var (
 	syncPerformedKeyLength = len(syncPerformedPrefix) + wrappers.LongLen
 )
 
+// Transaction pool keys and prefixes
+var (
+	// txPoolTransactionPrefix is the prefix for the transactions of the pool saved to survive restarts.
+	// txPoolTransactionPrefix + index as uint64 -> RLP encoded pool transaction
+	txPoolTransactionPrefix = []byte("txpool-tx")
+	// txPoolTransactionKeyLength is the length of the key for a saved transaction of the pool,
+	// and is equal to [txPoolTransactionPrefix] + index as uint64.
+	txPoolTransactionKeyLength = len(txPoolTransactionPrefix) + wrappers.LongLen
+)
+
+// Blob sidecar keys and prefixes
+var (
+	// blobSidecarsPrefix is the prefix for the sidecars of the blob transactions of accepted blocks.
+	// blobSidecarsPrefix + block number as uint64 + block hash -> RLP encoded list of blob sidecars
+	blobSidecarsPrefix = []byte("blob-sidecars")
+	// blobSidecarsKeyLength is the length of the key for the blob sidecars of a block,
+	// and is equal to [blobSidecarsPrefix] + block number as uint64 + block hash.
+	blobSidecarsKeyLength = len(blobSidecarsPrefix) + wrappers.LongLen + common.HashLength
+)
+
+// Fee discount keys and prefixes
+var (
+	// discountedGasPricesPrefix is the prefix for the effective gas prices of the transactions
+	// of a block that were charged a discounted base fee.
+	// discountedGasPricesPrefix + block number as uint64 + block hash -> RLP encoded list of gas prices
+	discountedGasPricesPrefix = []byte("discounted-gas-prices")
+	// discountedGasPricesKeyLength is the length of the key for the discounted gas prices of a block,
+	// and is equal to [discountedGasPricesPrefix] + block number as uint64 + block hash.
+	discountedGasPricesKeyLength = len(discountedGasPricesPrefix) + wrappers.LongLen + common.HashLength
+)
+
 var FirewoodScheme = "firewood"
 
 // upgradeConfigKey = upgradeConfigPrefix + hash
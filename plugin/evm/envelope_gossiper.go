@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/ava-labs/avalanchego/utils/bloom"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/log"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/subnet-evm/core/encryptedpool"
+	"github.com/ava-labs/subnet-evm/plugin/evm/config"
+
+	ethcommon "github.com/ava-labs/libevm/common"
+)
+
+var (
+	_ gossip.Gossipable                       = (*GossipEnvelope)(nil)
+	_ gossip.Marshaller[*GossipEnvelope]      = (*GossipEnvelopeMarshaller)(nil)
+	_ gossip.Set[*GossipEnvelope]             = (*GossipEnvelopePool)(nil)
+	_ gossip.PullGossiperSet[*GossipEnvelope] = (*GossipEnvelopePool)(nil)
+)
+
+func NewGossipEnvelopePool(pool *encryptedpool.EncryptedPool, clock *mockable.Clock, registerer prometheus.Registerer) (*GossipEnvelopePool, error) {
+	bloom, err := gossip.NewBloomFilter(
+		registerer,
+		"envelope_bloom_filter",
+		config.TxGossipBloomMinTargetElements,
+		config.TxGossipBloomTargetFalsePositiveRate,
+		config.TxGossipBloomResetFalsePositiveRate,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize bloom filter: %w", err)
+	}
+
+	return &GossipEnvelopePool{
+		pool:  pool,
+		clock: clock,
+		bloom: bloom,
+	}, nil
+}
+
+// GossipEnvelopePool exposes the encrypted pool to the envelope gossip.
+type GossipEnvelopePool struct {
+	pool  *encryptedpool.EncryptedPool
+	clock *mockable.Clock
+
+	bloom *gossip.BloomFilter
+	lock  sync.RWMutex
+}
+
+// Subscribe adds the envelopes added to the pool to the bloom filter, and
+// pushes the envelopes submitted to this node with [pushGossiper], until [ctx]
+// is done.
+func (g *GossipEnvelopePool) Subscribe(ctx context.Context, pushGossiper *gossip.PushGossiper[*GossipEnvelope]) {
+	events := make(chan encryptedpool.NewEnvelopeEvent, pendingTxsBuffer)
+	sub := g.pool.SubscribeEnvelopes(events)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("shutting down envelope subscription")
+			return
+		case event := <-events:
+			envelope := &GossipEnvelope{Tx: event.Tx}
+			g.addToBloom(envelope)
+			if event.Local {
+				pushGossiper.Add(envelope)
+			}
+		}
+	}
+}
+
+func (g *GossipEnvelopePool) addToBloom(envelope *GossipEnvelope) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.bloom.Add(envelope)
+	optimalElements := g.pool.Len() * config.TxGossipBloomChurnMultiplier
+	reset, err := gossip.ResetBloomFilterIfNeeded(g.bloom, optimalElements)
+	if err != nil {
+		log.Error("failed to reset envelope bloom filter", "err", err)
+		return
+	}
+	if reset {
+		log.Debug("resetting envelope bloom filter", "reason", "reached max filled ratio")
+
+		g.pool.Iterate(func(tx *types.Transaction) bool {
+			g.bloom.Add(&GossipEnvelope{Tx: tx})
+			return true
+		})
+	}
+}
+
+// Add adds an envelope received from a peer to the pool.
+func (g *GossipEnvelopePool) Add(envelope *GossipEnvelope) error {
+	return g.pool.Add(envelope.Tx, false, g.clock.Time())
+}
+
+func (g *GossipEnvelopePool) Has(envelopeID ids.ID) bool {
+	return g.pool.Has(ethcommon.Hash(envelopeID))
+}
+
+func (g *GossipEnvelopePool) Iterate(f func(envelope *GossipEnvelope) bool) {
+	g.pool.Iterate(func(tx *types.Transaction) bool {
+		return f(&GossipEnvelope{Tx: tx})
+	})
+}
+
+func (g *GossipEnvelopePool) Len() int {
+	return g.pool.Len()
+}
+
+func (g *GossipEnvelopePool) BloomFilter() (*bloom.Filter, ids.ID) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return g.bloom.BloomFilter()
+}
+
+type GossipEnvelopeMarshaller struct{}
+
+func (GossipEnvelopeMarshaller) MarshalGossip(envelope *GossipEnvelope) ([]byte, error) {
+	return envelope.Tx.MarshalBinary()
+}
+
+func (GossipEnvelopeMarshaller) UnmarshalGossip(bytes []byte) (*GossipEnvelope, error) {
+	envelope := &GossipEnvelope{
+		Tx: &types.Transaction{},
+	}
+
+	return envelope, envelope.Tx.UnmarshalBinary(bytes)
+}
+
+// GossipEnvelope is an envelope transaction of the encrypted pool.
+type GossipEnvelope struct {
+	Tx *types.Transaction
+}
+
+func (e *GossipEnvelope) GossipID() ids.ID {
+	return ids.ID(e.Tx.Hash())
+}
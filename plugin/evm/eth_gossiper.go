@@ -95,6 +95,9 @@ func (g *GossipEthTxPool) Subscribe(ctx context.Context) {
 			g.lock.Lock()
 			optimalElements := (g.mempool.PendingSize(txpool.PendingFilter{}) + len(pendingTxs.Txs)) * config.TxGossipBloomChurnMultiplier
 			for _, pendingTx := range pendingTxs.Txs {
+				if !g.gossipable(pendingTx) {
+					continue
+				}
 				tx := &GossipEthTx{Tx: pendingTx}
 				g.bloom.Add(tx)
 				reset, err := gossip.ResetBloomFilterIfNeeded(g.bloom, optimalElements)
@@ -107,7 +110,9 @@ func (g *GossipEthTxPool) Subscribe(ctx context.Context) {
 					log.Debug("resetting bloom filter", "reason", "reached max filled ratio")
 
 					g.mempool.IteratePending(func(tx *types.Transaction) bool {
-						g.bloom.Add(&GossipEthTx{Tx: tx})
+						if g.gossipable(tx) {
+							g.bloom.Add(&GossipEthTx{Tx: tx})
+						}
 						return true
 					})
 				}
@@ -129,12 +134,24 @@ func (g *GossipEthTxPool) Has(txID ids.ID) bool {
 	return g.mempool.Has(ethcommon.Hash(txID))
 }
 
+// Iterate iterates over the pending transactions that can be gossiped.
 func (g *GossipEthTxPool) Iterate(f func(tx *GossipEthTx) bool) {
 	g.mempool.IteratePending(func(tx *types.Transaction) bool {
+		if !g.gossipable(tx) {
+			return true
+		}
 		return f(&GossipEthTx{Tx: tx})
 	})
 }
 
+// gossipable returns whether [tx] can be advertised and served to peers. Private
+// transactions must never be, and peers cannot check the conditions of
+// conditional transactions.
+func (g *GossipEthTxPool) gossipable(tx *types.Transaction) bool {
+	hash := tx.Hash()
+	return !g.mempool.IsPrivate(hash) && g.mempool.Conditional(hash) == nil
+}
+
 func (g *GossipEthTxPool) BloomFilter() (*bloom.Filter, ids.ID) {
 	g.lock.RLock()
 	defer g.lock.RUnlock()
@@ -0,0 +1,197 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package feesim replays a load profile through the dynamic fee algorithm to
+// evaluate a [commontype.FeeConfig] before it is used in a genesis or a
+// setFeeConfig call.
+//
+// The libevm extras must be registered with [customtypes.Register] before
+// running a simulation.
+package feesim
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/core/types"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customheader"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+	"github.com/ava-labs/subnet-evm/plugin/evm/upgrade/subnetevm"
+)
+
+var (
+	ErrEmptyProfile       = errors.New("load profile is empty")
+	ErrTimestampDecreased = errors.New("block timestamp is before the parent timestamp")
+	ErrGasLimitExceeded   = errors.New("block gas used exceeds the gas limit")
+	errNotSubnetEVM       = errors.New("chain config must have the SubnetEVM upgrade active at genesis")
+)
+
+// Block is a single block of a load profile.
+type Block struct {
+	// TimeMilliseconds is the timestamp of the block in milliseconds.
+	TimeMilliseconds uint64 `json:"timeMilliseconds"`
+	// GasUsed is the gas consumed by the block.
+	GasUsed uint64 `json:"gasUsed"`
+}
+
+// Sample is the fee state of a simulated block.
+type Sample struct {
+	Number           uint64 `json:"number"`
+	TimeMilliseconds uint64 `json:"timeMilliseconds"`
+	GasUsed          uint64 `json:"gasUsed"`
+	// GasLimit is the gas limit of the block, which follows the utilization of the
+	// network if the fee config has an elastic gas limit.
+	GasLimit uint64 `json:"gasLimit"`
+	// WindowGas is the gas consumed within the fee window the base fee was computed from.
+	WindowGas uint64 `json:"windowGas"`
+	// BaseFee is the base fee of the block.
+	BaseFee *big.Int `json:"baseFee"`
+	// BlockGasCost is the gas the transactions of the block must pay for on top of the base fee.
+	BlockGasCost *big.Int `json:"blockGasCost"`
+	// EffectiveGasPrice is the minimum average gas price paid by the transactions of the block
+	// to cover both the base fee and the block gas cost. It equals the base fee for empty blocks.
+	EffectiveGasPrice *big.Int `json:"effectiveGasPrice"`
+}
+
+// Simulate replays [profile] on top of a genesis block using [config] and [feeConfig],
+// and returns the fee state of every block of [profile].
+// The genesis block has the timestamp of the first block of [profile].
+func Simulate(config *extras.ChainConfig, feeConfig commontype.FeeConfig, profile []Block) ([]Sample, error) {
+	if len(profile) == 0 {
+		return nil, ErrEmptyProfile
+	}
+	if err := feeConfig.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid fee config: %w", err)
+	}
+	genesisTime := profile[0].TimeMilliseconds / 1000
+	if !config.IsSubnetEVM(genesisTime) {
+		return nil, errNotSubnetEVM
+	}
+
+	genesis := newHeader(config, 0, profile[0].TimeMilliseconds, 0, feeConfig.GasLimit.Uint64())
+	genesis.BaseFee = new(big.Int).Set(feeConfig.MinBaseFee)
+	genesis.Extra = (&subnetevm.Window{}).Bytes()
+
+	simulator := NewSimulator(config, genesis)
+	samples := make([]Sample, 0, len(profile))
+	for _, block := range profile {
+		sample, err := simulator.Next(feeConfig, block)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// Simulator builds a chain of simulated headers on top of a parent header.
+type Simulator struct {
+	config *extras.ChainConfig
+	parent *types.Header
+}
+
+// NewSimulator returns a simulator building blocks on top of [parent].
+func NewSimulator(config *extras.ChainConfig, parent *types.Header) *Simulator {
+	return &Simulator{
+		config: config,
+		parent: parent,
+	}
+}
+
+// GasLimit returns the gas limit of the block following the last simulated block
+// if it is built at [timeMS] using [feeConfig].
+func (s *Simulator) GasLimit(feeConfig commontype.FeeConfig, timeMS uint64) (uint64, error) {
+	if timeMS < customtypes.HeaderTimeMilliseconds(s.parent) {
+		return 0, fmt.Errorf("%w: block %d", ErrTimestampDecreased, s.parent.Number.Uint64()+1)
+	}
+	return customheader.GasLimit(s.config, feeConfig, s.parent, timeMS)
+}
+
+// Next builds the block following the last simulated block using [feeConfig]
+// and returns its fee state.
+func (s *Simulator) Next(feeConfig commontype.FeeConfig, block Block) (Sample, error) {
+	number := s.parent.Number.Uint64() + 1
+	gasLimit, err := s.GasLimit(feeConfig, block.TimeMilliseconds)
+	if err != nil {
+		return Sample{}, err
+	}
+	if block.GasUsed > gasLimit {
+		return Sample{}, fmt.Errorf("%w: block %d used %d gas", ErrGasLimitExceeded, number, block.GasUsed)
+	}
+
+	header := newHeader(s.config, number, block.TimeMilliseconds, block.GasUsed, gasLimit)
+	baseFee, err := customheader.EstimateNextBaseFee(s.config, feeConfig, s.parent, block.TimeMilliseconds)
+	if err != nil {
+		return Sample{}, fmt.Errorf("failed to calculate base fee of block %d: %w", number, err)
+	}
+	header.BaseFee = baseFee
+	header.Extra, err = customheader.ExtraPrefix(s.config, s.parent, header)
+	if err != nil {
+		return Sample{}, fmt.Errorf("failed to calculate fee window of block %d: %w", number, err)
+	}
+	blockGasCost := customheader.BlockGasCost(s.config, feeConfig, s.parent, header.Time)
+	customtypes.GetHeaderExtra(header).BlockGasCost = blockGasCost
+
+	var windowGas uint64
+	if len(header.Extra) > 0 {
+		window, err := subnetevm.ParseWindow(header.Extra)
+		if err != nil {
+			return Sample{}, fmt.Errorf("failed to parse fee window of block %d: %w", number, err)
+		}
+		windowGas = window.Sum()
+	}
+	s.parent = header
+	return Sample{
+		Number:            number,
+		TimeMilliseconds:  block.TimeMilliseconds,
+		GasUsed:           block.GasUsed,
+		GasLimit:          gasLimit,
+		WindowGas:         windowGas,
+		BaseFee:           baseFee,
+		BlockGasCost:      blockGasCost,
+		EffectiveGasPrice: effectiveGasPrice(baseFee, blockGasCost, block.GasUsed),
+	}, nil
+}
+
+func newHeader(config *extras.ChainConfig, number uint64, timeMS uint64, gasUsed uint64, gasLimit uint64) *types.Header {
+	header := &types.Header{
+		Number:   new(big.Int).SetUint64(number),
+		Time:     timeMS / 1000,
+		GasUsed:  gasUsed,
+		GasLimit: gasLimit,
+	}
+	extra := &customtypes.HeaderExtra{}
+	if config.IsGranite(header.Time) {
+		extra.TimeMilliseconds = &timeMS
+	}
+	customtypes.SetHeaderExtra(header, extra)
+	return header
+}
+
+// effectiveGasPrice returns baseFee * (gasUsed + blockGasCost) / gasUsed.
+func effectiveGasPrice(baseFee *big.Int, blockGasCost *big.Int, gasUsed uint64) *big.Int {
+	if gasUsed == 0 || blockGasCost == nil || blockGasCost.Sign() == 0 {
+		return new(big.Int).Set(baseFee)
+	}
+	gas := new(big.Int).SetUint64(gasUsed)
+	price := new(big.Int).Add(gas, blockGasCost)
+	price.Mul(price, baseFee)
+	return price.Div(price, gas)
+}
+
+// ConstantLoad returns a load profile of [blocks] blocks that each use [gasUsed]
+// and are produced every [intervalMilliseconds], starting at [startMilliseconds].
+func ConstantLoad(blocks int, gasUsed uint64, startMilliseconds uint64, intervalMilliseconds uint64) []Block {
+	profile := make([]Block, blocks)
+	for i := range profile {
+		profile[i] = Block{
+			TimeMilliseconds: startMilliseconds + uint64(i)*intervalMilliseconds,
+			GasUsed:          gasUsed,
+		}
+	}
+	return profile
+}
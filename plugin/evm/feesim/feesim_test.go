@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feesim
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/params/extras"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
+)
+
+func TestMain(m *testing.M) {
+	customtypes.Register()
+	os.Exit(m.Run())
+}
+
+func TestSimulate(t *testing.T) {
+	feeConfig := extras.DefaultFeeConfig
+	tests := []struct {
+		name    string
+		config  *extras.ChainConfig
+		profile []Block
+		check   func(t *testing.T, samples []Sample)
+		wantErr error
+	}{
+		{
+			name:    "empty profile",
+			config:  extras.TestFortunaChainConfig,
+			wantErr: ErrEmptyProfile,
+		},
+		{
+			name:    "decreasing timestamp",
+			config:  extras.TestFortunaChainConfig,
+			profile: []Block{{TimeMilliseconds: 2000}, {TimeMilliseconds: 1000}},
+			wantErr: ErrTimestampDecreased,
+		},
+		{
+			name:    "gas limit exceeded",
+			config:  extras.TestFortunaChainConfig,
+			profile: []Block{{GasUsed: feeConfig.GasLimit.Uint64() + 1}},
+			wantErr: ErrGasLimitExceeded,
+		},
+		{
+			name:    "below target keeps the minimum base fee",
+			config:  extras.TestFortunaChainConfig,
+			profile: ConstantLoad(20, 1_000_000, 0, 2000),
+			check: func(t *testing.T, samples []Sample) {
+				for _, s := range samples {
+					require.Equal(t, feeConfig.MinBaseFee, s.BaseFee)
+					require.Zero(t, s.BlockGasCost.Sign())
+					require.Equal(t, s.BaseFee, s.EffectiveGasPrice)
+				}
+			},
+		},
+		{
+			name:    "above target increases the base fee",
+			config:  extras.TestFortunaChainConfig,
+			profile: ConstantLoad(20, 8_000_000, 0, 2000),
+			check: func(t *testing.T, samples []Sample) {
+				last := samples[len(samples)-1]
+				require.Positive(t, last.BaseFee.Cmp(feeConfig.MinBaseFee))
+				for i := 1; i < len(samples); i++ {
+					require.GreaterOrEqual(t, samples[i].BaseFee.Cmp(samples[i-1].BaseFee), 0)
+				}
+			},
+		},
+		{
+			name:    "fast blocks increase the block gas cost",
+			config:  extras.TestFortunaChainConfig,
+			profile: ConstantLoad(4, 1_000_000, 0, 1000),
+			check: func(t *testing.T, samples []Sample) {
+				require.Equal(t, big.NewInt(0), samples[0].BlockGasCost)
+				require.Equal(t, big.NewInt(200_000), samples[1].BlockGasCost)
+				require.Equal(t, big.NewInt(400_000), samples[2].BlockGasCost)
+				// baseFee * (1_000_000 + 400_000) / 1_000_000
+				require.Equal(t, big.NewInt(35_000_000_000), samples[2].EffectiveGasPrice)
+			},
+		},
+		{
+			name:    "no block gas cost after granite",
+			config:  extras.TestGraniteChainConfig,
+			profile: ConstantLoad(4, 1_000_000, 0, 500),
+			check: func(t *testing.T, samples []Sample) {
+				for _, s := range samples {
+					require.Zero(t, s.BlockGasCost.Sign())
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			samples, err := Simulate(test.config, feeConfig, test.profile)
+			require.ErrorIs(t, err, test.wantErr)
+			if test.wantErr != nil {
+				return
+			}
+			require.Len(t, samples, len(test.profile))
+			test.check(t, samples)
+		})
+	}
+}
+
+func TestSimulateElasticGasLimit(t *testing.T) {
+	require := require.New(t)
+
+	feeConfig := extras.DefaultFeeConfig
+	feeConfig.ElasticGasLimit = &commontype.ElasticGasLimitConfig{
+		MinGasLimit:               4_000_000,
+		MaxGasLimit:               16_000_000,
+		GasLimitChangeDenominator: 64,
+	}
+
+	// A load above the target raises the gas limit up to the maximum.
+	samples, err := Simulate(extras.TestFortunaChainConfig, feeConfig, ConstantLoad(200, 6_000_000, 0, 1000))
+	require.NoError(err)
+	require.Equal(feeConfig.GasLimit.Uint64(), samples[0].GasLimit)
+	for i := 1; i < len(samples); i++ {
+		diff := max(samples[i].GasLimit, samples[i-1].GasLimit) - min(samples[i].GasLimit, samples[i-1].GasLimit)
+		require.LessOrEqual(diff, samples[i-1].GasLimit/64)
+	}
+	require.Equal(uint64(16_000_000), samples[len(samples)-1].GasLimit)
+
+	// Empty blocks lower the gas limit down to the minimum.
+	samples, err = Simulate(extras.TestFortunaChainConfig, feeConfig, ConstantLoad(200, 0, 0, 2000))
+	require.NoError(err)
+	for i := 1; i < len(samples); i++ {
+		require.LessOrEqual(samples[i].GasLimit, samples[i-1].GasLimit)
+	}
+	require.Equal(uint64(4_000_000), samples[len(samples)-1].GasLimit)
+}
+
+func TestReadWrite(t *testing.T) {
+	require := require.New(t)
+
+	profile, err := ReadProfile(strings.NewReader("timeMilliseconds,gasUsed\n0,100\n2000,200\n"), FormatCSV)
+	require.NoError(err)
+	require.Equal([]Block{{0, 100}, {2000, 200}}, profile)
+
+	jsonProfile, err := ReadProfile(strings.NewReader(`[{"timeMilliseconds":0,"gasUsed":100},{"timeMilliseconds":2000,"gasUsed":200}]`), FormatJSON)
+	require.NoError(err)
+	require.Equal(profile, jsonProfile)
+
+	_, err = ReadProfile(strings.NewReader("time,gas\n0,100\n"), FormatCSV)
+	require.ErrorContains(err, "header") //nolint:forbidigo // error is not exported
+	_, err = ReadProfile(strings.NewReader(""), "xml")
+	require.ErrorIs(err, ErrUnknownFormat)
+
+	samples, err := Simulate(extras.TestFortunaChainConfig, extras.DefaultFeeConfig, profile)
+	require.NoError(err)
+	var buf bytes.Buffer
+	require.NoError(WriteSamples(&buf, samples, FormatCSV))
+	require.Equal(`number,timeMilliseconds,gasUsed,gasLimit,windowGas,baseFee,blockGasCost,effectiveGasPrice
+1,0,100,8000000,0,25000000000,0,25000000000
+2,2000,200,8000000,100,25000000000,0,25000000000
+`, buf.String())
+}
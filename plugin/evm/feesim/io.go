@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feesim
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown format")
+
+	profileHeader = []string{"timeMilliseconds", "gasUsed"}
+	samplesHeader = []string{"number", "timeMilliseconds", "gasUsed", "gasLimit", "windowGas", "baseFee", "blockGasCost", "effectiveGasPrice"}
+)
+
+// ReadProfile reads a load profile in [format] from [r].
+// A CSV profile has a timeMilliseconds,gasUsed header followed by a row per block.
+// A JSON profile is an array of [Block].
+func ReadProfile(r io.Reader, format string) ([]Block, error) {
+	switch format {
+	case FormatJSON:
+		var profile []Block
+		if err := json.NewDecoder(r).Decode(&profile); err != nil {
+			return nil, fmt.Errorf("failed to decode profile: %w", err)
+		}
+		return profile, nil
+	case FormatCSV:
+		records, err := csv.NewReader(r).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read profile: %w", err)
+		}
+		if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(profileHeader, ",") {
+			return nil, fmt.Errorf("profile must start with the header %q", strings.Join(profileHeader, ","))
+		}
+		profile := make([]Block, 0, len(records)-1)
+		for i, record := range records[1:] {
+			timeMS, err := strconv.ParseUint(record[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp on line %d: %w", i+2, err)
+			}
+			gasUsed, err := strconv.ParseUint(record[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid gas used on line %d: %w", i+2, err)
+			}
+			profile = append(profile, Block{TimeMilliseconds: timeMS, GasUsed: gasUsed})
+		}
+		return profile, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// WriteSamples writes [samples] in [format] to [w].
+func WriteSamples(w io.Writer, samples []Sample, format string) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(samples)
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(samplesHeader); err != nil {
+			return err
+		}
+		for _, s := range samples {
+			record := []string{
+				strconv.FormatUint(s.Number, 10),
+				strconv.FormatUint(s.TimeMilliseconds, 10),
+				strconv.FormatUint(s.GasUsed, 10),
+				strconv.FormatUint(s.GasLimit, 10),
+				strconv.FormatUint(s.WindowGas, 10),
+				s.BaseFee.String(),
+				s.BlockGasCost.String(),
+				s.EffectiveGasPrice.String(),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
@@ -14,10 +14,15 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 )
 
 var _ p2p.Handler = (*txGossipHandler)(nil)
 
+// NewTxGossipHandler returns a handler adding the gossiped transactions to
+// [mempool] and serving pull gossip requests from validators. If [admit] is not
+// nil, the transactions pushed by a peer are only added while [admit] returns no
+// error for the peer.
 func NewTxGossipHandler[T gossip.Gossipable](
 	log logging.Logger,
 	marshaller gossip.Marshaller[T],
@@ -29,6 +34,7 @@ func NewTxGossipHandler[T gossip.Gossipable](
 	validators p2p.ValidatorSet,
 	registerer prometheus.Registerer,
 	namespace string,
+	admit func(nodeID ids.NodeID) error,
 ) (*txGossipHandler, error) {
 	// push gossip messages can be handled from any peer
 	handler := gossip.NewHandler(
@@ -60,8 +66,19 @@ func NewTxGossipHandler[T gossip.Gossipable](
 		log,
 	)
 
+	var appGossipHandler p2p.Handler = handler
+	if admit != nil {
+		appGossipHandler = &admittedGossipHandler[T]{
+			handler:    handler,
+			log:        log,
+			marshaller: marshaller,
+			mempool:    mempool,
+			admit:      admit,
+		}
+	}
+
 	return &txGossipHandler{
-		appGossipHandler:  handler,
+		appGossipHandler:  appGossipHandler,
 		appRequestHandler: validatorHandler,
 	}, nil
 }
@@ -78,3 +95,55 @@ func (t *txGossipHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, goss
 func (t *txGossipHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, *common.AppError) {
 	return t.appRequestHandler.AppRequest(ctx, nodeID, deadline, requestBytes)
 }
+
+// admittedGossipHandler handles push gossip, forwarding the transactions of a
+// peer to [handler] only while they are admitted.
+type admittedGossipHandler[T gossip.Gossipable] struct {
+	p2p.NoOpHandler
+
+	handler    *gossip.Handler[T]
+	log        logging.Logger
+	marshaller gossip.Marshaller[T]
+	mempool    gossip.HandlerSet[T]
+	admit      func(nodeID ids.NodeID) error
+}
+
+func (h *admittedGossipHandler[T]) AppGossip(ctx context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	items, err := gossip.ParseAppGossip(gossipBytes)
+	if err != nil {
+		h.log.Debug("failed to unmarshal gossip", zap.Error(err))
+		return
+	}
+
+	// Items already known are not charged to the peer, as gossip commonly
+	// repeats them. Items that fail to unmarshal are left to [handler].
+	set, _ := h.mempool.(interface{ Has(ids.ID) bool })
+	admitted := items[:0]
+	for i, bytes := range items {
+		item, err := h.marshaller.UnmarshalGossip(bytes)
+		if err != nil || (set != nil && set.Has(item.GossipID())) {
+			admitted = append(admitted, bytes)
+			continue
+		}
+		if err := h.admit(nodeID); err != nil {
+			h.log.Debug("dropping gossip of peer exceeding its admission rate limit",
+				zap.Stringer("nodeID", nodeID),
+				zap.Int("dropped", len(items)-i),
+				zap.Error(err),
+			)
+			break
+		}
+		admitted = append(admitted, bytes)
+	}
+	if len(admitted) == 0 {
+		return
+	}
+	if len(admitted) < len(items) {
+		gossipBytes, err = gossip.MarshalAppGossip(admitted)
+		if err != nil {
+			h.log.Error("failed to marshal admitted gossip", zap.Error(err))
+			return
+		}
+	}
+	h.handler.AppGossip(ctx, nodeID, gossipBytes)
+}
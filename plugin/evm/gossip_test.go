@@ -91,6 +91,143 @@ func TestGossipSubscribe(t *testing.T) {
 	}, 30*time.Second, 500*time.Millisecond, "expected all transactions to eventually be in the bloom filter")
 }
 
+func TestGossipPrivateTxs(t *testing.T) {
+	require := require.New(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	txPool := setupPoolWithConfig(t, params.TestChainConfig, addr)
+	defer txPool.Close()
+	txPool.SetGasTip(common.Big1)
+	txPool.SetMinFee(common.Big0)
+
+	gossipTxPool, err := NewGossipEthTxPool(txPool, prometheus.NewRegistry())
+	require.NoError(err)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go gossipTxPool.Subscribe(ctx)
+
+	require.Eventually(func() bool {
+		return gossipTxPool.IsSubscribed()
+	}, 10*time.Second, 500*time.Millisecond, "expected gossipTxPool to be subscribed")
+
+	ethTxs := getValidEthTxs(key, 4, big.NewInt(226*utils.GWei))
+	privateTx, publicTx, expiringTx, droppedTx := ethTxs[0], ethTxs[1], ethTxs[2], ethTxs[3]
+	now := time.Now()
+	require.NoError(txPool.AddPrivate(privateTx, now.Add(time.Hour)))
+	require.NoError(txPool.AddRemotesSync([]*types.Transaction{publicTx})[0])
+	require.NoError(txPool.AddPrivate(expiringTx, now.Add(time.Minute)))
+	require.NoError(txPool.AddPrivate(droppedTx, now.Add(2*time.Minute)))
+	require.NoError(txPool.Sync())
+	require.Equal(4, txPool.PendingSize(txpool.PendingFilter{}))
+
+	gossiped := func() []common.Hash {
+		var hashes []common.Hash
+		gossipTxPool.Iterate(func(tx *GossipEthTx) bool {
+			hashes = append(hashes, tx.Tx.Hash())
+			return true
+		})
+		return hashes
+	}
+	require.Equal([]common.Hash{publicTx.Hash()}, gossiped())
+	require.Eventually(func() bool {
+		gossipTxPool.lock.RLock()
+		defer gossipTxPool.lock.RUnlock()
+
+		return gossipTxPool.bloom.Has(&GossipEthTx{Tx: publicTx})
+	}, 30*time.Second, 500*time.Millisecond, "expected the public transaction to eventually be in the bloom filter")
+	gossipTxPool.lock.RLock()
+	require.False(gossipTxPool.bloom.Has(&GossipEthTx{Tx: privateTx}))
+	require.False(gossipTxPool.bloom.Has(&GossipEthTx{Tx: expiringTx}))
+	gossipTxPool.lock.RUnlock()
+
+	// Only the expired transaction is released.
+	require.Empty(txPool.ExpirePrivate(now, true))
+	expired := txPool.ExpirePrivate(now.Add(time.Minute), true)
+	require.Len(expired, 1)
+	require.Equal(expiringTx.Hash(), expired[0].Hash())
+	require.False(txPool.IsPrivate(expiringTx.Hash()))
+	require.True(txPool.IsPrivate(privateTx.Hash()))
+	require.ElementsMatch([]common.Hash{publicTx.Hash(), expiringTx.Hash()}, gossiped())
+
+	// Expired transactions that are not released leave the pool before they are
+	// unmarked.
+	expired = txPool.ExpirePrivate(now.Add(2*time.Minute), false)
+	require.Len(expired, 1)
+	require.Equal(droppedTx.Hash(), expired[0].Hash())
+	require.False(txPool.Has(droppedTx.Hash()))
+	require.False(txPool.IsPrivate(droppedTx.Hash()))
+	require.ElementsMatch([]common.Hash{publicTx.Hash(), expiringTx.Hash()}, gossiped())
+
+	// Dropped transactions are forgotten.
+	require.True(txPool.Remove(privateTx.Hash()))
+	require.False(txPool.Has(privateTx.Hash()))
+	require.False(txPool.Remove(privateTx.Hash()))
+	require.Empty(txPool.ExpirePrivate(now, true))
+	require.False(txPool.IsPrivate(privateTx.Hash()))
+}
+
+// TestGossipPrivateTxsConcurrent adds private transactions while the gossiper
+// iterates the pool and private transactions expire, which must neither deadlock
+// nor gossip a private transaction.
+func TestGossipPrivateTxsConcurrent(t *testing.T) {
+	require := require.New(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	txPool := setupPoolWithConfig(t, params.TestChainConfig, addr)
+	defer txPool.Close()
+	txPool.SetGasTip(common.Big1)
+	txPool.SetMinFee(common.Big0)
+
+	gossipTxPool, err := NewGossipEthTxPool(txPool, prometheus.NewRegistry())
+	require.NoError(err)
+
+	var (
+		ethTxs   = getValidEthTxs(key, 100, big.NewInt(226*utils.GWei))
+		now      = time.Now()
+		added    = make(chan error, 1)
+		gossiped = make(chan common.Hash, 1)
+	)
+	go func() {
+		for _, tx := range ethTxs {
+			if err := txPool.AddPrivate(tx, now.Add(time.Hour)); err != nil {
+				added <- err
+				return
+			}
+		}
+		added <- nil
+	}()
+
+	done := false
+	for !done {
+		select {
+		case err := <-added:
+			require.NoError(err)
+			done = true
+		case hash := <-gossiped:
+			require.Failf("private transaction gossiped", "hash %s", hash)
+		case <-time.After(30 * time.Second):
+			require.FailNow("timed out adding private transactions while gossiping")
+		default:
+			gossipTxPool.Iterate(func(tx *GossipEthTx) bool {
+				select {
+				case gossiped <- tx.Tx.Hash():
+				default:
+				}
+				return true
+			})
+			require.Empty(txPool.ExpirePrivate(now, true))
+		}
+	}
+	require.Len(gossiped, 0)
+	for _, tx := range ethTxs {
+		require.True(txPool.IsPrivate(tx.Hash()))
+	}
+}
+
 func setupPoolWithConfig(t *testing.T, config *params.ChainConfig, fundedAddress common.Address) *txpool.TxPool {
 	diskdb := rawdb.NewMemoryDatabase()
 	engine := dummy.NewETHFaker()
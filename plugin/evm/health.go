@@ -8,7 +8,15 @@ import "context"
 // Health returns nil if this chain is healthy.
 // Also returns details, which should be one of:
 // string, []byte, map[string]string
-func (*VM) HealthCheck(context.Context) (interface{}, error) {
+//
+// While the node state syncs, the details report the progress of the sync.
+func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 	// TODO perform actual health check
-	return nil, nil
+	progress := vm.stateSyncProgress()
+	if !progress.Syncing {
+		return nil, nil
+	}
+	return map[string]interface{}{
+		"stateSync": progress,
+	}, nil
 }
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/ava-labs/subnet-evm/plugin/evm/client"
+)
+
+// StateSyncAPI reports the progress of the state sync of the node.
+type StateSyncAPI struct {
+	vm *VM
+}
+
+// GetProgress returns the progress of the last state sync started by the node.
+func (api *StateSyncAPI) GetProgress(_ *http.Request, _ *struct{}, reply *client.StateSyncProgress) error {
+	*reply = api.vm.stateSyncProgress()
+	return nil
+}
+
+// stateSyncProgress returns the progress of the last state sync started by the
+// node, from the counters maintained by the syncer.
+func (vm *VM) stateSyncProgress() client.StateSyncProgress {
+	progress := client.StateSyncProgress{
+		TriesInProgress: []client.TrieSyncProgress{},
+		Peers:           []client.PeerSyncThroughput{},
+	}
+	if vm.Client == nil {
+		return progress
+	}
+
+	syncProgress := vm.Client.Progress()
+	progress.Syncing = syncProgress.Syncing
+	if target := syncProgress.Target; target != nil {
+		progress.Target = &client.StateSyncTarget{
+			Height:    target.Height(),
+			BlockHash: target.GetBlockHash(),
+			BlockRoot: target.GetBlockRoot(),
+		}
+	}
+	if state := syncProgress.State; state != nil {
+		progress.LeafsFetched = state.LeafsFetched
+		progress.LeafsPerSecond = state.LeafsPerSecond
+		progress.TriesSynced = state.TriesSynced
+		progress.OutstandingStorageTries = state.TriesRemaining
+		progress.OutstandingCodeHashes = state.OutstandingCodeHashes
+		progress.SecondsRemaining = uint64(state.TimeRemaining.Seconds())
+		for root, leafs := range state.TriesInProgress {
+			progress.TriesInProgress = append(progress.TriesInProgress, client.TrieSyncProgress{
+				Root:         root,
+				LeafsFetched: leafs,
+			})
+		}
+		slices.SortFunc(progress.TriesInProgress, func(a, b client.TrieSyncProgress) int {
+			return a.Root.Cmp(b.Root)
+		})
+	}
+	for _, peer := range syncProgress.Peers {
+		progress.Peers = append(progress.Peers, client.PeerSyncThroughput{
+			NodeID:         peer.NodeID,
+			Requests:       peer.Requests,
+			Failures:       peer.Failures,
+			BytesReceived:  peer.BytesReceived,
+			BytesPerSecond: peer.BytesPerSecond,
+		})
+	}
+	return progress
+}
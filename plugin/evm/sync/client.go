@@ -4,7 +4,9 @@
 package sync
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -80,6 +82,12 @@ type ClientConfig struct {
 	Extender Extender
 
 	Client syncclient.Client
+	// Throughput is an optional tracker of the throughput of the peers serving
+	// [Client], reported by [Client.Progress], and can be nil.
+	Throughput *syncclient.PeerThroughputTracker
+	// Archive is an optional state sync archive, and can be nil. The state
+	// syncs from the archive instead of [Client] when its summary is proposed.
+	Archive *syncclient.ArchiveClient
 
 	StateSyncDone chan struct{}
 }
@@ -88,6 +96,7 @@ type client struct {
 	*ClientConfig
 
 	resumableSummary message.Syncable
+	archiveSummary   message.Syncable
 
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
@@ -95,6 +104,13 @@ type client struct {
 	// State Sync results
 	summary message.Syncable
 	err     error
+
+	// progressLock guards the fields reporting the progress of the sync, which
+	// is read concurrently with the sync.
+	progressLock sync.RWMutex
+	syncing      bool
+	target       message.Syncable
+	stateSyncer  interface{ Progress() statesync.Progress }
 }
 
 func NewClient(config *ClientConfig) Client {
@@ -113,6 +129,20 @@ type Client interface {
 	ClearOngoingSummary() error
 	Shutdown() error
 	Error() error
+	Progress() Progress
+}
+
+// Progress is a snapshot of the progress of the state sync.
+type Progress struct {
+	// Syncing is true while a state sync is in progress.
+	Syncing bool
+	// Target is the summary of the last state sync started, or nil if none.
+	Target message.Syncable
+	// State is the progress of the EVM state trie sync, or nil if it did not
+	// start yet.
+	State *statesync.Progress
+	// Peers is the throughput of the peers serving the sync, if tracked.
+	Peers []syncclient.PeerThroughput
 }
 
 // Syncer represents a step in state sync,
@@ -131,15 +161,28 @@ func (client *client) StateSyncEnabled(context.Context) (bool, error) {
 
 // GetOngoingSyncStateSummary returns a state summary that was previously started
 // and not finished, and sets [resumableSummary] if one was found.
-// Returns [database.ErrNotFound] if no ongoing summary is found or if [client.skipResume] is true.
+// Otherwise, returns the summary of [client.Archive] if set, so the engine
+// proposes it to the network.
+// Returns [database.ErrNotFound] if no summary is found.
 func (client *client) GetOngoingSyncStateSummary(context.Context) (block.StateSummary, error) {
+	if client.Archive != nil && client.archiveSummary == nil {
+		summary, err := client.Parser.Parse(client.Archive.Summary(), client.acceptSyncSummary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse state sync archive summary: %w", err)
+		}
+		client.archiveSummary = summary
+	}
+
 	if client.SkipResume {
-		return nil, database.ErrNotFound
+		return client.getArchiveSummary()
 	}
 
 	summaryBytes, err := client.MetadataDB.Get(stateSyncSummaryKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return client.getArchiveSummary()
+	}
 	if err != nil {
-		return nil, err // includes the [database.ErrNotFound] case
+		return nil, err
 	}
 
 	summary, err := client.Parser.Parse(summaryBytes, client.acceptSyncSummary)
@@ -150,6 +193,29 @@ func (client *client) GetOngoingSyncStateSummary(context.Context) (block.StateSu
 	return summary, nil
 }
 
+// getArchiveSummary returns the summary of [client.Archive], or
+// [database.ErrNotFound] if there is no archive.
+func (client *client) getArchiveSummary() (block.StateSummary, error) {
+	if client.archiveSummary == nil {
+		return nil, database.ErrNotFound
+	}
+	log.Info("proposing state sync archive summary", "summary", client.archiveSummary)
+	return client.archiveSummary, nil
+}
+
+// fromArchive returns true if [client.Archive] holds [client.summary].
+func (client *client) fromArchive() bool {
+	return client.Archive != nil && bytes.Equal(client.Archive.Summary(), client.summary.Bytes())
+}
+
+// syncClient returns the client serving the sync to [client.summary].
+func (client *client) syncClient() syncclient.Client {
+	if client.fromArchive() {
+		return client.Archive
+	}
+	return client.Client
+}
+
 // ClearOngoingSummary clears any marker of an ongoing state sync summary
 func (client *client) ClearOngoingSummary() error {
 	if err := client.MetadataDB.Delete(stateSyncSummaryKey); err != nil {
@@ -179,7 +245,7 @@ func (client *client) stateSync(ctx context.Context) error {
 	}
 
 	if client.Extender != nil {
-		return client.Extender.Sync(ctx, client.Client, client.VerDB, client.summary)
+		return client.Extender.Sync(ctx, client.syncClient(), client.VerDB, client.summary)
 	}
 	return nil
 }
@@ -225,7 +291,13 @@ func (client *client) acceptSyncSummary(proposedSummary message.Syncable) (block
 		return block.StateSyncSkipped, fmt.Errorf("failed to commit db: %w", err)
 	}
 
-	log.Info("Starting state sync", "summary", proposedSummary)
+	log.Info("Starting state sync", "summary", proposedSummary, "fromArchive", client.fromArchive())
+
+	client.progressLock.Lock()
+	client.syncing = true
+	client.target = proposedSummary
+	client.stateSyncer = nil
+	client.progressLock.Unlock()
 
 	// create a cancellable ctx for the state sync goroutine
 	ctx, cancel := context.WithCancel(context.Background())
@@ -240,6 +312,9 @@ func (client *client) acceptSyncSummary(proposedSummary message.Syncable) (block
 		} else {
 			client.err = client.finishSync()
 		}
+		client.progressLock.Lock()
+		client.syncing = false
+		client.progressLock.Unlock()
 		// notify engine regardless of whether err == nil,
 		// this error will be propagated to the engine when it calls
 		// vm.SetState(snow.Bootstrapping)
@@ -280,7 +355,7 @@ func (client *client) syncBlocks(ctx context.Context, fromHash common.Hash, from
 		if err := ctx.Err(); err != nil {
 			return err
 		}
-		blocks, err := client.Client.GetBlocks(ctx, nextHash, nextHeight, parentsPerRequest)
+		blocks, err := client.syncClient().GetBlocks(ctx, nextHash, nextHeight, parentsPerRequest)
 		if err != nil {
 			log.Error("could not get blocks from peer", "err", err, "nextHash", nextHash, "remaining", i+1)
 			return err
@@ -301,7 +376,7 @@ func (client *client) syncBlocks(ctx context.Context, fromHash common.Hash, from
 func (client *client) syncStateTrie(ctx context.Context) error {
 	log.Info("state sync: sync starting", "root", client.summary.GetBlockRoot())
 	evmSyncer, err := statesync.NewStateSyncer(&statesync.StateSyncerConfig{
-		Client:                   client.Client,
+		Client:                   client.syncClient(),
 		Root:                     client.summary.GetBlockRoot(),
 		BatchSize:                ethdb.IdealBatchSize,
 		DB:                       client.ChaindDB,
@@ -312,6 +387,10 @@ func (client *client) syncStateTrie(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	client.progressLock.Lock()
+	client.stateSyncer = evmSyncer
+	client.progressLock.Unlock()
+
 	if err := evmSyncer.Start(ctx); err != nil {
 		return err
 	}
@@ -411,5 +490,24 @@ func (client *client) commitVMMarkers() error {
 	return client.VerDB.Commit()
 }
 
+// Progress returns a snapshot of the progress of the state sync.
+func (client *client) Progress() Progress {
+	client.progressLock.RLock()
+	defer client.progressLock.RUnlock()
+
+	progress := Progress{
+		Syncing: client.syncing,
+		Target:  client.target,
+	}
+	if client.stateSyncer != nil {
+		state := client.stateSyncer.Progress()
+		progress.State = &state
+	}
+	if client.Throughput != nil {
+		progress.Peers = client.Throughput.Peers()
+	}
+	return progress
+}
+
 // Error returns a non-nil error if one occurred during the sync.
 func (client *client) Error() error { return client.err }
@@ -8,6 +8,9 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -38,6 +41,7 @@ import (
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/coretest"
 	"github.com/ava-labs/subnet-evm/params/paramstest"
+	"github.com/ava-labs/subnet-evm/plugin/evm/client"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
 	"github.com/ava-labs/subnet-evm/sync/statesync/statesynctest"
 	"github.com/ava-labs/subnet-evm/utils/utilstest"
@@ -86,6 +90,19 @@ func TestStateSyncFromScratchExceedParent(t *testing.T) {
 	testSyncerVM(t, vmSetup, test)
 }
 
+func TestStateSyncFromArchive(t *testing.T) {
+	rand.Seed(1)
+	test := syncTest{
+		syncableInterval:   256,
+		stateSyncMinBlocks: 50, // must be less than [syncableInterval] to perform sync
+		syncMode:           block.StateSyncStatic,
+		fromArchive:        true,
+	}
+	vmSetup := createSyncServerAndClientVMs(t, test, syncervm.ParentsToFetch)
+
+	testSyncerVM(t, vmSetup, test)
+}
+
 func TestStateSyncToggleEnabledToDisabled(t *testing.T) {
 	rand.New(rand.NewSource(1))
 
@@ -303,9 +320,22 @@ func createSyncServerAndClientVMs(t *testing.T, test syncTest, numBlocks int) *s
 	// initialise [syncerVM] with blank genesis state
 	// Match the server's state-sync-commit-interval so parsed summaries are acceptable.
 	stateSyncEnabledJSON := fmt.Sprintf(
-		`{"state-sync-enabled":true, "state-sync-min-blocks": %d, "tx-lookup-limit": %d, "state-sync-commit-interval": %d}`,
+		`{"state-sync-enabled":true, "state-sync-min-blocks": %d, "tx-lookup-limit": %d, "state-sync-commit-interval": %d`,
 		test.stateSyncMinBlocks, 4, test.syncableInterval,
 	)
+	if test.fromArchive {
+		archivePath := filepath.Join(t.TempDir(), "archive")
+		admin := NewAdminService(serverVM.vm, t.TempDir())
+		var reply client.ExportStateSyncArchiveReply
+		require.NoError(admin.ExportStateSyncArchive(
+			httptest.NewRequest(http.MethodPost, "/", nil),
+			&client.ExportStateSyncArchiveArgs{Path: archivePath},
+			&reply,
+		))
+		require.Equal(uint64(numBlocks)-uint64(numBlocks)%test.syncableInterval, reply.Summary.Height)
+		stateSyncEnabledJSON += fmt.Sprintf(`, "state-sync-archive": %q`, archivePath)
+	}
+	stateSyncEnabledJSON += "}"
 	syncerVM := newVM(t, testVMConfig{
 		genesisJSON: toGenesisJSON(paramstest.ForkToChainConfig[upgradetest.Latest]),
 		configJSON:  stateSyncEnabledJSON,
@@ -398,6 +428,8 @@ type syncTest struct {
 	syncableInterval   uint64
 	syncMode           block.StateSyncMode
 	expectedErr        error
+	// fromArchive syncs [syncerVM] from an archive exported by [serverVM].
+	fromArchive bool
 }
 
 func testSyncerVM(t *testing.T, vmSetup *syncVMSetup, test syncTest) {
@@ -416,6 +448,12 @@ func testSyncerVM(t *testing.T, vmSetup *syncVMSetup, test syncTest) {
 	retrievedSummary, err := serverVM.GetStateSummary(t.Context(), parsedSummary.Height())
 	require.NoError(err, "error getting state sync summary at height")
 	require.Equal(summary, retrievedSummary)
+	if test.fromArchive {
+		// The engine proposes the summary of the archive to the network.
+		ongoingSummary, err := syncerVM.GetOngoingSyncStateSummary(t.Context())
+		require.NoError(err)
+		require.Equal(summary.Bytes(), ongoingSummary.Bytes())
+	}
 
 	syncMode, err := parsedSummary.Accept(t.Context())
 	require.NoError(err, "error accepting state summary")
@@ -439,6 +477,24 @@ func testSyncerVM(t *testing.T, vmSetup *syncVMSetup, test syncTest) {
 	}
 	require.NoError(err, "state sync failed")
 
+	progress := syncerVM.stateSyncProgress()
+	require.False(progress.Syncing)
+	targetBlock := serverVM.blockChain.GetBlockByNumber(parsedSummary.Height())
+	require.Equal(&client.StateSyncTarget{
+		Height:    targetBlock.NumberU64(),
+		BlockHash: targetBlock.Hash(),
+		BlockRoot: targetBlock.Root(),
+	}, progress.Target)
+	require.Positive(progress.LeafsFetched)
+	require.Empty(progress.TriesInProgress)
+	require.Zero(progress.OutstandingStorageTries)
+	require.Zero(progress.OutstandingCodeHashes)
+	if test.fromArchive {
+		require.Empty(progress.Peers, "no sync request sent to peers")
+	} else {
+		require.NotEmpty(progress.Peers)
+	}
+
 	// set [syncerVM] to bootstrapping and verify the last accepted block has been updated correctly
 	// and that we can bootstrap and process some blocks.
 	require.NoError(syncerVM.SetState(t.Context(), snow.Bootstrapping))
@@ -23,11 +23,16 @@ import (
 	"github.com/ava-labs/avalanchego/utils/bloom"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/encryptedpool"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/params/paramstest"
 	"github.com/ava-labs/subnet-evm/utils/utilstest"
 
@@ -267,3 +272,60 @@ type noOpGossiper struct{}
 func (noOpGossiper) Gossip(context.Context) error {
 	return nil
 }
+
+// Tests that a gossiped envelope is added to the encrypted pool
+func TestEnvelopePushGossipInbound(t *testing.T) {
+	require := require.New(t)
+	ctx := t.Context()
+	snowCtx := utilstest.NewTestSnowContext(t)
+
+	sender := &enginetest.Sender{}
+	vm := &VM{
+		ethTxPullGossiper: noOpGossiper{},
+	}
+
+	chainConfig := params.Copy(paramstest.ForkToChainConfig[upgradetest.Latest])
+	envelopeConfig := extras.DefaultEnvelopeConfig
+	params.GetExtra(&chainConfig).EnvelopeConfig = &envelopeConfig
+	require.NoError(vm.Initialize(
+		ctx,
+		snowCtx,
+		memdb.New(),
+		[]byte(toGenesisJSON(&chainConfig)),
+		nil,
+		[]byte(`{"encrypted-mempool-enabled": true, "encrypted-mempool-local-key": "0x0000000000000000000000000000000000000000000000000000000000000000"}`),
+		nil,
+		sender,
+	))
+	require.NoError(vm.SetState(ctx, snow.NormalOp))
+
+	defer func() {
+		require.NoError(vm.Shutdown(ctx))
+	}()
+
+	key, err := encryptedpool.NewLocalKey(make([]byte, encryptedpool.LocalKeySize))
+	require.NoError(err)
+	signer := types.NewEIP155Signer(vm.chainConfig.ChainID)
+	tx := types.NewTransaction(0, testEthAddrs[0], big.NewInt(10), 21000, big.NewInt(testMinGasPrice), nil)
+	signedTx, err := types.SignTx(tx, signer, testKeys[1].ToECDSA())
+	require.NoError(err)
+	ciphertext, err := key.Seal(signedTx)
+	require.NoError(err)
+	envelope := types.NewTransaction(0, core.EnvelopeAddress, common.Big0, 100_000, big.NewInt(testMinGasPrice), ciphertext)
+	signedEnvelope, err := types.SignTx(envelope, signer, testKeys[0].ToECDSA())
+	require.NoError(err)
+	envelopeBytes, err := signedEnvelope.MarshalBinary()
+	require.NoError(err)
+
+	inboundGossipBytes, err := proto.Marshal(&sdk.PushGossip{
+		Gossip: [][]byte{envelopeBytes},
+	})
+	require.NoError(err)
+
+	inboundGossipMsg := append(binary.AppendUvarint(nil, envelopeGossipHandlerID), inboundGossipBytes...)
+	require.NoError(vm.AppGossip(ctx, ids.EmptyNodeID, inboundGossipMsg))
+
+	require.True(vm.eth.EncryptedPool().Has(signedEnvelope.Hash()))
+	// The envelope is only decrypted once the block including it is accepted.
+	require.False(vm.txPool.Has(signedTx.Hash()))
+}
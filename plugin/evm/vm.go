@@ -59,6 +59,7 @@ import (
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
 	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/encryptedpool"
 	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/eth"
 	"github.com/ava-labs/subnet-evm/eth/ethconfig"
@@ -123,9 +124,18 @@ const (
 	ethRPCEndpoint       = "/rpc"
 	ethWSEndpoint        = "/ws"
 	validatorsEndpoint   = "/validators"
+	stateSyncEndpoint    = "/statesync"
 	ethTxGossipNamespace = "eth_tx_gossip"
 )
 
+const (
+	// envelopeGossipHandlerID identifies the gossip of the encrypted transaction
+	// envelopes. It is fixed, and kept clear of the handler IDs standardized by
+	// avalanchego, as peers running different versions must agree on it.
+	envelopeGossipHandlerID = 128
+	envelopeGossipNamespace = "envelope_gossip"
+)
+
 var (
 	// Set last accepted key to be longer than the keys used to store accepted block IDs.
 	lastAcceptedKey    = []byte("last_accepted_key")
@@ -252,6 +262,11 @@ type VM struct {
 	// State sync server and client
 	vmsync.Server
 	vmsync.Client
+	// syncRequestHandler serves the state sync requests of peers, and the
+	// exports of state sync archives.
+	syncRequestHandler message.RequestHandler
+	// stateSyncArchive is the state sync archive to sync from, or nil.
+	stateSyncArchive *statesyncclient.ArchiveClient
 
 	// Avalanche Warp Messaging backend
 	// Used to serve BLS signatures of warp messages over RPC
@@ -365,6 +380,11 @@ func (vm *VM) Initialize(
 	vm.ethConfig.RPCGasCap = vm.config.RPCGasCap
 	vm.ethConfig.RPCEVMTimeout = vm.config.APIMaxDuration.Duration
 	vm.ethConfig.RPCTxFeeCap = vm.config.RPCTxFeeCap
+	vm.ethConfig.GPO.Strategy = vm.config.GasPriceOracleStrategy
+	vm.ethConfig.Miner.OrderingPolicy = vm.config.MinerOrderingPolicy
+	vm.ethConfig.Miner.PriorityAddresses = vm.config.MinerPriorityAddresses
+	vm.ethConfig.Miner.MaxBuildTime = vm.config.MinerMaxBuildTime.Duration
+	vm.ethConfig.Miner.BuildSlice = vm.config.MinerBuildSlice.Duration
 
 	vm.ethConfig.TxPool.Locals = vm.config.PriorityRegossipAddresses
 	vm.ethConfig.TxPool.NoLocals = !vm.config.LocalTxsEnabled
@@ -375,6 +395,27 @@ func (vm *VM) Initialize(
 	vm.ethConfig.TxPool.AccountQueue = vm.config.TxPoolAccountQueue
 	vm.ethConfig.TxPool.GlobalQueue = vm.config.TxPoolGlobalQueue
 	vm.ethConfig.TxPool.Lifetime = vm.config.TxPoolLifetime.Duration
+	vm.ethConfig.PersistTxPool = vm.config.TxPoolPersist
+	vm.ethConfig.TxPoolAdmission = txpool.AdmissionConfig{
+		SenderRate:  vm.config.TxPoolSenderRateLimit,
+		SenderBurst: vm.config.TxPoolSenderBurst,
+		OriginRate:  vm.config.TxPoolOriginRateLimit,
+		OriginBurst: vm.config.TxPoolOriginBurst,
+	}
+	vm.ethConfig.BundlePool.MaxBundles = vm.config.BundlePoolMaxBundles
+	vm.ethConfig.BundlePool.MaxBundleTxs = vm.config.BundlePoolMaxBundleTxs
+	vm.ethConfig.BundlePool.Lifetime = vm.config.BundlePoolLifetime.Duration
+	if vm.config.EncryptedMempoolEnabled {
+		key, err := encryptedpool.NewLocalKey(common.FromHex(vm.config.EncryptedMempoolLocalKey))
+		if err != nil {
+			return fmt.Errorf("failed to initialize encrypted mempool key: %w", err)
+		}
+		vm.ethConfig.EnvelopeDecrypter = key
+		vm.ethConfig.EncryptedPool.MaxEnvelopesPerBlock = vm.config.EncryptedMempoolMaxEnvelopesPerBlock
+		vm.ethConfig.EncryptedPool.Lifetime = vm.config.EncryptedMempoolLifetime.Duration
+	}
+	vm.ethConfig.PrivateTxLifetime = vm.config.PrivateTxLifetime.Duration
+	vm.ethConfig.PrivateTxRelease = vm.config.PrivateTxRelease
 	// If we re-enable txpool journaling, we should also add the saved local
 	// transactions to the p2p gossip on startup.
 	vm.ethConfig.TxPool.Journal = "" // disable journal
@@ -406,6 +447,7 @@ func (vm *VM) Initialize(
 	vm.ethConfig.StateHistory = vm.config.StateHistory
 	vm.ethConfig.TransactionHistory = vm.config.TransactionHistory
 	vm.ethConfig.SkipTxIndexing = vm.config.SkipTxIndexing
+	vm.ethConfig.FeeConfigHistory = vm.config.FeeConfigHistoryEnabled
 	vm.ethConfig.StateScheme = vm.config.StateScheme
 
 	if vm.ethConfig.StateScheme == customrawdb.FirewoodScheme {
@@ -684,6 +726,7 @@ func (vm *VM) initializeStateSync(lastAcceptedHeight uint64) error {
 		syncStats,
 	)
 	vm.Network.SetRequestHandler(networkHandler)
+	vm.syncRequestHandler = networkHandler
 
 	vm.Server = vmsync.NewServer(vm.blockChain, vm.extensionConfig.SyncSummaryProvider, vm.config.StateSyncCommitInterval) // parse nodeIDs from state sync IDs in vm config
 	// parse nodeIDs from state sync IDs in vm config
@@ -704,6 +747,15 @@ func (vm *VM) initializeStateSync(lastAcceptedHeight uint64) error {
 	leafMetricsNames := make(map[message.NodeType]string)
 	leafMetricsNames[stateLeafRequestConfig.LeafType] = stateLeafRequestConfig.MetricName
 
+	if vm.config.StateSyncEnabled && vm.config.StateSyncArchive != "" {
+		archive, err := statesyncclient.OpenArchive(vm.config.StateSyncArchive, vm.networkCodec, vm)
+		if err != nil {
+			return err
+		}
+		vm.stateSyncArchive = archive
+	}
+
+	throughput := statesyncclient.NewPeerThroughputTracker()
 	vm.Client = vmsync.NewClient(&vmsync.ClientConfig{
 		StateSyncDone: vm.stateSyncDone,
 		Chain:         vm.eth,
@@ -715,8 +767,11 @@ func (vm *VM) initializeStateSync(lastAcceptedHeight uint64) error {
 				Stats:            stats.NewClientSyncerStats(leafMetricsNames),
 				StateSyncNodeIDs: stateSyncIDs,
 				BlockParser:      vm,
+				Throughput:       throughput,
 			},
 		),
+		Throughput:         throughput,
+		Archive:            vm.stateSyncArchive,
 		Enabled:            vm.config.StateSyncEnabled,
 		SkipResume:         vm.config.StateSyncSkipResume,
 		MinBlocks:          vm.config.StateSyncMinBlocks,
@@ -906,6 +961,9 @@ func (vm *VM) onNormalOperationsStarted() error {
 			vm.P2PValidators(),
 			vm.sdkMetrics,
 			"eth_tx_gossip",
+			func(nodeID ids.NodeID) error {
+				return vm.txPool.AdmitOrigin("peer:" + nodeID.String())
+			},
 		)
 	}
 	if err != nil {
@@ -944,6 +1002,101 @@ func (vm *VM) onNormalOperationsStarted() error {
 		vm.shutdownWg.Done()
 	}()
 
+	if pool := vm.eth.EncryptedPool(); pool != nil {
+		if err := vm.initEnvelopeGossip(ctx, pool); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// initEnvelopeGossip starts the gossip of the envelopes of the encrypted pool.
+// Envelopes are only pushed to validators, and only validators pull them or
+// serve them, so that they are only held by the block builders.
+func (vm *VM) initEnvelopeGossip(ctx context.Context, pool *encryptedpool.EncryptedPool) error {
+	marshaller := GossipEnvelopeMarshaller{}
+	client := vm.Network.NewClient(envelopeGossipHandlerID)
+	metrics, err := avalanchegossip.NewMetrics(vm.sdkMetrics, envelopeGossipNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to initialize envelope gossip metrics: %w", err)
+	}
+	envelopePool, err := NewGossipEnvelopePool(pool, vm.clock, vm.sdkMetrics)
+	if err != nil {
+		return fmt.Errorf("failed to initialize gossip envelope pool: %w", err)
+	}
+
+	pushGossiper, err := avalanchegossip.NewPushGossiper[*GossipEnvelope](
+		marshaller,
+		envelopePool,
+		vm.P2PValidators(),
+		client,
+		metrics,
+		avalanchegossip.BranchingFactor{
+			StakePercentage: vm.config.PushGossipPercentStake,
+			Validators:      vm.config.PushGossipNumValidators,
+		},
+		avalanchegossip.BranchingFactor{
+			Validators: vm.config.PushRegossipNumValidators,
+		},
+		config.PushGossipDiscardedElements,
+		config.TxGossipTargetMessageSize,
+		vm.config.RegossipFrequency.Duration,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize envelope push gossiper: %w", err)
+	}
+	vm.shutdownWg.Add(1)
+	go func() {
+		envelopePool.Subscribe(ctx, pushGossiper)
+		vm.shutdownWg.Done()
+	}()
+
+	handler, err := gossip.NewTxGossipHandler[*GossipEnvelope](
+		vm.ctx.Log,
+		marshaller,
+		envelopePool,
+		metrics,
+		config.TxGossipTargetMessageSize,
+		config.TxGossipThrottlingPeriod,
+		config.TxGossipRequestsPerPeer,
+		vm.P2PValidators(),
+		vm.sdkMetrics,
+		envelopeGossipNamespace,
+		func(nodeID ids.NodeID) error {
+			return vm.txPool.AdmitOrigin("peer:" + nodeID.String())
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize envelope gossip handler: %w", err)
+	}
+	if err := vm.Network.AddHandler(envelopeGossipHandlerID, handler); err != nil {
+		return fmt.Errorf("failed to add envelope gossip handler: %w", err)
+	}
+
+	pullGossiper := avalanchegossip.ValidatorGossiper{
+		Gossiper: avalanchegossip.NewPullGossiper[*GossipEnvelope](
+			vm.ctx.Log,
+			marshaller,
+			envelopePool,
+			client,
+			metrics,
+			config.TxGossipPollSize,
+		),
+		NodeID:     vm.ctx.NodeID,
+		Validators: vm.P2PValidators(),
+	}
+
+	vm.shutdownWg.Add(1)
+	go func() {
+		avalanchegossip.Every(ctx, vm.ctx.Log, pushGossiper, vm.config.PushGossipFrequency.Duration)
+		vm.shutdownWg.Done()
+	}()
+	vm.shutdownWg.Add(1)
+	go func() {
+		avalanchegossip.Every(ctx, vm.ctx.Log, pullGossiper, vm.config.PullGossipFrequency.Duration)
+		vm.shutdownWg.Done()
+	}()
 	return nil
 }
 
@@ -986,6 +1139,11 @@ func (vm *VM) Shutdown(context.Context) error {
 	if err := vm.Client.Shutdown(); err != nil {
 		log.Error("error stopping state syncer", "err", err)
 	}
+	if vm.stateSyncArchive != nil {
+		if err := vm.stateSyncArchive.Close(); err != nil {
+			log.Error("error closing state sync archive", "err", err)
+		}
+	}
 	close(vm.shutdownChan)
 	// Stop RPC handlers before eth.Stop which will close the database
 	for _, handler := range vm.rpcHandlers {
@@ -1033,6 +1191,9 @@ func (vm *VM) buildBlockWithContext(_ context.Context, proposerVMBlockCtx *block
 	if err != nil {
 		return nil, fmt.Errorf("failed to wrap built block: %w", err)
 	}
+	// Collect the blob sidecars while the transaction pool still holds them,
+	// so that they are sent along with the block.
+	blk.blobSidecars = vm.availableBlobSidecars(block, nil)
 
 	// Verify is called on a non-wrapped block here, such that this
 	// does not add [blk] to the processing blocks map in ChainState.
@@ -1065,11 +1226,16 @@ func (vm *VM) parseBlock(_ context.Context, b []byte) (snowman.Block, error) {
 		return nil, err
 	}
 
+	// The sidecars sent along with the blob transactions are kept apart from
+	// the block, whose transactions root does not cover them.
+	ethBlock, sidecars := withoutBlobSidecars(ethBlock)
+
 	// Note: the status of block is set by ChainState
 	block, err := wrapBlock(ethBlock, vm)
 	if err != nil {
 		return nil, err
 	}
+	block.blobSidecars = sidecars
 	// Performing syntactic verification in ParseBlock allows for
 	// short-circuiting bad blocks before they are processed by the VM.
 	if err := block.syntacticVerify(); err != nil {
@@ -1207,6 +1373,15 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 		enabledAPIs = append(enabledAPIs, "validators")
 	}
 
+	if vm.config.StateSyncAPIEnabled {
+		stateSyncAPI, err := newHandler("statesync", &StateSyncAPI{vm})
+		if err != nil {
+			return nil, fmt.Errorf("failed to register service for state sync API due to %w", err)
+		}
+		apis[stateSyncEndpoint] = stateSyncAPI
+		enabledAPIs = append(enabledAPIs, "statesync")
+	}
+
 	if vm.config.WarpAPIEnabled {
 		warpSDKClient := vm.Network.NewClient(p2p.SignatureRequestHandlerID)
 		signatureAggregator := acp118.NewSignatureAggregator(vm.ctx.Log, warpSDKClient)
@@ -2059,6 +2059,7 @@ func TestFeeManagerChangeFee(t *testing.T) {
 	require.NoError(t, err)
 	tvm := newVM(t, testVMConfig{
 		genesisJSON: string(genesisJSON),
+		configJSON:  `{"fee-config-history-enabled": true}`,
 	})
 
 	defer func() {
@@ -2117,6 +2118,18 @@ func TestFeeManagerChangeFee(t *testing.T) {
 	require.Equal(t, testHighFeeConfig, feeConfig)
 	require.Equal(t, tvm.vm.blockChain.CurrentBlock().Number, lastChangedAt)
 
+	// The change should be indexed in the fee config history
+	tvm.vm.blockChain.DrainAcceptorQueue()
+	history, err := tvm.vm.blockChain.GetFeeConfigHistory(0, block.NumberU64())
+	require.NoError(t, err)
+	require.Equal(t, []customrawdb.FeeConfigChange{{
+		BlockNumber:  block.NumberU64(),
+		TxHash:       signedTx.Hash(),
+		Sender:       testEthAddrs[0],
+		OldFeeConfig: testLowFeeConfig,
+		NewFeeConfig: testHighFeeConfig,
+	}}, history)
+
 	// should fail, with same params since fee is higher now
 	tx2 := types.NewTx(&types.DynamicFeeTx{
 		ChainID:   genesis.Config.ChainID,
@@ -2873,6 +2886,51 @@ func TestFeeManagerRegressionMempoolMinFeeAfterRestart(t *testing.T) {
 	require.Equal(t, newHead.Head.Hash(), common.Hash(blk.ID()))
 }
 
+func TestTxPoolPersistence(t *testing.T) {
+	require := require.New(t)
+	genesisJSON := toGenesisJSON(paramstest.ForkToChainConfig[upgradetest.Latest])
+	tvmConfig := testVMConfig{
+		genesisJSON: genesisJSON,
+		configJSON:  `{"tx-pool-persist": true}`,
+	}
+	tvm := newVM(t, tvmConfig)
+
+	signer := types.LatestSigner(tvm.vm.chainConfig)
+	var txs []*types.Transaction
+	// The transaction with nonce 2 is queued behind the missing nonce 1.
+	for _, nonce := range []uint64{0, 2} {
+		tx := types.NewTransaction(nonce, testEthAddrs[1], big.NewInt(10), 21000, big.NewInt(testMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, testKeys[0].ToECDSA())
+		require.NoError(err)
+		txs = append(txs, signedTx)
+	}
+	for _, err := range tvm.vm.txPool.AddRemotesSync(txs) {
+		require.NoError(err)
+	}
+	pending, queued := tvm.vm.txPool.Stats()
+	require.Equal(1, pending)
+	require.Equal(1, queued)
+
+	restartedTVM, err := restartVM(tvm, tvmConfig)
+	require.NoError(err)
+	for _, tx := range txs {
+		require.True(restartedTVM.vm.txPool.Has(tx.Hash()))
+	}
+	pending, queued = restartedTVM.vm.txPool.Stats()
+	require.Equal(1, pending)
+	require.Equal(1, queued)
+
+	// Without persistence, the pool starts empty.
+	restartedTVM, err = restartVM(restartedTVM, testVMConfig{genesisJSON: genesisJSON})
+	require.NoError(err)
+	defer func() {
+		require.NoError(restartedTVM.vm.Shutdown(context.Background()))
+	}()
+	pending, queued = restartedTVM.vm.txPool.Stats()
+	require.Zero(pending)
+	require.Zero(queued)
+}
+
 func restartVM(tvm *testVM, tvmConfig testVMConfig) (*testVM, error) {
 	if err := tvm.vm.Shutdown(context.Background()); err != nil {
 		return nil, err
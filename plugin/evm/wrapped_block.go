@@ -25,6 +25,7 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customheader"
+	"github.com/ava-labs/subnet-evm/plugin/evm/customrawdb"
 	"github.com/ava-labs/subnet-evm/plugin/evm/customtypes"
 	"github.com/ava-labs/subnet-evm/plugin/evm/extension"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -59,6 +60,13 @@ type wrappedBlock struct {
 	ethBlock  *types.Block
 	extension extension.BlockExtension
 	vm        *VM
+
+	// blobSidecars are the sidecars of the blob transactions of the block, sent
+	// along with the block or taken from the transaction pool. They are not part
+	// of the block ID, so they are checked against the versioned hashes when the
+	// block is verified without affecting its validity, and stored once it is
+	// accepted.
+	blobSidecars []customrawdb.BlobSidecar
 }
 
 // wrapBlock returns a new Block wrapping the ethBlock type and implementing the snowman.Block interface
@@ -104,10 +112,22 @@ func (b *wrappedBlock) Accept(context.Context) error {
 	if err := vm.blockChain.Accept(b.ethBlock); err != nil {
 		return fmt.Errorf("chain could not accept %s: %w", blkID, err)
 	}
+	if err := vm.writeBlobSidecars(b.ethBlock, b.blobSidecars); err != nil {
+		return fmt.Errorf("failed to write blob sidecars of %s: %w", blkID, err)
+	}
 
 	if err := vm.PutLastAcceptedID(blkID); err != nil {
 		return fmt.Errorf("failed to put %s as the last accepted block: %w", blkID, err)
 	}
+	// The envelopes of the block are decrypted now, so the next block can be
+	// built on top of it.
+	if vm.eth.EncryptedPool() != nil && core.HasEnvelopes(b.ethBlock) {
+		vm.builderLock.Lock()
+		if vm.builder != nil {
+			vm.builder.signalCanBuild()
+		}
+		vm.builderLock.Unlock()
+	}
 
 	// No block extension batching path in subnet-evm; commit versioned DB directly
 	return b.vm.versiondb.Commit()
@@ -227,6 +247,8 @@ func (b *wrappedBlock) verify(predicateContext *precompileconfig.PredicateContex
 		return fmt.Errorf("failed to verify block: %w", err)
 	}
 
+	b.blobSidecars = b.vm.availableBlobSidecars(b.ethBlock, b.blobSidecars)
+
 	// The engine may call VerifyWithContext multiple times on the same block with different contexts.
 	if b.vm.State.IsProcessing(b.id) {
 		return nil
@@ -411,12 +433,10 @@ func (b *wrappedBlock) syntacticVerify() error {
 			return fmt.Errorf("%w: have %x, expected empty hash", errParentBeaconRootNonEmpty, ethHeader.ParentBeaconRoot)
 		case ethHeader.BlobGasUsed == nil:
 			return errBlobGasUsedNilInCancun
-		case *ethHeader.BlobGasUsed != 0:
+		case *ethHeader.BlobGasUsed != 0 && !params.GetExtra(b.vm.chainConfig).IsBlobEnabled(ethHeader.Time):
 			return fmt.Errorf("%w: used %d blob gas, expected 0", errBlobsNotEnabled, *ethHeader.BlobGasUsed)
 		case ethHeader.ExcessBlobGas == nil:
 			return fmt.Errorf("%w: have nil, expected 0", errInvalidExcessBlobGas)
-		case *ethHeader.ExcessBlobGas != 0:
-			return fmt.Errorf("%w: have %d, expected 0", errInvalidExcessBlobGas, *ethHeader.ExcessBlobGas)
 		}
 	} else {
 		switch {
@@ -470,8 +490,17 @@ func (b *wrappedBlock) verifyPredicates(predicateContext *precompileconfig.Predi
 }
 
 // Bytes implements the snowman.Block interface
+// The blob transactions carry their sidecars, if known.
 func (b *wrappedBlock) Bytes() []byte {
-	res, err := rlp.EncodeToBytes(b.ethBlock)
+	sidecars := b.blobSidecars
+	if sidecars == nil && hasBlobTxs(b.ethBlock) {
+		var err error
+		sidecars, _, err = customrawdb.ReadBlobSidecars(b.vm.chaindb, b.ethBlock.NumberU64(), b.ethBlock.Hash())
+		if err != nil {
+			log.Warn("Failed to read blob sidecars", "block", b.ID(), "err", err)
+		}
+	}
+	res, err := rlp.EncodeToBytes(withBlobSidecars(b.ethBlock, sidecars))
 	if err != nil {
 		panic(err)
 	}
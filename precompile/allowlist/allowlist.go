@@ -26,6 +26,9 @@ const (
 	ReadAllowListGasCost   = contract.ReadGasCostPerSlot
 
 	allowListInputLen = common.HashLength
+
+	// ReadAllowListQueryName is the name of the query returning the allow list role of an address.
+	ReadAllowListQueryName = "readAllowList"
 )
 
 var (
@@ -47,6 +50,14 @@ func GetAllowListStatus(state contract.StateReader, precompileAddr common.Addres
 	return Role(state.GetState(precompileAddr, addressKey))
 }
 
+// NewReadAllowListQuery returns the query other precompiles use to read the allow list role
+// of an address for the precompile at [precompileAddr].
+func NewReadAllowListQuery(precompileAddr common.Address) *contract.TypedQuery[common.Address, Role] {
+	return contract.NewTypedQuery(precompileAddr, ReadAllowListQueryName, ReadAllowListGasCost, func(state contract.StateReader, address common.Address) (Role, error) {
+		return GetAllowListStatus(state, precompileAddr, address), nil
+	})
+}
+
 // SetAllowListRole sets the permissions of [address] to [role] for the precompile
 // at [precompileAddr].
 // assumes [role] has already been verified as valid.
@@ -47,6 +47,17 @@ func (r Role) IsAdmin() bool {
 	}
 }
 
+// IsManager returns true if [r] indicates the permission to manage enabled
+// addresses of the allow list. Admins have all the permissions of managers.
+func (r Role) IsManager() bool {
+	switch r {
+	case AdminRole, ManagerRole:
+		return true
+	default:
+		return false
+	}
+}
+
 // IsEnabled returns true if [r] indicates that it has permission to access the resource.
 func (r Role) IsEnabled() bool {
 	switch r {
@@ -67,6 +67,35 @@ func TestIsEnabled(t *testing.T) {
 	}
 }
 
+func TestIsManager(t *testing.T) {
+	tests := []struct {
+		role     Role
+		expected bool
+	}{
+		{
+			role:     ManagerRole,
+			expected: true,
+		},
+		{
+			role:     AdminRole,
+			expected: true,
+		},
+		{
+			role:     EnabledRole,
+			expected: false,
+		},
+		{
+			role:     NoRole,
+			expected: false,
+		},
+	}
+
+	for index, test := range tests {
+		isManager := test.role.IsManager()
+		require.Equal(t, test.expected, isManager, "test index: %d", index)
+	}
+}
+
 func TestCanModify(t *testing.T) {
 	tests := []struct {
 		role     Role
@@ -34,6 +34,8 @@ type StatefulPrecompileFunction struct {
 	execute RunStatefulPrecompileFunc
 	// activation is checked before this function is executed
 	activation ActivationFunc
+	// metrics are recorded for each call once registered by [RegisterMetrics]
+	metrics *functionMetrics
 }
 
 func (f *StatefulPrecompileFunction) IsActivated(accessibleState AccessibleState) bool {
@@ -63,8 +65,9 @@ func NewStatefulPrecompileFunctionWithActivator(selector []byte, execute RunStat
 // off responsibilities to internal execution functions.
 // Note: because we only ever read from [functions] there no lock is required to make it thread-safe.
 type statefulPrecompileWithFunctionSelectors struct {
-	fallback  RunStatefulPrecompileFunc
-	functions map[string]*StatefulPrecompileFunction
+	fallback        RunStatefulPrecompileFunc
+	fallbackMetrics *functionMetrics
+	functions       map[string]*StatefulPrecompileFunction
 }
 
 // NewStatefulPrecompileContract generates new StatefulPrecompile using [functions] as the available functions and [fallback]
@@ -91,7 +94,7 @@ func NewStatefulPrecompileContract(fallback RunStatefulPrecompileFunc, functions
 func (s *statefulPrecompileWithFunctionSelectors) Run(accessibleState AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
 	// If there is no input data present, call the fallback function if present.
 	if len(input) == 0 && s.fallback != nil {
-		return s.fallback(accessibleState, caller, addr, nil, suppliedGas, readOnly)
+		return runWithMetrics(s.fallbackMetrics, s.fallback, accessibleState, caller, addr, nil, suppliedGas, readOnly)
 	}
 
 	// Otherwise, an unexpected input size will result in an error.
@@ -112,5 +115,5 @@ func (s *statefulPrecompileWithFunctionSelectors) Run(accessibleState Accessible
 		return nil, suppliedGas, fmt.Errorf("%w: %#x", ErrInvalidNonActivatedFunctionSelector, selector)
 	}
 
-	return function.execute(accessibleState, caller, addr, functionInput, suppliedGas, readOnly)
+	return runWithMetrics(function.metrics, function.execute, accessibleState, caller, addr, functionInput, suppliedGas, readOnly)
 }
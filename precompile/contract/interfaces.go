@@ -39,6 +39,7 @@ type StateDB interface {
 
 	GetBalance(common.Address) *uint256.Int
 	AddBalance(common.Address, *uint256.Int)
+	SubBalance(common.Address, *uint256.Int)
 
 	CreateAccount(common.Address)
 	Exist(common.Address) bool
@@ -58,6 +59,11 @@ type AccessibleState interface {
 	GetBlockContext() BlockContext
 	GetSnowContext() *snow.Context
 	GetRules() precompileconfig.Rules
+	GetChainConfig() precompileconfig.ChainConfig
+	// QueryPrecompile runs the read-only query [name] of the active precompile at [addr]
+	// with [input], deducting the gas cost of the query from [suppliedGas].
+	// Precompiles should call queries through the [TypedQuery] exposed by the queried precompile.
+	QueryPrecompile(addr common.Address, name string, input any, suppliedGas uint64) (output any, remainingGas uint64, err error)
 }
 
 // ConfigurationBlockContext defines the interface required to configure a precompile.
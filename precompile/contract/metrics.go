@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/metrics"
+)
+
+const (
+	// precompileMetricsPrefix is the prefix of the per-function precompile metrics.
+	// Metrics are named precompile/{address}/{selector}/{metric}.
+	precompileMetricsPrefix = "precompile"
+	// fallbackSelectorName replaces the selector in the metric names of the fallback function.
+	fallbackSelectorName = "fallback"
+)
+
+// functionMetrics are the metrics recorded for a single function of a stateful precompile.
+type functionMetrics struct {
+	calls    metrics.Counter
+	gasUsed  metrics.Counter
+	reverts  metrics.Counter
+	duration metrics.Timer
+}
+
+func newFunctionMetrics(addr common.Address, selector []byte) *functionMetrics {
+	name := fallbackSelectorName
+	if selector != nil {
+		name = common.Bytes2Hex(selector)
+	}
+	prefix := fmt.Sprintf("%s/%s/%s/", precompileMetricsPrefix, strings.ToLower(addr.Hex()), name)
+	return &functionMetrics{
+		calls:    metrics.GetOrRegisterCounter(prefix+"calls", nil),
+		gasUsed:  metrics.GetOrRegisterCounter(prefix+"gas_used", nil),
+		reverts:  metrics.GetOrRegisterCounter(prefix+"reverts", nil),
+		duration: metrics.GetOrRegisterTimer(prefix+"duration", nil),
+	}
+}
+
+// RegisterMetrics registers the per-function metrics of [precompile] for the address [addr] it is
+// accessible at, so that calls do not need to look them up. It must be called before the
+// precompile is run, which is the case when it is called while registering the precompile module.
+// Contracts that are not created with [NewStatefulPrecompileContract] record no metrics.
+func RegisterMetrics(addr common.Address, precompile StatefulPrecompiledContract) {
+	contract, ok := precompile.(*statefulPrecompileWithFunctionSelectors)
+	if !ok {
+		return
+	}
+	if contract.fallback != nil {
+		contract.fallbackMetrics = newFunctionMetrics(addr, nil)
+	}
+	for _, function := range contract.functions {
+		function.metrics = newFunctionMetrics(addr, function.selector)
+	}
+}
+
+// runWithMetrics executes [run] and, if expensive metrics are enabled and [m] is registered,
+// records the call count, gas used, revert count and latency of the function in [m].
+func runWithMetrics(m *functionMetrics, run RunStatefulPrecompileFunc, accessibleState AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) ([]byte, uint64, error) {
+	if m == nil || !metrics.EnabledExpensive {
+		return run(accessibleState, caller, addr, input, suppliedGas, readOnly)
+	}
+
+	start := time.Now()
+	ret, remainingGas, err := run(accessibleState, caller, addr, input, suppliedGas, readOnly)
+	elapsed := time.Since(start)
+
+	m.calls.Inc(1)
+	if remainingGas <= suppliedGas {
+		m.gasUsed.Inc(int64(suppliedGas - remainingGas))
+	}
+	if err != nil {
+		m.reverts.Inc(1)
+	}
+	m.duration.Update(elapsed)
+	return ret, remainingGas, err
+}
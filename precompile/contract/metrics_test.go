@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMetrics(t *testing.T) {
+	require := require.New(t)
+
+	enabled, enabledExpensive := metrics.Enabled, metrics.EnabledExpensive
+	metrics.Enabled, metrics.EnabledExpensive = true, true
+	t.Cleanup(func() {
+		metrics.Enabled, metrics.EnabledExpensive = enabled, enabledExpensive
+	})
+
+	errFailed := errors.New("failed")
+	execute := func(_ AccessibleState, _ common.Address, _ common.Address, input []byte, suppliedGas uint64, _ bool) ([]byte, uint64, error) {
+		if len(input) > 0 {
+			return nil, suppliedGas - 10, errFailed
+		}
+		return nil, suppliedGas - 100, nil
+	}
+	fallback := func(_ AccessibleState, _ common.Address, _ common.Address, _ []byte, suppliedGas uint64, _ bool) ([]byte, uint64, error) {
+		return nil, suppliedGas - 1, nil
+	}
+	selector := []byte{0x01, 0x02, 0x03, 0x04}
+	precompile, err := NewStatefulPrecompileContract(fallback, []*StatefulPrecompileFunction{
+		NewStatefulPrecompileFunction(selector, execute),
+	})
+	require.NoError(err)
+
+	addr := common.HexToAddress("0x03000000000000000000000000000000000000AB")
+	RegisterMetrics(addr, precompile)
+	_, _, err = precompile.Run(nil, common.Address{}, addr, selector, 1000, false)
+	require.NoError(err)
+	_, _, err = precompile.Run(nil, common.Address{}, addr, selector, 1000, false)
+	require.NoError(err)
+	_, _, err = precompile.Run(nil, common.Address{}, addr, append(selector, 0x00), 1000, false)
+	require.ErrorIs(err, errFailed)
+	_, _, err = precompile.Run(nil, common.Address{}, addr, nil, 1000, false)
+	require.NoError(err)
+
+	prefix := "precompile/0x03000000000000000000000000000000000000ab/01020304/"
+	require.Equal(int64(3), metrics.GetOrRegisterCounter(prefix+"calls", nil).Snapshot().Count())
+	require.Equal(int64(210), metrics.GetOrRegisterCounter(prefix+"gas_used", nil).Snapshot().Count())
+	require.Equal(int64(1), metrics.GetOrRegisterCounter(prefix+"reverts", nil).Snapshot().Count())
+	require.Equal(int64(3), metrics.GetOrRegisterTimer(prefix+"duration", nil).Snapshot().Count())
+
+	fallbackPrefix := "precompile/0x03000000000000000000000000000000000000ab/fallback/"
+	require.Equal(int64(1), metrics.GetOrRegisterCounter(fallbackPrefix+"calls", nil).Snapshot().Count())
+	require.Equal(int64(1), metrics.GetOrRegisterCounter(fallbackPrefix+"gas_used", nil).Snapshot().Count())
+	require.Zero(metrics.GetOrRegisterCounter(fallbackPrefix+"reverts", nil).Snapshot().Count())
+}
+
+func TestRunWithoutRegisteredMetrics(t *testing.T) {
+	require := require.New(t)
+
+	enabled, enabledExpensive := metrics.Enabled, metrics.EnabledExpensive
+	metrics.Enabled, metrics.EnabledExpensive = true, true
+	t.Cleanup(func() {
+		metrics.Enabled, metrics.EnabledExpensive = enabled, enabledExpensive
+	})
+
+	selector := []byte{0x05, 0x06, 0x07, 0x08}
+	execute := func(_ AccessibleState, _ common.Address, _ common.Address, _ []byte, suppliedGas uint64, _ bool) ([]byte, uint64, error) {
+		return nil, suppliedGas - 100, nil
+	}
+	precompile, err := NewStatefulPrecompileContract(nil, []*StatefulPrecompileFunction{
+		NewStatefulPrecompileFunction(selector, execute),
+	})
+	require.NoError(err)
+
+	addr := common.HexToAddress("0x03000000000000000000000000000000000000AC")
+	_, _, err = precompile.Run(nil, common.Address{}, addr, selector, 1000, false)
+	require.NoError(err)
+
+	prefix := "precompile/0x03000000000000000000000000000000000000ac/05060708/"
+	require.Nil(metrics.DefaultRegistry.Get(prefix + "calls"))
+}
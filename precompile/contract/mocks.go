@@ -128,6 +128,20 @@ func (mr *MockAccessibleStateMockRecorder) GetBlockContext() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockContext", reflect.TypeOf((*MockAccessibleState)(nil).GetBlockContext))
 }
 
+// GetChainConfig mocks base method.
+func (m *MockAccessibleState) GetChainConfig() precompileconfig.ChainConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainConfig")
+	ret0, _ := ret[0].(precompileconfig.ChainConfig)
+	return ret0
+}
+
+// GetChainConfig indicates an expected call of GetChainConfig.
+func (mr *MockAccessibleStateMockRecorder) GetChainConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainConfig", reflect.TypeOf((*MockAccessibleState)(nil).GetChainConfig))
+}
+
 // GetRules mocks base method.
 func (m *MockAccessibleState) GetRules() precompileconfig.Rules {
 	m.ctrl.T.Helper()
@@ -170,6 +184,22 @@ func (mr *MockAccessibleStateMockRecorder) GetStateDB() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateDB", reflect.TypeOf((*MockAccessibleState)(nil).GetStateDB))
 }
 
+// QueryPrecompile mocks base method.
+func (m *MockAccessibleState) QueryPrecompile(addr common.Address, name string, input any, suppliedGas uint64) (any, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryPrecompile", addr, name, input, suppliedGas)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QueryPrecompile indicates an expected call of QueryPrecompile.
+func (mr *MockAccessibleStateMockRecorder) QueryPrecompile(addr, name, input, suppliedGas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryPrecompile", reflect.TypeOf((*MockAccessibleState)(nil).QueryPrecompile), addr, name, input, suppliedGas)
+}
+
 // MockStateDB is a mock of StateDB interface.
 type MockStateDB struct {
 	ctrl     *gomock.Controller
@@ -361,6 +391,18 @@ func (mr *MockStateDBMockRecorder) Snapshot() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockStateDB)(nil).Snapshot))
 }
 
+// SubBalance mocks base method.
+func (m *MockStateDB) SubBalance(arg0 common.Address, arg1 *uint256.Int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SubBalance", arg0, arg1)
+}
+
+// SubBalance indicates an expected call of SubBalance.
+func (mr *MockStateDBMockRecorder) SubBalance(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubBalance", reflect.TypeOf((*MockStateDB)(nil).SubBalance), arg0, arg1)
+}
+
 // TxHash mocks base method.
 func (m *MockStateDB) TxHash() common.Hash {
 	m.ctrl.T.Helper()
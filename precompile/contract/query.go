@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/libevm/common"
+)
+
+var (
+	ErrPrecompileNotActive = errors.New("precompile is not active")
+	ErrQueryNotFound       = errors.New("precompile query not found")
+
+	errInvalidQueryInput  = errors.New("invalid precompile query input")
+	errInvalidQueryOutput = errors.New("invalid precompile query output")
+)
+
+// QueryFunc is a read-only view of the state of a precompile.
+type QueryFunc func(state StateReader, input any) (output any, err error)
+
+// Query is a read-only view of the state of a precompile that can be called by other
+// precompiles through [AccessibleState.QueryPrecompile]. Queries are registered with the
+// precompile module and are only available while the precompile is active.
+type Query struct {
+	// Name identifies the query within the precompile.
+	Name string
+	// GasCost is deducted from the gas supplied by the calling precompile before the query runs.
+	GasCost uint64
+	// Run executes the query.
+	Run QueryFunc
+}
+
+// Execute deducts [GasCost] from [suppliedGas] and runs the query on [state].
+// It is used by implementations of [AccessibleState.QueryPrecompile].
+func (q Query) Execute(state StateReader, input any, suppliedGas uint64) (output any, remainingGas uint64, err error) {
+	if remainingGas, err = DeductGas(suppliedGas, q.GasCost); err != nil {
+		return nil, 0, err
+	}
+	output, err = q.Run(state, input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// TypedQuery is a typed handle of a [Query] of the precompile at a given address.
+// Precompiles expose their queries as TypedQuery values so that callers do not need to
+// know the storage layout of the precompile they read from.
+type TypedQuery[In, Out any] struct {
+	address common.Address
+	query   Query
+}
+
+// NewTypedQuery returns a query named [name] of the precompile at [address] that costs [gasCost]
+// and is executed by [run].
+func NewTypedQuery[In, Out any](address common.Address, name string, gasCost uint64, run func(state StateReader, input In) (Out, error)) *TypedQuery[In, Out] {
+	return &TypedQuery[In, Out]{
+		address: address,
+		query: Query{
+			Name:    name,
+			GasCost: gasCost,
+			Run: func(state StateReader, input any) (any, error) {
+				typedInput, ok := input.(In)
+				if !ok {
+					return nil, fmt.Errorf("%w: expected %T, got %T", errInvalidQueryInput, typedInput, input)
+				}
+				return run(state, typedInput)
+			},
+		},
+	}
+}
+
+// Address returns the address of the precompile the query reads from.
+func (q *TypedQuery[In, Out]) Address() common.Address {
+	return q.address
+}
+
+// Query returns the untyped query to be registered with the precompile module.
+func (q *TypedQuery[In, Out]) Query() Query {
+	return q.query
+}
+
+// Call runs the query with [input] through [accessibleState], deducting its gas cost from [suppliedGas].
+func (q *TypedQuery[In, Out]) Call(accessibleState AccessibleState, input In, suppliedGas uint64) (output Out, remainingGas uint64, err error) {
+	result, remainingGas, err := accessibleState.QueryPrecompile(q.address, q.query.Name, input, suppliedGas)
+	if err != nil {
+		return output, remainingGas, err
+	}
+	output, ok := result.(Out)
+	if !ok {
+		return output, remainingGas, fmt.Errorf("%w: expected %T, got %T", errInvalidQueryOutput, output, result)
+	}
+	return output, remainingGas, nil
+}
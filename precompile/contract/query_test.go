@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTypedQuery(t *testing.T) {
+	addr := common.HexToAddress("0x0300000000000000000000000000000000000001")
+	key := common.Hash{1}
+	query := NewTypedQuery(addr, "get", 100, func(state StateReader, key common.Hash) (common.Hash, error) {
+		return state.GetState(addr, key), nil
+	})
+	require.Equal(t, addr, query.Address())
+	require.Equal(t, "get", query.Query().Name)
+
+	ctrl := gomock.NewController(t)
+	state := NewMockStateDB(ctrl)
+	state.EXPECT().GetState(addr, key).Return(common.Hash{2}).AnyTimes()
+	accessibleState := NewMockAccessibleState(ctrl)
+	accessibleState.EXPECT().QueryPrecompile(addr, "get", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ common.Address, _ string, input any, suppliedGas uint64) (any, uint64, error) {
+			return query.Query().Execute(state, input, suppliedGas)
+		},
+	).AnyTimes()
+
+	output, remainingGas, err := query.Call(accessibleState, key, 150)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{2}, output)
+	require.Equal(t, uint64(50), remainingGas)
+
+	_, remainingGas, err = query.Call(accessibleState, key, 99)
+	require.ErrorIs(t, err, vm.ErrOutOfGas)
+	require.Zero(t, remainingGas)
+
+	// The untyped query rejects inputs of the wrong type.
+	_, _, err = query.Query().Execute(state, "key", 150)
+	require.ErrorIs(t, err, errInvalidQueryInput)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/libevm/common"
 
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -21,11 +22,15 @@ const ConfigKey = "contractDeployerAllowListConfig"
 
 var ContractAddress = common.HexToAddress("0x0200000000000000000000000000000000000000")
 
+// ReadAllowListQuery reads the allow list role of an address from other precompiles.
+var ReadAllowListQuery = allowlist.NewReadAllowListQuery(ContractAddress)
+
 var Module = modules.Module{
 	ConfigKey:    ConfigKey,
 	Address:      ContractAddress,
 	Contract:     ContractDeployerAllowListPrecompile,
 	Configurator: &configurator{},
+	Queries:      []contract.Query{ReadAllowListQuery.Query()},
 }
 
 type configurator struct{}
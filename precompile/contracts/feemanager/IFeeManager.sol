@@ -14,6 +14,12 @@ interface IFeeManager is IAllowList {
     uint256 blockGasCostStep;
   }
   event FeeConfigChanged(address indexed sender, FeeConfig oldFeeConfig, FeeConfig newFeeConfig);
+  event FeeDiscountChanged(
+    address indexed sender,
+    address indexed addr,
+    uint256 oldFeePercentage,
+    uint256 newFeePercentage
+  );
 
   // Set fee config fields to contract storage
   function setFeeConfig(
@@ -44,4 +50,11 @@ interface IFeeManager is IAllowList {
 
   // Get the last block number changed the fee config from the contract storage
   function getFeeConfigLastChangedAt() external view returns (uint256 blockNumber);
+
+  // Set the percentage of the base fee charged to transactions sent by addr (0 exempts addr, 100 removes the discount).
+  // Can only be called by admins. Available after the Helicon upgrade.
+  function setFeeDiscount(address addr, uint256 feePercentage) external;
+
+  // Get the percentage of the base fee charged to transactions sent by addr
+  function getFeeDiscount(address addr) external view returns (uint256 feePercentage);
 }
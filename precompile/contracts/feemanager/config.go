@@ -79,5 +79,8 @@ func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 		return nil
 	}
 
-	return c.InitialFeeConfig.Verify()
+	if err := c.InitialFeeConfig.Verify(); err != nil {
+		return err
+	}
+	return verifyElasticGasLimit(chainConfig.GetFeeConfig().ElasticGasLimit, c.InitialFeeConfig.GasLimit)
 }
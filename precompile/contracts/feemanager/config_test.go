@@ -35,6 +35,15 @@ func TestVerify(t *testing.T) {
 	admins := []common.Address{allowlisttest.TestAdminAddr}
 	invalidFeeConfig := validFeeConfig
 	invalidFeeConfig.GasLimit = big.NewInt(0)
+	elasticFeeConfig := commontype.ValidTestFeeConfig
+	elasticFeeConfig.ElasticGasLimit = &commontype.ElasticGasLimitConfig{
+		MinGasLimit:               validFeeConfig.GasLimit.Uint64() + 1,
+		MaxGasLimit:               2 * validFeeConfig.GasLimit.Uint64(),
+		GasLimitChangeDenominator: 1024,
+	}
+	elasticChainConfig := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+	elasticChainConfig.EXPECT().GetFeeConfig().AnyTimes().Return(elasticFeeConfig)
+	elasticChainConfig.EXPECT().IsDurango(gomock.Any()).AnyTimes().Return(true)
 	tests := map[string]precompiletest.ConfigVerifyTest{
 		"invalid initial fee manager config": {
 			Config:        feemanager.NewConfig(utils.NewUint64(3), admins, nil, nil, &invalidFeeConfig),
@@ -44,6 +53,11 @@ func TestVerify(t *testing.T) {
 			Config:        feemanager.NewConfig(utils.NewUint64(3), admins, nil, nil, &commontype.FeeConfig{}),
 			ExpectedError: commontype.ErrGasLimitNil,
 		},
+		"initial gas limit out of elastic gas limit bounds": {
+			Config:        feemanager.NewConfig(utils.NewUint64(3), admins, nil, nil, &validFeeConfig),
+			ChainConfig:   elasticChainConfig,
+			ExpectedError: feemanager.ErrGasLimitOutOfElasticBounds,
+		},
 	}
 	allowlisttest.VerifyPrecompileWithAllowListTests(t, feemanager.Module, tests)
 }
@@ -51,9 +51,12 @@ var (
 	feeConfigLastChangedAtKey = common.Hash{'l', 'c', 'a'}
 
 	ErrCannotChangeFee = errors.New("non-enabled cannot change fee config")
-	ErrInvalidLen      = errors.New("invalid input length for fee config Input")
-	ErrUnpackInput     = errors.New("failed to unpack input")
-	ErrUnpackOutput    = errors.New("failed to unpack output")
+
+	ErrGasLimitOutOfElasticBounds = errors.New("gasLimit out of the elastic gas limit bounds")
+
+	ErrInvalidLen   = errors.New("invalid input length for fee config Input")
+	ErrUnpackInput  = errors.New("failed to unpack input")
+	ErrUnpackOutput = errors.New("failed to unpack output")
 
 	// IFeeManagerRawABI contains the raw ABI of FeeManager contract.
 	//go:embed contract.abi
@@ -120,6 +123,31 @@ func GetFeeConfigLastChangedAt(stateDB contract.StateReader) *big.Int {
 	return val.Big()
 }
 
+// GetFeeConfigQuery reads the stored fee config from other precompiles.
+var GetFeeConfigQuery = contract.NewTypedQuery(ContractAddress, "getFeeConfig", GetFeeConfigGasCost, func(state contract.StateReader, _ struct{}) (commontype.FeeConfig, error) {
+	return GetStoredFeeConfig(state), nil
+})
+
+// GetFeeConfigLastChangedAtQuery reads the block number the fee config was last changed at from other precompiles.
+var GetFeeConfigLastChangedAtQuery = contract.NewTypedQuery(ContractAddress, "getFeeConfigLastChangedAt", GetLastChangedAtGasCost, func(state contract.StateReader, _ struct{}) (*big.Int, error) {
+	return GetFeeConfigLastChangedAt(state), nil
+})
+
+// verifyElasticGasLimit checks that [gasLimit] is within the bounds of [elastic],
+// the elastic gas limit of the chain config, if any. While the elastic gas limit
+// is enabled, the stored gas limit only sets the gas limit of the first block
+// once Subnet-EVM activates, and the fee manager does not steer the gas limit of
+// the following blocks, which follows the utilization of the network.
+func verifyElasticGasLimit(elastic *commontype.ElasticGasLimitConfig, gasLimit *big.Int) error {
+	if elastic == nil {
+		return nil
+	}
+	if !gasLimit.IsUint64() || gasLimit.Uint64() < elastic.MinGasLimit || gasLimit.Uint64() > elastic.MaxGasLimit {
+		return fmt.Errorf("%w: gasLimit = %d, minGasLimit = %d, maxGasLimit = %d", ErrGasLimitOutOfElasticBounds, gasLimit, elastic.MinGasLimit, elastic.MaxGasLimit)
+	}
+	return nil
+}
+
 // StoreFeeConfig stores given [feeConfig] and block number in the [blockContext] to the [stateDB].
 // A validation on [feeConfig] is done before storing.
 func StoreFeeConfig(stateDB contract.StateDB, feeConfig commontype.FeeConfig, blockContext contract.ConfigurationBlockContext) error {
@@ -233,6 +261,10 @@ func setFeeConfig(accessibleState contract.AccessibleState, caller common.Addres
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeFee, caller)
 	}
 
+	if err := verifyElasticGasLimit(accessibleState.GetChainConfig().GetFeeConfig().ElasticGasLimit, feeConfig.GasLimit); err != nil {
+		return nil, remainingGas, err
+	}
+
 	if rules.IsDurangoActivated() {
 		if remainingGas, err = contract.DeductGas(remainingGas, FeeConfigChangedEventGasCost); err != nil {
 			return nil, 0, err
@@ -388,7 +420,12 @@ func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
 		"getFeeConfigLastChangedAt": getFeeConfigLastChangedAt,
 		"setFeeConfig":              setFeeConfig,
 	}
-	functions := make([]*contract.StatefulPrecompileFunction, 0, len(abiFunctionMap)+len(allowlist.AllowListABI.Methods))
+	// Fee discounts are only available after Helicon.
+	heliconFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"getFeeDiscount": getFeeDiscount,
+		"setFeeDiscount": setFeeDiscount,
+	}
+	functions := make([]*contract.StatefulPrecompileFunction, 0, len(abiFunctionMap)+len(heliconFunctionMap)+len(allowlist.AllowListABI.Methods))
 	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
 
 	for name, function := range abiFunctionMap {
@@ -398,6 +435,13 @@ func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
 		}
 		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
 	}
+	for name, function := range heliconFunctionMap {
+		method, ok := FeeManagerABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, feeDiscountsActivated))
+	}
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
 	if err != nil {
@@ -8,12 +8,15 @@ import (
 	"testing"
 
 	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/state"
 	"github.com/ava-labs/libevm/core/vm"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/core/extstate"
+	"github.com/ava-labs/subnet-evm/params/extras"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist/allowlisttest"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
@@ -46,8 +49,9 @@ var (
 		MaxBlockGasCost:  new(big.Int),
 		BlockGasCostStep: new(big.Int),
 	}
-	testBlockNumber = big.NewInt(7)
-	tests           = []precompiletest.PrecompileTest{
+	testBlockNumber  = big.NewInt(7)
+	testDiscountAddr = common.HexToAddress("0x0000000000000000000000000000000000000d15")
+	tests            = []precompiletest.PrecompileTest{
 		{
 			Name:       "set_config_from_no_role_fails",
 			Caller:     allowlisttest.TestNoRoleAddr,
@@ -318,6 +322,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
 				return config
 			},
 			SuppliedGas: feemanager.SetFeeConfigGasCost,
@@ -342,6 +347,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
 				return config
 			},
 			SuppliedGas: feemanager.SetFeeConfigGasCost + feemanager.FeeConfigChangedEventGasCost,
@@ -370,6 +376,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
 				return config
 			},
 			SuppliedGas: feemanager.SetFeeConfigGasCost,
@@ -388,6 +395,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
 				return config
 			},
 			SuppliedGas: feemanager.SetFeeConfigGasCost + feemanager.FeeConfigChangedEventGasCost,
@@ -414,6 +422,7 @@ var (
 			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
 				config := precompileconfig.NewMockChainConfig(ctrl)
 				config.EXPECT().IsDurango(gomock.Any()).Return(false).AnyTimes()
+				config.EXPECT().GetFeeConfig().Return(commontype.ValidTestFeeConfig).AnyTimes()
 				return config
 			},
 			InputFn: func(t testing.TB) []byte {
@@ -429,6 +438,140 @@ var (
 				require.Empty(t, logs)
 			},
 		},
+		{
+			Name:       "set_config_out_of_elastic_gas_limit_bounds_fails",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(feemanager.Module.Address),
+			ChainConfigFn: func(ctrl *gomock.Controller) precompileconfig.ChainConfig {
+				gasLimit := testFeeConfig.GasLimit.Uint64()
+				feeConfig := commontype.ValidTestFeeConfig
+				feeConfig.ElasticGasLimit = &commontype.ElasticGasLimitConfig{
+					MinGasLimit:               gasLimit + 1,
+					MaxGasLimit:               2 * gasLimit,
+					GasLimitChangeDenominator: 1024,
+				}
+				config := precompileconfig.NewMockChainConfig(ctrl)
+				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				config.EXPECT().GetFeeConfig().Return(feeConfig).AnyTimes()
+				return config
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := feemanager.PackSetFeeConfig(testFeeConfig)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: feemanager.SetFeeConfigGasCost,
+			ReadOnly:    false,
+			ExpectedErr: feemanager.ErrGasLimitOutOfElasticBounds,
+		},
+		{
+			Name:       "set_fee_discount_should_fail_before_Helicon",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(feemanager.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := feemanager.PackSetFeeDiscount(testDiscountAddr, 50)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: 0, // non-activated functions do not consume gas
+			ReadOnly:    false,
+			ExpectedErr: contract.ErrInvalidNonActivatedFunctionSelector,
+		},
+		{
+			Name:       "set_fee_discount_from_enabled_address_fails",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(feemanager.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := feemanager.PackSetFeeDiscount(testDiscountAddr, 50)
+				require.NoError(t, err)
+				return input
+			},
+			Rules:       extras.AvalancheRules{IsDurango: true, IsGranite: true, IsHelicon: true},
+			SuppliedGas: feemanager.SetFeeDiscountGasCost,
+			ReadOnly:    false,
+			ExpectedErr: feemanager.ErrCannotSetFeeDiscount,
+		},
+		{
+			Name:       "set_fee_discount_from_admin_succeeds_and_emits_logs",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(feemanager.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := feemanager.PackSetFeeDiscount(testDiscountAddr, 25)
+				require.NoError(t, err)
+				return input
+			},
+			Rules:       extras.AvalancheRules{IsDurango: true, IsGranite: true, IsHelicon: true},
+			SuppliedGas: feemanager.SetFeeDiscountGasCost + feemanager.FeeDiscountChangedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state *extstate.StateDB) {
+				require.Equal(t, uint64(25), feemanager.GetFeePercentage(state, testDiscountAddr))
+				require.Equal(t, feemanager.MaxFeePercentage, feemanager.GetFeePercentage(state, allowlisttest.TestAdminAddr))
+
+				logs := state.Logs()
+				require.Len(t, logs, 1)
+				require.Equal(t, []common.Hash{
+					feemanager.FeeManagerABI.Events["FeeDiscountChanged"].ID,
+					common.BytesToHash(allowlisttest.TestAdminAddr[:]),
+					common.BytesToHash(testDiscountAddr[:]),
+				}, logs[0].Topics)
+				oldFeePercentage, newFeePercentage, err := feemanager.UnpackFeeDiscountChangedEventData(logs[0].Data)
+				require.NoError(t, err)
+				require.Equal(t, big.NewInt(100), oldFeePercentage)
+				require.Equal(t, big.NewInt(25), newFeePercentage)
+			},
+		},
+		{
+			Name:       "set_fee_discount_above_max_fails",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(feemanager.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := feemanager.PackSetFeeDiscount(testDiscountAddr, feemanager.MaxFeePercentage+1)
+				require.NoError(t, err)
+				return input
+			},
+			Rules:       extras.AvalancheRules{IsDurango: true, IsGranite: true, IsHelicon: true},
+			SuppliedGas: feemanager.SetFeeDiscountGasCost,
+			ReadOnly:    false,
+			ExpectedErr: feemanager.ErrInvalidFeePercentage,
+		},
+		{
+			Name:       "set_fee_discount_readOnly_fails",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(feemanager.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := feemanager.PackSetFeeDiscount(testDiscountAddr, 0)
+				require.NoError(t, err)
+				return input
+			},
+			Rules:       extras.AvalancheRules{IsDurango: true, IsGranite: true, IsHelicon: true},
+			SuppliedGas: feemanager.SetFeeDiscountGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vm.ErrWriteProtection,
+		},
+		{
+			Name:   "get_fee_discount_from_no_role_succeeds",
+			Caller: allowlisttest.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(feemanager.Module.Address)(t, state)
+				feemanager.SetFeePercentage(state, testDiscountAddr, 0)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := feemanager.PackGetFeeDiscount(testDiscountAddr)
+				require.NoError(t, err)
+				return input
+			},
+			Rules:       extras.AvalancheRules{IsDurango: true, IsGranite: true, IsHelicon: true},
+			SuppliedGas: feemanager.GetFeeDiscountGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := feemanager.PackGetFeeDiscountOutput(0)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
 	}
 )
 
@@ -459,3 +602,59 @@ func assertFeeEvent(
 	require.True(t, expectedOldFeeConfig.Equal(&oldFeeConfig), "expected %v, got %v", expectedOldFeeConfig, oldFeeConfig)
 	require.True(t, expectedNewFeeConfig.Equal(&resFeeConfig), "expected %v, got %v", expectedNewFeeConfig, resFeeConfig)
 }
+
+func TestFeeManagerQueries(t *testing.T) {
+	require := require.New(t)
+
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+	stateDB := extstate.New(statedb)
+
+	ctrl := gomock.NewController(t)
+	blockContext := contract.NewMockBlockContext(ctrl)
+	blockContext.EXPECT().Number().Return(big.NewInt(7)).AnyTimes()
+	require.NoError(feemanager.StoreFeeConfig(stateDB, testFeeConfig, blockContext))
+
+	output, remainingGas, err := feemanager.GetFeeConfigQuery.Query().Execute(stateDB, struct{}{}, feemanager.GetFeeConfigGasCost+1)
+	require.NoError(err)
+	require.Equal(uint64(1), remainingGas)
+	feeConfig, ok := output.(commontype.FeeConfig)
+	require.True(ok)
+	require.True(testFeeConfig.Equal(&feeConfig))
+
+	output, _, err = feemanager.GetFeeConfigLastChangedAtQuery.Query().Execute(stateDB, struct{}{}, feemanager.GetLastChangedAtGasCost)
+	require.NoError(err)
+	require.Equal(big.NewInt(7), output)
+
+	_, _, err = feemanager.GetFeeConfigQuery.Query().Execute(stateDB, struct{}{}, feemanager.GetFeeConfigGasCost-1)
+	require.ErrorIs(err, vm.ErrOutOfGas)
+}
+
+func TestFeeDiscount(t *testing.T) {
+	require := require.New(t)
+
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(err)
+	stateDB := extstate.New(statedb)
+
+	// Addresses without a discount are charged the full base fee.
+	require.Equal(feemanager.MaxFeePercentage, feemanager.GetFeePercentage(stateDB, testDiscountAddr))
+	feemanager.SetFeePercentage(stateDB, testDiscountAddr, 0)
+	require.Zero(feemanager.GetFeePercentage(stateDB, testDiscountAddr))
+	feemanager.SetFeePercentage(stateDB, testDiscountAddr, 40)
+	require.Equal(uint64(40), feemanager.GetFeePercentage(stateDB, testDiscountAddr))
+
+	// The discount table does not overlap with the fee config or the allow list.
+	require.Equal(zeroFeeConfig, feemanager.GetStoredFeeConfig(stateDB))
+	require.True(feemanager.GetFeeManagerStatus(stateDB, testDiscountAddr).IsNoRole())
+
+	output, _, err := feemanager.GetFeeDiscountQuery.Query().Execute(stateDB, testDiscountAddr, feemanager.GetFeeDiscountGasCost)
+	require.NoError(err)
+	require.Equal(uint64(40), output)
+
+	baseFee := big.NewInt(1_000)
+	require.Equal(big.NewInt(400), feemanager.DiscountBaseFee(baseFee, 40))
+	require.Equal(big.NewInt(0), feemanager.DiscountBaseFee(baseFee, 0))
+	require.Same(baseFee, feemanager.DiscountBaseFee(baseFee, feemanager.MaxFeePercentage))
+	require.Nil(feemanager.DiscountBaseFee(nil, 40))
+}
@@ -81,3 +81,28 @@ func convertToCommonConfig(config changeFeeConfigEventData) commontype.FeeConfig
 		BlockGasCostStep:         config.BlockGasCostStep,
 	}
 }
+
+// FeeDiscountChangedEventGasCost is the gas cost of a FeeDiscountChanged event.
+// It is the gas cost of reading the old fee percentage + the base gas cost + the gas cost
+// of the topics (signature, sender, addr) and the gas cost of the non-indexed data
+// len(oldFeePercentage) + len(newFeePercentage).
+const FeeDiscountChangedEventGasCost = GetFeeDiscountGasCost + contract.LogGas + contract.LogTopicGas*3 + 2*common.HashLength*contract.LogDataGas
+
+// PackFeeDiscountChangedEvent packs the event into the appropriate arguments for FeeDiscountChanged.
+// It returns topic hashes and the encoded non-indexed data.
+func PackFeeDiscountChangedEvent(sender common.Address, address common.Address, oldFeePercentage uint64, newFeePercentage uint64) ([]common.Hash, []byte, error) {
+	return FeeManagerABI.PackEvent("FeeDiscountChanged", sender, address, new(big.Int).SetUint64(oldFeePercentage), new(big.Int).SetUint64(newFeePercentage))
+}
+
+// UnpackFeeDiscountChangedEventData attempts to unpack the non-indexed [dataBytes]
+// into the old and new fee percentages.
+func UnpackFeeDiscountChangedEventData(dataBytes []byte) (*big.Int, *big.Int, error) {
+	eventData := struct {
+		OldFeePercentage *big.Int
+		NewFeePercentage *big.Int
+	}{}
+	if err := FeeManagerABI.UnpackIntoInterface(&eventData, "FeeDiscountChanged", dataBytes); err != nil {
+		return nil, nil, err
+	}
+	return eventData.OldFeePercentage, eventData.NewFeePercentage, nil
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+const (
+	// MaxFeePercentage is the percentage of the base fee charged to addresses without a fee discount.
+	MaxFeePercentage uint64 = 100
+
+	SetFeeDiscountGasCost uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write 1 slot + read allow list
+	GetFeeDiscountGasCost uint64 = contract.ReadGasCostPerSlot
+)
+
+var (
+	// feeDiscountKeyPrefix prefixes the storage key of the fee discount of each address.
+	// The remaining bytes of the key hold the address, so the keys cannot collide with
+	// the fee config keys or the allow list keys.
+	feeDiscountKeyPrefix = []byte("fd")
+
+	ErrCannotSetFeeDiscount = errors.New("non-admin cannot set fee discount")
+	ErrInvalidFeePercentage = errors.New("invalid fee percentage")
+)
+
+// feeDiscountKey returns the storage key of the fee discount of [address].
+func feeDiscountKey(address common.Address) common.Hash {
+	var key common.Hash
+	copy(key[:], feeDiscountKeyPrefix)
+	copy(key[common.HashLength-common.AddressLength:], address[:])
+	return key
+}
+
+// GetFeePercentage returns the percentage of the base fee charged to transactions
+// sent by [address]. Addresses without a fee discount are charged [MaxFeePercentage].
+func GetFeePercentage(stateDB contract.StateReader, address common.Address) uint64 {
+	// The discount is stored rather than the percentage charged, so that addresses
+	// without a stored value are charged the full base fee.
+	discount := stateDB.GetState(ContractAddress, feeDiscountKey(address)).Big().Uint64()
+	return MaxFeePercentage - min(discount, MaxFeePercentage)
+}
+
+// SetFeePercentage sets the percentage of the base fee charged to transactions sent by [address].
+// Assumes [feePercentage] is at most [MaxFeePercentage].
+func SetFeePercentage(stateDB contract.StateDB, address common.Address, feePercentage uint64) {
+	discount := new(big.Int).SetUint64(MaxFeePercentage - feePercentage)
+	stateDB.SetState(ContractAddress, feeDiscountKey(address), common.BigToHash(discount))
+}
+
+// DiscountBaseFee returns the base fee charged to an address that is charged
+// [feePercentage] percent of [baseFee]. It returns [baseFee] itself if the
+// address has no discount.
+func DiscountBaseFee(baseFee *big.Int, feePercentage uint64) *big.Int {
+	if baseFee == nil || feePercentage >= MaxFeePercentage {
+		return baseFee
+	}
+	discounted := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(feePercentage))
+	return discounted.Div(discounted, new(big.Int).SetUint64(MaxFeePercentage))
+}
+
+// GetFeeDiscountQuery reads the percentage of the base fee charged to an address from other precompiles.
+var GetFeeDiscountQuery = contract.NewTypedQuery(ContractAddress, "getFeeDiscount", GetFeeDiscountGasCost, func(state contract.StateReader, address common.Address) (uint64, error) {
+	return GetFeePercentage(state, address), nil
+})
+
+// PackSetFeeDiscount packs [address] and [feePercentage] into the appropriate arguments for setFeeDiscount.
+func PackSetFeeDiscount(address common.Address, feePercentage uint64) ([]byte, error) {
+	return FeeManagerABI.Pack("setFeeDiscount", address, new(big.Int).SetUint64(feePercentage))
+}
+
+// UnpackSetFeeDiscountInput attempts to unpack [input] into the address and fee percentage arguments of setFeeDiscount.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetFeeDiscountInput(input []byte) (common.Address, *big.Int, error) {
+	res, err := FeeManagerABI.UnpackInput("setFeeDiscount", input, false)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("%w: %w", ErrUnpackInput, err)
+	}
+	address := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	feePercentage := *abi.ConvertType(res[1], new(*big.Int)).(**big.Int)
+	return address, feePercentage, nil
+}
+
+// setFeeDiscount checks if the caller is an admin of the fee manager and sets the
+// percentage of the base fee charged to the input address.
+func setFeeDiscount(accessibleState contract.AccessibleState, caller common.Address, _ common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetFeeDiscountGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+
+	address, feePercentage, err := UnpackSetFeeDiscountInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !feePercentage.IsUint64() || feePercentage.Uint64() > MaxFeePercentage {
+		return nil, remainingGas, fmt.Errorf("%w: %d is above %d", ErrInvalidFeePercentage, feePercentage, MaxFeePercentage)
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Fee discounts are negotiated per address, so only admins can set them.
+	callerStatus := GetFeeManagerStatus(stateDB, caller)
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetFeeDiscount, caller)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, FeeDiscountChangedEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackFeeDiscountChangedEvent(caller, address, GetFeePercentage(stateDB, address), feePercentage.Uint64())
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(&types.Log{
+		Address:     ContractAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+	})
+
+	SetFeePercentage(stateDB, address, feePercentage.Uint64())
+
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackGetFeeDiscount packs [address] into the input data to getFeeDiscount.
+func PackGetFeeDiscount(address common.Address) ([]byte, error) {
+	return FeeManagerABI.Pack("getFeeDiscount", address)
+}
+
+// PackGetFeeDiscountOutput attempts to pack [feePercentage] to conform the ABI outputs.
+func PackGetFeeDiscountOutput(feePercentage uint64) ([]byte, error) {
+	return FeeManagerABI.PackOutput("getFeeDiscount", new(big.Int).SetUint64(feePercentage))
+}
+
+// UnpackGetFeeDiscountOutput attempts to unpack [output] into the fee percentage returned by getFeeDiscount.
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetFeeDiscountOutput(output []byte) (*big.Int, error) {
+	res, err := FeeManagerABI.Unpack("getFeeDiscount", output)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnpackOutput, err)
+	}
+	return *abi.ConvertType(res[0], new(*big.Int)).(**big.Int), nil
+}
+
+// getFeeDiscount returns the percentage of the base fee charged to the input address.
+//
+//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+func getFeeDiscount(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetFeeDiscountGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	var address common.Address
+	if err := FeeManagerABI.UnpackInputIntoInterface(&address, "getFeeDiscount", input, false); err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %w", ErrUnpackInput, err)
+	}
+
+	output, err := PackGetFeeDiscountOutput(GetFeePercentage(accessibleState.GetStateDB(), address))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// feeDiscountsActivated returns whether the fee discount functions are available.
+func feeDiscountsActivated(accessibleState contract.AccessibleState) bool {
+	return accessibleState.GetRules().IsHeliconActivated()
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/libevm/common"
 
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -21,12 +22,21 @@ const ConfigKey = "feeManagerConfig"
 
 var ContractAddress = common.HexToAddress("0x0200000000000000000000000000000000000003")
 
+// ReadAllowListQuery reads the allow list role of an address from other precompiles.
+var ReadAllowListQuery = allowlist.NewReadAllowListQuery(ContractAddress)
+
 // Module is the precompile module. It is used to register the precompile contract.
 var Module = modules.Module{
 	ConfigKey:    ConfigKey,
 	Address:      ContractAddress,
 	Contract:     FeeManagerPrecompile,
 	Configurator: &configurator{},
+	Queries: []contract.Query{
+		ReadAllowListQuery.Query(),
+		GetFeeConfigQuery.Query(),
+		GetFeeConfigLastChangedAtQuery.Query(),
+		GetFeeDiscountQuery.Query(),
+	},
 }
 
 type configurator struct{}
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/libevm/core/types"
 	"github.com/ava-labs/libevm/core/vm"
 	"github.com/ava-labs/libevm/crypto"
+	ethparams "github.com/ava-labs/libevm/params"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
@@ -231,6 +232,69 @@ func TestFeeManager(t *testing.T) {
 				require.ErrorContains(t, err, txpool.ErrUnderpriced.Error()) //nolint:forbidigo // upstream error wrapped as string
 			},
 		},
+		{
+			name: "discounted sender should only pay its share of the base fee",
+			test: func(t *testing.T, backend *sim.Backend, feeManager *feemanagerbindings.IFeeManager) {
+				require := require.New(t)
+
+				tx, err := feeManager.SetFeeDiscount(admin, unprivilegedAddress, big.NewInt(50))
+				require.NoError(err)
+				testutils.WaitReceiptSuccessful(t, backend, tx)
+
+				feePercentage, err := feeManager.GetFeeDiscount(nil, unprivilegedAddress)
+				require.NoError(err)
+				require.Equal(big.NewInt(50), feePercentage)
+
+				iter, err := feeManager.FilterFeeDiscountChanged(nil, []common.Address{adminAddress}, []common.Address{unprivilegedAddress})
+				require.NoError(err)
+				defer iter.Close()
+				require.True(iter.Next(), "expected to find FeeDiscountChanged event")
+				require.Equal(big.NewInt(100), iter.Event.OldFeePercentage)
+				require.Equal(big.NewInt(50), iter.Event.NewFeePercentage)
+				require.False(iter.Next(), "expected no more FeeDiscountChanged events")
+				require.NoError(iter.Error())
+
+				// The unprivileged address can now send transactions with a fee cap below the base fee.
+				client := backend.Client()
+				header, err := client.HeaderByNumber(t.Context(), nil)
+				require.NoError(err)
+				balanceBefore, err := client.BalanceAt(t.Context(), unprivilegedAddress, nil)
+				require.NoError(err)
+				nonce, err := client.NonceAt(t.Context(), unprivilegedAddress, nil)
+				require.NoError(err)
+
+				gasTipCap := big.NewInt(1)
+				tx, err = types.SignNewTx(unprivilegedKey, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+					ChainID:   chainID,
+					Nonce:     nonce,
+					GasTipCap: gasTipCap,
+					GasFeeCap: new(big.Int).Sub(header.BaseFee, common.Big1),
+					Gas:       ethparams.TxGas,
+					To:        &adminAddress,
+				})
+				require.NoError(err)
+				require.NoError(client.SendTransaction(t.Context(), tx))
+				receipt := testutils.WaitReceiptSuccessful(t, backend, tx)
+
+				block, err := client.HeaderByNumber(t.Context(), receipt.BlockNumber)
+				require.NoError(err)
+				balanceAfter, err := client.BalanceAt(t.Context(), unprivilegedAddress, nil)
+				require.NoError(err)
+				gasPrice := new(big.Int).Add(feemanager.DiscountBaseFee(block.BaseFee, 50), gasTipCap)
+				paid := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+				require.Equal(paid, new(big.Int).Sub(balanceBefore, balanceAfter))
+			},
+		},
+		{
+			name: "only admins should be able to set fee discounts",
+			test: func(t *testing.T, backend *sim.Backend, feeManager *feemanagerbindings.IFeeManager) {
+				allowlisttest.SetAsEnabled(t, backend, feeManager, admin, unprivilegedAddress)
+
+				unprivileged := testutils.NewAuth(t, unprivilegedKey, chainID)
+				_, err := feeManager.SetFeeDiscount(unprivileged, unprivilegedAddress, big.NewInt(0))
+				require.ErrorContains(t, err, feemanager.ErrCannotSetFeeDiscount.Error()) //nolint:forbidigo // upstream error wrapped as string
+			},
+		},
 	}
 
 	precompileCfg := feemanager.NewConfig(utils.NewUint64(0), []common.Address{adminAddress}, nil, nil, &genesisFeeConfig)
@@ -280,4 +344,5 @@ func TestIFeeManager_Events(t *testing.T) {
 		require.False(iter.Next(), "expected no more FeeConfigChanged events")
 		require.NoError(iter.Error())
 	})
+
 }
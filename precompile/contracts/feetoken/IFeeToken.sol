@@ -0,0 +1,20 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.24;
+import "precompile/allowlist/IAllowList.sol";
+
+interface IFeeToken is IAllowList {
+    // FeeTokenRateChanged is the event logged whenever the exchange rate of a fee token is modified
+    event FeeTokenRateChanged(
+        address indexed sender,
+        address indexed token,
+        uint256 oldRate,
+        uint256 newRate
+    );
+
+    // setFeeTokenRate sets the amount of [token] base units charged per 1e18 wei of fees.
+    // Setting the rate to 0 removes [token] from the approved fee tokens.
+    function setFeeTokenRate(address token, uint256 rate) external;
+
+    // getFeeTokenRate returns the exchange rate of [token], or 0 if it is not an approved fee token
+    function getFeeTokenRate(address token) external view returns (uint256 rate);
+}
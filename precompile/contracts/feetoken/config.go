@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feetoken
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/math"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+var (
+	_ precompileconfig.Config = (*Config)(nil)
+
+	ErrInitialRateNil     = errors.New("initial fee token rate cannot be nil")
+	ErrInitialRateInvalid = errors.New("initial fee token rate must be positive")
+	ErrInitialRateToken   = errors.New("initial fee token cannot be the zero address")
+)
+
+// Config implements the precompileconfig.Config interface while adding in the
+// FeeToken specific precompile config.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+	InitialRates map[common.Address]*math.HexOrDecimal256 `json:"initialRates,omitempty"` // fee tokens approved on activation mapped to their exchange rates
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// FeeToken with the given [admins], [enableds] and [managers] as members of the allowlist.
+// Also approves the fee tokens of [initialRates] at their rates when the upgrade activates.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address, initialRates map[common.Address]*math.HexOrDecimal256) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade:      precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		InitialRates: initialRates,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables FeeToken.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the FeeToken precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Equal returns true if [cfg] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (cfg).(*Config)
+	if !ok {
+		return false
+	}
+	if !c.Upgrade.Equal(&other.Upgrade) || !c.AllowListConfig.Equal(&other.AllowListConfig) {
+		return false
+	}
+	if len(c.InitialRates) != len(other.InitialRates) {
+		return false
+	}
+	for token, rate := range c.InitialRates {
+		otherRate, ok := other.InitialRates[token]
+		if !ok || !utils.BigNumEqual((*big.Int)(rate), (*big.Int)(otherRate)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	// ensure that all of the initial rates are non-nil positive values
+	for token, rate := range c.InitialRates {
+		if token == (common.Address{}) {
+			return ErrInitialRateToken
+		}
+		if rate == nil {
+			return fmt.Errorf("%w for token %s", ErrInitialRateNil, token)
+		}
+		if (*big.Int)(rate).Sign() < 1 {
+			return fmt.Errorf("%w: rate %v for token %s", ErrInitialRateInvalid, (*big.Int)(rate), token)
+		}
+	}
+	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
+}
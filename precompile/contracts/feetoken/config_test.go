@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feetoken_test
+
+import (
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/math"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feetoken"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/precompiletest"
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+func TestVerify(t *testing.T) {
+	admins := []common.Address{allowlisttest.TestAdminAddr}
+	enableds := []common.Address{allowlisttest.TestEnabledAddr}
+	managers := []common.Address{allowlisttest.TestManagerAddr}
+	tests := map[string]precompiletest.ConfigVerifyTest{
+		"valid config": {
+			Config: feetoken.NewConfig(utils.NewUint64(3), admins, enableds, managers,
+				map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(1),
+				}),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				return config
+			}(),
+			ExpectedError: nil,
+		},
+		"invalid allow list config in fee token": {
+			Config:        feetoken.NewConfig(utils.NewUint64(3), admins, admins, nil, nil),
+			ExpectedError: allowlist.ErrAdminAndEnabledAddress,
+		},
+		"nil initial rate": {
+			Config: feetoken.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): nil,
+				}),
+			ExpectedError: feetoken.ErrInitialRateNil,
+		},
+		"zero initial rate": {
+			Config: feetoken.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(0),
+				}),
+			ExpectedError: feetoken.ErrInitialRateInvalid,
+		},
+		"zero address initial token": {
+			Config: feetoken.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*math.HexOrDecimal256{
+					{}: math.NewHexOrDecimal256(1),
+				}),
+			ExpectedError: feetoken.ErrInitialRateToken,
+		},
+	}
+	allowlisttest.VerifyPrecompileWithAllowListTests(t, feetoken.Module, tests)
+}
+
+func TestEqual(t *testing.T) {
+	admins := []common.Address{allowlisttest.TestAdminAddr}
+	enableds := []common.Address{allowlisttest.TestEnabledAddr}
+	managers := []common.Address{allowlisttest.TestManagerAddr}
+	tests := map[string]precompiletest.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   feetoken.NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   feetoken.NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   feetoken.NewConfig(utils.NewUint64(3), admins, nil, nil, nil),
+			Other:    feetoken.NewConfig(utils.NewUint64(4), admins, nil, nil, nil),
+			Expected: false,
+		},
+		"different initial rates": {
+			Config: feetoken.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(1),
+				}),
+			Other: feetoken.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(2),
+				}),
+			Expected: false,
+		},
+		"different initial tokens": {
+			Config: feetoken.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(1),
+				}),
+			Other: feetoken.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x02"): math.NewHexOrDecimal256(1),
+				}),
+			Expected: false,
+		},
+		"same config": {
+			Config: feetoken.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(1),
+				}),
+			Other: feetoken.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(1),
+				}),
+			Expected: true,
+		},
+	}
+	allowlisttest.EqualPrecompileWithAllowListTests(t, feetoken.Module, tests)
+}
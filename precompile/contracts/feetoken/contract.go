@@ -0,0 +1,240 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feetoken
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+const (
+	SetFeeTokenRateGasCost uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write 1 slot + read allow list
+	GetFeeTokenRateGasCost uint64 = contract.ReadGasCostPerSlot
+
+	// TokenCallGasLimit is the gas available to each call the fee token precompile
+	// makes to a fee token contract to escrow, refund or pay the fees of a transaction.
+	TokenCallGasLimit uint64 = 100_000
+	// FeeTokenIntrinsicGas is the gas charged on top of the intrinsic gas of a
+	// transaction paying its fees in a fee token, to cover the calls to the token
+	// contract to escrow, refund and pay its fees. It is charged regardless of the
+	// gas the calls actually use, so it covers all of the gas available to them.
+	FeeTokenIntrinsicGas uint64 = 3 * TokenCallGasLimit
+)
+
+var (
+	// RateDenominator is the amount of wei of fees the rate of a fee token is quoted for.
+	RateDenominator = big.NewInt(1e18)
+
+	// feeTokenRateKeyPrefix prefixes the storage key of the rate of each fee token.
+	// The remaining bytes of the key hold the token address, so the keys cannot
+	// collide with the allow list keys.
+	feeTokenRateKeyPrefix = []byte("ftr")
+)
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	ErrCannotSetFeeTokenRate = errors.New("non-enabled cannot call setFeeTokenRate")
+	ErrInvalidFeeToken       = errors.New("fee token cannot be the zero address")
+	ErrUnpackInput           = errors.New("failed to unpack input")
+
+	// FeeTokenRawABI contains the raw ABI of FeeToken contract.
+	//go:embed contract.abi
+	FeeTokenRawABI string
+
+	FeeTokenABI        = contract.ParseABI(FeeTokenRawABI)
+	FeeTokenPrecompile = createFeeTokenPrecompile()
+)
+
+// GetFeeTokenAllowListStatus returns the role of [address] for the FeeToken list.
+func GetFeeTokenAllowListStatus(stateDB contract.StateReader, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetFeeTokenAllowListStatus sets the permissions of [address] to [role] for the
+// FeeToken list. Assumes [role] has already been verified as valid.
+func SetFeeTokenAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// feeTokenRateKey returns the storage key of the rate of [token].
+func feeTokenRateKey(token common.Address) common.Hash {
+	var key common.Hash
+	copy(key[:], feeTokenRateKeyPrefix)
+	copy(key[common.HashLength-common.AddressLength:], token[:])
+	return key
+}
+
+// GetFeeTokenRate returns the amount of [token] base units charged per [RateDenominator]
+// wei of fees, or zero if [token] is not an approved fee token.
+func GetFeeTokenRate(stateDB contract.StateReader, token common.Address) *big.Int {
+	return stateDB.GetState(ContractAddress, feeTokenRateKey(token)).Big()
+}
+
+// SetFeeTokenRate sets the rate of [token]. A zero [rate] removes [token] from the
+// approved fee tokens. Assumes [rate] fits in 256 bits.
+func SetFeeTokenRate(stateDB contract.StateDB, token common.Address, rate *big.Int) {
+	stateDB.SetState(ContractAddress, feeTokenRateKey(token), common.BigToHash(rate))
+}
+
+// TokenAmount returns the amount of a fee token with [rate] charged for [fee] wei,
+// rounded up so that fees paid in a token are never below their native value.
+func TokenAmount(fee *big.Int, rate *big.Int) *big.Int {
+	amount := new(big.Int).Mul(fee, rate)
+	amount.Add(amount, new(big.Int).Sub(RateDenominator, common.Big1))
+	return amount.Div(amount, RateDenominator)
+}
+
+// FeeTokenFromAccessList returns the fee token a transaction with [accessList] pays
+// its fees in. A transaction opts in to paying its fees in a token by including the
+// precompile address in its access list with the token address as its only storage key.
+func FeeTokenFromAccessList(accessList types.AccessList) (common.Address, bool) {
+	for _, tuple := range accessList {
+		if tuple.Address == ContractAddress && len(tuple.StorageKeys) == 1 {
+			return common.BytesToAddress(tuple.StorageKeys[0].Bytes()), true
+		}
+	}
+	return common.Address{}, false
+}
+
+// GetFeeTokenRateQuery reads the rate of a fee token from other precompiles.
+var GetFeeTokenRateQuery = contract.NewTypedQuery(ContractAddress, "getFeeTokenRate", GetFeeTokenRateGasCost, func(state contract.StateReader, token common.Address) (*big.Int, error) {
+	return GetFeeTokenRate(state, token), nil
+})
+
+// PackSetFeeTokenRate packs [token] and [rate] into the appropriate arguments for setFeeTokenRate.
+func PackSetFeeTokenRate(token common.Address, rate *big.Int) ([]byte, error) {
+	return FeeTokenABI.Pack("setFeeTokenRate", token, rate)
+}
+
+// UnpackSetFeeTokenRateInput attempts to unpack [input] into the token and rate arguments of setFeeTokenRate.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetFeeTokenRateInput(input []byte) (common.Address, *big.Int, error) {
+	res, err := FeeTokenABI.UnpackInput("setFeeTokenRate", input, false)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("%w: %w", ErrUnpackInput, err)
+	}
+	token := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	rate := *abi.ConvertType(res[1], new(*big.Int)).(**big.Int)
+	return token, rate, nil
+}
+
+// setFeeTokenRate checks if the caller is enabled in the allow list and sets the
+// rate of the input token.
+func setFeeTokenRate(accessibleState contract.AccessibleState, caller common.Address, _ common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetFeeTokenRateGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+
+	token, rate, err := UnpackSetFeeTokenRateInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is in the allow list and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetFeeTokenRate, caller)
+	}
+	if token == (common.Address{}) {
+		return nil, remainingGas, ErrInvalidFeeToken
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, FeeTokenRateChangedEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackFeeTokenRateChangedEvent(caller, token, GetFeeTokenRate(stateDB, token), rate)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(&types.Log{
+		Address:     ContractAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+	})
+
+	SetFeeTokenRate(stateDB, token, rate)
+
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackGetFeeTokenRate packs [token] into the input data to getFeeTokenRate.
+func PackGetFeeTokenRate(token common.Address) ([]byte, error) {
+	return FeeTokenABI.Pack("getFeeTokenRate", token)
+}
+
+// PackGetFeeTokenRateOutput attempts to pack [rate] to conform the ABI outputs.
+func PackGetFeeTokenRateOutput(rate *big.Int) ([]byte, error) {
+	return FeeTokenABI.PackOutput("getFeeTokenRate", rate)
+}
+
+// UnpackGetFeeTokenRateOutput attempts to unpack [output] into the rate returned by getFeeTokenRate.
+func UnpackGetFeeTokenRateOutput(output []byte) (*big.Int, error) {
+	res, err := FeeTokenABI.Unpack("getFeeTokenRate", output)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(res[0], new(*big.Int)).(**big.Int), nil
+}
+
+// getFeeTokenRate returns the rate of the input token.
+//
+//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+func getFeeTokenRate(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetFeeTokenRateGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	var token common.Address
+	if err := FeeTokenABI.UnpackInputIntoInterface(&token, "getFeeTokenRate", input, false); err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %w", ErrUnpackInput, err)
+	}
+
+	output, err := PackGetFeeTokenRateOutput(GetFeeTokenRate(accessibleState.GetStateDB(), token))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// createFeeTokenPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+// Access to the setters is controlled by an allow list for [ContractAddress].
+func createFeeTokenPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"setFeeTokenRate": setFeeTokenRate,
+		"getFeeTokenRate": getFeeTokenRate,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := FeeTokenABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
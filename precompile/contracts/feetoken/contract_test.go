@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feetoken_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/common/math"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/core/extstate"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feetoken"
+	"github.com/ava-labs/subnet-evm/precompile/precompiletest"
+)
+
+var (
+	testToken = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testRate  = big.NewInt(2_000_000)
+
+	tests = []precompiletest.PrecompileTest{
+		{
+			Name:       "calling_setFeeTokenRate_from_NoRole_should_fail",
+			Caller:     allowlisttest.TestNoRoleAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(feetoken.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := feetoken.PackSetFeeTokenRate(testToken, testRate)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: feetoken.SetFeeTokenRateGasCost,
+			ReadOnly:    false,
+			ExpectedErr: feetoken.ErrCannotSetFeeTokenRate,
+		},
+		{
+			Name:       "calling_setFeeTokenRate_from_Enabled_should_succeed",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(feetoken.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := feetoken.PackSetFeeTokenRate(testToken, testRate)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: feetoken.SetFeeTokenRateGasCost + feetoken.FeeTokenRateChangedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, stateDB *extstate.StateDB) {
+				require.Equal(t, testRate, feetoken.GetFeeTokenRate(stateDB, testToken))
+				assertFeeTokenRateChangedEvent(t, stateDB.Logs(), allowlisttest.TestEnabledAddr, testToken, common.Big0, testRate)
+			},
+		},
+		{
+			Name:   "calling_setFeeTokenRate_with_zero_rate_should_remove_token",
+			Caller: allowlisttest.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(feetoken.Module.Address)(t, state)
+				feetoken.SetFeeTokenRate(state, testToken, testRate)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := feetoken.PackSetFeeTokenRate(testToken, common.Big0)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: feetoken.SetFeeTokenRateGasCost + feetoken.FeeTokenRateChangedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, stateDB *extstate.StateDB) {
+				require.Zero(t, feetoken.GetFeeTokenRate(stateDB, testToken).Sign())
+				assertFeeTokenRateChangedEvent(t, stateDB.Logs(), allowlisttest.TestAdminAddr, testToken, testRate, common.Big0)
+			},
+		},
+		{
+			Name:       "calling_setFeeTokenRate_with_zero_address_should_fail",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(feetoken.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := feetoken.PackSetFeeTokenRate(common.Address{}, testRate)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: feetoken.SetFeeTokenRateGasCost,
+			ReadOnly:    false,
+			ExpectedErr: feetoken.ErrInvalidFeeToken,
+		},
+		{
+			Name:       "readOnly_setFeeTokenRate_should_fail",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(feetoken.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := feetoken.PackSetFeeTokenRate(testToken, testRate)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: feetoken.SetFeeTokenRateGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vm.ErrWriteProtection,
+		},
+		{
+			Name:       "insufficient_gas_setFeeTokenRate_should_fail",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(feetoken.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := feetoken.PackSetFeeTokenRate(testToken, testRate)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: feetoken.SetFeeTokenRateGasCost + feetoken.FeeTokenRateChangedEventGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vm.ErrOutOfGas,
+		},
+		{
+			Name:   "calling_getFeeTokenRate_from_NoRole_should_succeed",
+			Caller: allowlisttest.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(feetoken.Module.Address)(t, state)
+				feetoken.SetFeeTokenRate(state, testToken, testRate)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := feetoken.PackGetFeeTokenRate(testToken)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: feetoken.GetFeeTokenRateGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := feetoken.PackGetFeeTokenRateOutput(testRate)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		{
+			Name:   "initial_rates_are_set_on_activation",
+			Caller: allowlisttest.TestNoRoleAddr,
+			Config: &feetoken.Config{
+				InitialRates: map[common.Address]*math.HexOrDecimal256{
+					testToken: (*math.HexOrDecimal256)(testRate),
+				},
+			},
+			AfterHook: func(t testing.TB, stateDB *extstate.StateDB) {
+				require.Equal(t, testRate, feetoken.GetFeeTokenRate(stateDB, testToken))
+			},
+		},
+	}
+)
+
+func TestFeeTokenRun(t *testing.T) {
+	allowlisttest.RunPrecompileWithAllowListTests(t, feetoken.Module, tests)
+}
+
+func TestTokenAmount(t *testing.T) {
+	tests := map[string]struct {
+		fee  *big.Int
+		rate *big.Int
+		want *big.Int
+	}{
+		"exact": {
+			fee:  big.NewInt(3e18),
+			rate: big.NewInt(2e6),
+			want: big.NewInt(6e6),
+		},
+		"rounded up": {
+			fee:  big.NewInt(1),
+			rate: big.NewInt(2e6),
+			want: big.NewInt(1),
+		},
+		"zero fee": {
+			fee:  common.Big0,
+			rate: big.NewInt(2e6),
+			want: common.Big0,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Zero(t, test.want.Cmp(feetoken.TokenAmount(test.fee, test.rate)))
+		})
+	}
+}
+
+func TestFeeTokenFromAccessList(t *testing.T) {
+	token, ok := feetoken.FeeTokenFromAccessList(types.AccessList{
+		{Address: testToken, StorageKeys: []common.Hash{{1}}},
+		{Address: feetoken.ContractAddress, StorageKeys: []common.Hash{common.BytesToHash(testToken.Bytes())}},
+	})
+	require.True(t, ok)
+	require.Equal(t, testToken, token)
+
+	_, ok = feetoken.FeeTokenFromAccessList(types.AccessList{
+		{Address: feetoken.ContractAddress, StorageKeys: []common.Hash{{1}, {2}}},
+	})
+	require.False(t, ok)
+}
+
+func assertFeeTokenRateChangedEvent(t testing.TB, logs []*types.Log, sender common.Address, token common.Address, oldRate *big.Int, newRate *big.Int) {
+	require.Len(t, logs, 1)
+	log := logs[0]
+	require.Equal(
+		t,
+		[]common.Hash{
+			feetoken.FeeTokenABI.Events["FeeTokenRateChanged"].ID,
+			common.BytesToHash(sender[:]),
+			common.BytesToHash(token[:]),
+		},
+		log.Topics,
+	)
+	gotOldRate, gotNewRate, err := feetoken.UnpackFeeTokenRateChangedEventData(log.Data)
+	require.NoError(t, err)
+	require.Zero(t, oldRate.Cmp(gotOldRate))
+	require.Zero(t, newRate.Cmp(gotNewRate))
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feetoken
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+// erc20RawABI contains the subset of the ERC-20 interface the fee token precompile
+// calls on fee token contracts.
+const erc20RawABI = `[
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"transferFrom","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"allowance","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+// ERC20ABI is the ABI of the ERC-20 functions called on fee token contracts.
+var ERC20ABI = contract.ParseABI(erc20RawABI)
+
+// PackTransfer packs a call to transfer [amount] tokens to [to].
+func PackTransfer(to common.Address, amount *big.Int) ([]byte, error) {
+	return ERC20ABI.Pack("transfer", to, amount)
+}
+
+// PackTransferFrom packs a call to transfer [amount] tokens from [from] to [to].
+func PackTransferFrom(from common.Address, to common.Address, amount *big.Int) ([]byte, error) {
+	return ERC20ABI.Pack("transferFrom", from, to, amount)
+}
+
+// PackBalanceOf packs a call to read the token balance of [account].
+func PackBalanceOf(account common.Address) ([]byte, error) {
+	return ERC20ABI.Pack("balanceOf", account)
+}
+
+// PackAllowance packs a call to read the amount of tokens [spender] can transfer from [owner].
+func PackAllowance(owner common.Address, spender common.Address) ([]byte, error) {
+	return ERC20ABI.Pack("allowance", owner, spender)
+}
+
+// UnpackAmount unpacks the uint256 returned by balanceOf or allowance.
+func UnpackAmount(output []byte) (*big.Int, error) {
+	res, err := ERC20ABI.Unpack("balanceOf", output)
+	if err != nil {
+		return nil, err
+	}
+	return res[0].(*big.Int), nil
+}
+
+// TransferSucceeded returns whether the [output] of a call to transfer or transferFrom
+// that did not revert reports success. Tokens that return no data are assumed to
+// revert on failure, as many widely used tokens predate the boolean return value.
+func TransferSucceeded(output []byte) bool {
+	if len(output) == 0 {
+		return true
+	}
+	return len(output) == common.HashLength && common.BytesToHash(output).Big().Sign() != 0
+}
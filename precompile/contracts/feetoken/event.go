@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feetoken
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+// FeeTokenRateChangedEventGasCost is the gas cost of a FeeTokenRateChanged event.
+// It is the base gas cost + the gas cost of the topics (signature, sender, token),
+// the gas cost of the non-indexed data (oldRate, newRate) and the gas cost of
+// reading the old rate.
+const FeeTokenRateChangedEventGasCost = contract.LogGas + contract.LogTopicGas*3 + 2*common.HashLength*contract.LogDataGas + contract.ReadGasCostPerSlot
+
+// PackFeeTokenRateChangedEvent packs the event into the appropriate arguments for FeeTokenRateChanged.
+// It returns topic hashes and the encoded non-indexed data.
+func PackFeeTokenRateChangedEvent(sender common.Address, token common.Address, oldRate *big.Int, newRate *big.Int) ([]common.Hash, []byte, error) {
+	return FeeTokenABI.PackEvent("FeeTokenRateChanged", sender, token, oldRate, newRate)
+}
+
+// UnpackFeeTokenRateChangedEventData attempts to unpack the non-indexed [dataBytes]
+// of a FeeTokenRateChanged event into the old and new rates.
+func UnpackFeeTokenRateChangedEventData(dataBytes []byte) (*big.Int, *big.Int, error) {
+	var eventData struct {
+		OldRate *big.Int
+		NewRate *big.Int
+	}
+	if err := FeeTokenABI.UnpackIntoInterface(&eventData, "FeeTokenRateChanged", dataBytes); err != nil {
+		return nil, nil, err
+	}
+	return eventData.OldRate, eventData.NewRate, nil
+}
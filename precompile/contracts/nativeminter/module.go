@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/libevm/common"
 	"github.com/holiman/uint256"
 
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -23,12 +24,16 @@ const ConfigKey = "contractNativeMinterConfig"
 
 var ContractAddress = common.HexToAddress("0x0200000000000000000000000000000000000001")
 
+// ReadAllowListQuery reads the allow list role of an address from other precompiles.
+var ReadAllowListQuery = allowlist.NewReadAllowListQuery(ContractAddress)
+
 // Module is the precompile module. It is used to register the precompile contract.
 var Module = modules.Module{
 	ConfigKey:    ConfigKey,
 	Address:      ContractAddress,
 	Contract:     ContractNativeMinterPrecompile,
 	Configurator: &configurator{},
+	Queries:      []contract.Query{ReadAllowListQuery.Query()},
 }
 
 type configurator struct{}
@@ -0,0 +1,37 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.24;
+import "precompile/allowlist/IAllowList.sol";
+
+interface IRebate is IAllowList {
+    // RebateSet is the event logged whenever the rebate of a contract is registered or modified
+    event RebateSet(
+        address indexed sender,
+        address indexed target,
+        address owner,
+        uint256 rate
+    );
+
+    // RebateClaimed is the event logged whenever the owner of a contract claims its accrued rebate
+    event RebateClaimed(
+        address indexed target,
+        address indexed owner,
+        uint256 amount
+    );
+
+    // setRebate registers [target] to receive [rate] basis points of the fees paid for
+    // the gas consumed by transactions calling it, claimable by [owner]. The gas consumed
+    // by the contracts it calls in turn is included, while calls into [target] made by
+    // other contracts do not accrue rebates.
+    // Setting the rate to 0 stops accruing rebates for [target].
+    function setRebate(address target, address owner, uint256 rate) external;
+
+    // getRebate returns the owner and the rate in basis points of the rebate of [target]
+    function getRebate(address target) external view returns (address owner, uint256 rate);
+
+    // accruedRebate returns the amount of rebate accrued by [target] and not claimed yet
+    function accruedRebate(address target) external view returns (uint256 amount);
+
+    // claimRebate transfers the rebate accrued by [target] to its owner.
+    // Only the owner of [target] can claim its rebate.
+    function claimRebate(address target) external returns (uint256 amount);
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rebate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/libevm/common"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+)
+
+var (
+	_ precompileconfig.Config = (*Config)(nil)
+
+	ErrInitialRebateNil    = errors.New("initial rebate cannot be nil")
+	ErrInitialRebateTarget = errors.New("initial rebate target cannot be the zero address")
+)
+
+// InitialRebate is a rebate registered when the precompile activates.
+type InitialRebate struct {
+	Owner common.Address `json:"owner"` // address allowed to claim the accrued rebate
+	Rate  uint64         `json:"rate"`  // share of the fees rebated, in basis points
+}
+
+// Config implements the precompileconfig.Config interface while adding in the
+// Rebate specific precompile config.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+	InitialRebates map[common.Address]*InitialRebate `json:"initialRebates,omitempty"` // contracts registered on activation mapped to their rebates
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// Rebate with the given [admins], [enableds] and [managers] as members of the allowlist.
+// Also registers the contracts of [initialRebates] when the upgrade activates.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address, initialRebates map[common.Address]*InitialRebate) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade:        precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		InitialRebates: initialRebates,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables Rebate.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the Rebate precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Equal returns true if [cfg] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (cfg).(*Config)
+	if !ok {
+		return false
+	}
+	if !c.Upgrade.Equal(&other.Upgrade) || !c.AllowListConfig.Equal(&other.AllowListConfig) {
+		return false
+	}
+	if len(c.InitialRebates) != len(other.InitialRebates) {
+		return false
+	}
+	for target, rebate := range c.InitialRebates {
+		otherRebate, ok := other.InitialRebates[target]
+		if !ok || (rebate == nil) != (otherRebate == nil) || (rebate != nil && *rebate != *otherRebate) {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	for target, rebate := range c.InitialRebates {
+		if target == (common.Address{}) {
+			return ErrInitialRebateTarget
+		}
+		if rebate == nil {
+			return fmt.Errorf("%w for target %s", ErrInitialRebateNil, target)
+		}
+		if rebate.Rate > MaxRebateRate {
+			return fmt.Errorf("%w: rate %d for target %s", ErrInvalidRebateRate, rebate.Rate, target)
+		}
+		if rebate.Owner == (common.Address{}) {
+			return fmt.Errorf("%w for target %s", ErrInvalidRebateOwner, target)
+		}
+	}
+	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
+}
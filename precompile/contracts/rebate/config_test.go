@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rebate_test
+
+import (
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rebate"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/precompiletest"
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+func TestVerify(t *testing.T) {
+	admins := []common.Address{allowlisttest.TestAdminAddr}
+	enableds := []common.Address{allowlisttest.TestEnabledAddr}
+	managers := []common.Address{allowlisttest.TestManagerAddr}
+	tests := map[string]precompiletest.ConfigVerifyTest{
+		"valid config": {
+			Config: rebate.NewConfig(utils.NewUint64(3), admins, enableds, managers,
+				map[common.Address]*rebate.InitialRebate{
+					common.HexToAddress("0x01"): {Owner: common.HexToAddress("0x02"), Rate: rebate.MaxRebateRate},
+				}),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDurango(gomock.Any()).Return(true).AnyTimes()
+				return config
+			}(),
+			ExpectedError: nil,
+		},
+		"invalid allow list config in rebate": {
+			Config:        rebate.NewConfig(utils.NewUint64(3), admins, admins, nil, nil),
+			ExpectedError: allowlist.ErrAdminAndEnabledAddress,
+		},
+		"nil initial rebate": {
+			Config: rebate.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*rebate.InitialRebate{
+					common.HexToAddress("0x01"): nil,
+				}),
+			ExpectedError: rebate.ErrInitialRebateNil,
+		},
+		"initial rate too high": {
+			Config: rebate.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*rebate.InitialRebate{
+					common.HexToAddress("0x01"): {Owner: common.HexToAddress("0x02"), Rate: rebate.MaxRebateRate + 1},
+				}),
+			ExpectedError: rebate.ErrInvalidRebateRate,
+		},
+		"zero address initial owner": {
+			Config: rebate.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*rebate.InitialRebate{
+					common.HexToAddress("0x01"): {Rate: 1},
+				}),
+			ExpectedError: rebate.ErrInvalidRebateOwner,
+		},
+		"zero address initial target": {
+			Config: rebate.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*rebate.InitialRebate{
+					{}: {Owner: common.HexToAddress("0x02"), Rate: 1},
+				}),
+			ExpectedError: rebate.ErrInitialRebateTarget,
+		},
+	}
+	allowlisttest.VerifyPrecompileWithAllowListTests(t, rebate.Module, tests)
+}
+
+func TestEqual(t *testing.T) {
+	admins := []common.Address{allowlisttest.TestAdminAddr}
+	enableds := []common.Address{allowlisttest.TestEnabledAddr}
+	managers := []common.Address{allowlisttest.TestManagerAddr}
+	tests := map[string]precompiletest.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   rebate.NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   rebate.NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   rebate.NewConfig(utils.NewUint64(3), admins, nil, nil, nil),
+			Other:    rebate.NewConfig(utils.NewUint64(4), admins, nil, nil, nil),
+			Expected: false,
+		},
+		"different initial rates": {
+			Config: rebate.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*rebate.InitialRebate{
+					common.HexToAddress("0x01"): {Owner: common.HexToAddress("0x02"), Rate: 1},
+				}),
+			Other: rebate.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*rebate.InitialRebate{
+					common.HexToAddress("0x01"): {Owner: common.HexToAddress("0x02"), Rate: 2},
+				}),
+			Expected: false,
+		},
+		"different initial owners": {
+			Config: rebate.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*rebate.InitialRebate{
+					common.HexToAddress("0x01"): {Owner: common.HexToAddress("0x02"), Rate: 1},
+				}),
+			Other: rebate.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*rebate.InitialRebate{
+					common.HexToAddress("0x01"): {Owner: common.HexToAddress("0x03"), Rate: 1},
+				}),
+			Expected: false,
+		},
+		"same config": {
+			Config: rebate.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*rebate.InitialRebate{
+					common.HexToAddress("0x01"): {Owner: common.HexToAddress("0x02"), Rate: 1},
+				}),
+			Other: rebate.NewConfig(utils.NewUint64(3), admins, nil, nil,
+				map[common.Address]*rebate.InitialRebate{
+					common.HexToAddress("0x01"): {Owner: common.HexToAddress("0x02"), Rate: 1},
+				}),
+			Expected: true,
+		},
+	}
+	allowlisttest.EqualPrecompileWithAllowListTests(t, rebate.Module, tests)
+}
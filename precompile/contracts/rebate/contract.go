@@ -0,0 +1,396 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rebate
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/ava-labs/libevm/libevm/stateconf"
+	"github.com/holiman/uint256"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+
+	ethparams "github.com/ava-labs/libevm/params"
+)
+
+const (
+	SetRebateGasCost     uint64 = contract.ReadGasCostPerSlot + 3*contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // read rate + write 3 slots + read allow list
+	GetRebateGasCost     uint64 = 2 * contract.ReadGasCostPerSlot
+	AccruedRebateGasCost uint64 = contract.ReadGasCostPerSlot
+	// ClaimRebateGasCost covers reading the owner and the accrued rebate, clearing
+	// the accrued rebate and transferring it to the owner.
+	ClaimRebateGasCost uint64 = 2*contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot + ethparams.CallValueTransferGas
+
+	// MaxRebateRate is the rate rebating all of the fees, in basis points.
+	MaxRebateRate uint64 = 10_000
+)
+
+var (
+	// rebateOwnerKeyPrefix, rebateRateKeyPrefix and accruedRebateKeyPrefix prefix the
+	// storage keys of the owner, rate and accrued rebate of each contract. The remaining
+	// bytes of the keys hold the contract address, so the keys cannot collide with the
+	// allow list keys.
+	rebateOwnerKeyPrefix   = []byte("rbo")
+	rebateRateKeyPrefix    = []byte("rbr")
+	accruedRebateKeyPrefix = []byte("rba")
+
+	// registeredCountKey is the storage key of the number of contracts accruing
+	// rebates, so that transactions skip the rebate accounting while there are none.
+	registeredCountKey = common.Hash{'r', 'b', 'c'}
+)
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	ErrCannotSetRebate    = errors.New("non-enabled cannot call setRebate")
+	ErrCannotClaimRebate  = errors.New("only the rebate owner can call claimRebate")
+	ErrInvalidRebateOwner = errors.New("rebate owner cannot be the zero address")
+	ErrInvalidRebateRate  = errors.New("rebate rate cannot exceed 10000 basis points")
+	ErrInvalidTarget      = errors.New("rebate target cannot be the zero address")
+	ErrUnpackInput        = errors.New("failed to unpack input")
+
+	// RebateRawABI contains the raw ABI of Rebate contract.
+	//go:embed contract.abi
+	RebateRawABI string
+
+	RebateABI        = contract.ParseABI(RebateRawABI)
+	RebatePrecompile = createRebatePrecompile()
+)
+
+// AccrualStateDB is the state a rebate accrues in while a transaction is applied.
+type AccrualStateDB interface {
+	contract.StateReader
+	SetState(common.Address, common.Hash, common.Hash, ...stateconf.StateDBStateOption)
+	AddBalance(common.Address, *uint256.Int)
+}
+
+// GetRebateAllowListStatus returns the role of [address] for the Rebate list.
+func GetRebateAllowListStatus(stateDB contract.StateReader, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetRebateAllowListStatus sets the permissions of [address] to [role] for the
+// Rebate list. Assumes [role] has already been verified as valid.
+func SetRebateAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// storageKey returns the storage key starting with [prefix] for [target].
+func storageKey(prefix []byte, target common.Address) common.Hash {
+	var key common.Hash
+	copy(key[:], prefix)
+	copy(key[common.HashLength-common.AddressLength:], target[:])
+	return key
+}
+
+// GetRebate returns the owner of the rebate of [target] and its rate in basis points.
+// The rate is zero if [target] does not accrue rebates.
+func GetRebate(stateDB contract.StateReader, target common.Address) (common.Address, uint64) {
+	owner := common.BytesToAddress(stateDB.GetState(ContractAddress, storageKey(rebateOwnerKeyPrefix, target)).Bytes())
+	rate := stateDB.GetState(ContractAddress, storageKey(rebateRateKeyPrefix, target)).Big().Uint64()
+	return owner, rate
+}
+
+// RegisteredCount returns the number of contracts accruing rebates.
+func RegisteredCount(stateDB contract.StateReader) uint64 {
+	return stateDB.GetState(ContractAddress, registeredCountKey).Big().Uint64()
+}
+
+// SetRebate registers [target] to accrue [rate] basis points of the fees paid for
+// the gas consumed by transactions calling it for [owner]. Assumes [rate] does not
+// exceed [MaxRebateRate].
+func SetRebate(stateDB contract.StateDB, target common.Address, owner common.Address, rate uint64) {
+	_, prevRate := GetRebate(stateDB, target)
+	switch count := RegisteredCount(stateDB); {
+	case prevRate == 0 && rate != 0:
+		stateDB.SetState(ContractAddress, registeredCountKey, common.BigToHash(new(big.Int).SetUint64(count+1)))
+	case prevRate != 0 && rate == 0:
+		stateDB.SetState(ContractAddress, registeredCountKey, common.BigToHash(new(big.Int).SetUint64(count-1)))
+	}
+	stateDB.SetState(ContractAddress, storageKey(rebateOwnerKeyPrefix, target), common.BytesToHash(owner.Bytes()))
+	stateDB.SetState(ContractAddress, storageKey(rebateRateKeyPrefix, target), common.BigToHash(new(big.Int).SetUint64(rate)))
+}
+
+// GetAccruedRebate returns the rebate accrued by [target] and not claimed yet.
+func GetAccruedRebate(stateDB contract.StateReader, target common.Address) *big.Int {
+	return stateDB.GetState(ContractAddress, storageKey(accruedRebateKeyPrefix, target)).Big()
+}
+
+// Accrue accrues the rebate of [target] on [fee], the fee paid for the gas consumed
+// by a transaction calling [target]. The rebate is moved to the balance of the precompile until it
+// is claimed, and the returned amount must be deducted from [fee] by the caller.
+// Returns zero if [target] does not accrue rebates.
+func Accrue(stateDB AccrualStateDB, target common.Address, fee *uint256.Int) *uint256.Int {
+	_, rate := GetRebate(stateDB, target)
+	if rate == 0 {
+		return new(uint256.Int)
+	}
+	amount, _ := new(uint256.Int).MulDivOverflow(fee, uint256.NewInt(rate), uint256.NewInt(MaxRebateRate))
+	if amount.IsZero() {
+		return amount
+	}
+	accrued := GetAccruedRebate(stateDB, target)
+	accrued.Add(accrued, amount.ToBig())
+	stateDB.SetState(ContractAddress, storageKey(accruedRebateKeyPrefix, target), common.BigToHash(accrued))
+	stateDB.AddBalance(ContractAddress, amount)
+	return amount
+}
+
+// AccruedRebateQuery reads the rebate accrued by a contract from other precompiles.
+var AccruedRebateQuery = contract.NewTypedQuery(ContractAddress, "accruedRebate", AccruedRebateGasCost, func(state contract.StateReader, target common.Address) (*big.Int, error) {
+	return GetAccruedRebate(state, target), nil
+})
+
+// PackSetRebate packs [target], [owner] and [rate] into the appropriate arguments for setRebate.
+func PackSetRebate(target common.Address, owner common.Address, rate uint64) ([]byte, error) {
+	return RebateABI.Pack("setRebate", target, owner, new(big.Int).SetUint64(rate))
+}
+
+// UnpackSetRebateInput attempts to unpack [input] into the target, owner and rate arguments of setRebate.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetRebateInput(input []byte) (common.Address, common.Address, *big.Int, error) {
+	res, err := RebateABI.UnpackInput("setRebate", input, false)
+	if err != nil {
+		return common.Address{}, common.Address{}, nil, fmt.Errorf("%w: %w", ErrUnpackInput, err)
+	}
+	target := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	owner := *abi.ConvertType(res[1], new(common.Address)).(*common.Address)
+	rate := *abi.ConvertType(res[2], new(*big.Int)).(**big.Int)
+	return target, owner, rate, nil
+}
+
+// setRebate checks if the caller is enabled in the allow list and registers the
+// rebate of the input target.
+func setRebate(accessibleState contract.AccessibleState, caller common.Address, _ common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetRebateGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+
+	target, owner, rate, err := UnpackSetRebateInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is in the allow list and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetRebate, caller)
+	}
+	switch {
+	case target == (common.Address{}):
+		return nil, remainingGas, ErrInvalidTarget
+	case owner == (common.Address{}):
+		return nil, remainingGas, ErrInvalidRebateOwner
+	case !rate.IsUint64() || rate.Uint64() > MaxRebateRate:
+		return nil, remainingGas, fmt.Errorf("%w: %v", ErrInvalidRebateRate, rate)
+	}
+
+	if remainingGas, err = contract.DeductGas(remainingGas, RebateSetEventGasCost); err != nil {
+		return nil, 0, err
+	}
+	topics, data, err := PackRebateSetEvent(caller, target, owner, rate.Uint64())
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	stateDB.AddLog(&types.Log{
+		Address:     ContractAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+	})
+
+	SetRebate(stateDB, target, owner, rate.Uint64())
+
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// PackGetRebate packs [target] into the input data to getRebate.
+func PackGetRebate(target common.Address) ([]byte, error) {
+	return RebateABI.Pack("getRebate", target)
+}
+
+// PackGetRebateOutput attempts to pack [owner] and [rate] to conform the ABI outputs.
+func PackGetRebateOutput(owner common.Address, rate uint64) ([]byte, error) {
+	return RebateABI.PackOutput("getRebate", owner, new(big.Int).SetUint64(rate))
+}
+
+// UnpackGetRebateOutput attempts to unpack [output] into the owner and rate returned by getRebate.
+func UnpackGetRebateOutput(output []byte) (common.Address, *big.Int, error) {
+	res, err := RebateABI.Unpack("getRebate", output)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	owner := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	rate := *abi.ConvertType(res[1], new(*big.Int)).(**big.Int)
+	return owner, rate, nil
+}
+
+// getRebate returns the owner and the rate of the rebate of the input target.
+//
+//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+func getRebate(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetRebateGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	var target common.Address
+	if err := RebateABI.UnpackInputIntoInterface(&target, "getRebate", input, false); err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %w", ErrUnpackInput, err)
+	}
+
+	output, err := PackGetRebateOutput(GetRebate(accessibleState.GetStateDB(), target))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// PackAccruedRebate packs [target] into the input data to accruedRebate.
+func PackAccruedRebate(target common.Address) ([]byte, error) {
+	return RebateABI.Pack("accruedRebate", target)
+}
+
+// PackAccruedRebateOutput attempts to pack [amount] to conform the ABI outputs.
+func PackAccruedRebateOutput(amount *big.Int) ([]byte, error) {
+	return RebateABI.PackOutput("accruedRebate", amount)
+}
+
+// UnpackAccruedRebateOutput attempts to unpack [output] into the amount returned by accruedRebate.
+func UnpackAccruedRebateOutput(output []byte) (*big.Int, error) {
+	res, err := RebateABI.Unpack("accruedRebate", output)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(res[0], new(*big.Int)).(**big.Int), nil
+}
+
+// accruedRebate returns the rebate accrued by the input target.
+//
+//nolint:revive // General-purpose types lose the meaning of args if unused ones are removed
+func accruedRebate(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, AccruedRebateGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	var target common.Address
+	if err := RebateABI.UnpackInputIntoInterface(&target, "accruedRebate", input, false); err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %w", ErrUnpackInput, err)
+	}
+
+	output, err := PackAccruedRebateOutput(GetAccruedRebate(accessibleState.GetStateDB(), target))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// PackClaimRebate packs [target] into the input data to claimRebate.
+func PackClaimRebate(target common.Address) ([]byte, error) {
+	return RebateABI.Pack("claimRebate", target)
+}
+
+// PackClaimRebateOutput attempts to pack [amount] to conform the ABI outputs.
+func PackClaimRebateOutput(amount *big.Int) ([]byte, error) {
+	return RebateABI.PackOutput("claimRebate", amount)
+}
+
+// UnpackClaimRebateOutput attempts to unpack [output] into the amount returned by claimRebate.
+func UnpackClaimRebateOutput(output []byte) (*big.Int, error) {
+	res, err := RebateABI.Unpack("claimRebate", output)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(res[0], new(*big.Int)).(**big.Int), nil
+}
+
+// claimRebate checks that the caller owns the rebate of the input target and
+// transfers the rebate accrued by the target to the caller.
+func claimRebate(accessibleState contract.AccessibleState, caller common.Address, _ common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ClaimRebateGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vm.ErrWriteProtection
+	}
+
+	var target common.Address
+	if err := RebateABI.UnpackInputIntoInterface(&target, "claimRebate", input, false); err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %w", ErrUnpackInput, err)
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	owner, _ := GetRebate(stateDB, target)
+	if owner == (common.Address{}) || caller != owner {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotClaimRebate, caller)
+	}
+
+	amount := GetAccruedRebate(stateDB, target)
+	if amount.Sign() > 0 {
+		if remainingGas, err = contract.DeductGas(remainingGas, RebateClaimedEventGasCost); err != nil {
+			return nil, 0, err
+		}
+		topics, data, err := PackRebateClaimedEvent(target, owner, amount)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		stateDB.AddLog(&types.Log{
+			Address:     ContractAddress,
+			Topics:      topics,
+			Data:        data,
+			BlockNumber: accessibleState.GetBlockContext().Number().Uint64(),
+		})
+
+		stateDB.SetState(ContractAddress, storageKey(accruedRebateKeyPrefix, target), common.Hash{})
+		amountU256 := uint256.MustFromBig(amount)
+		stateDB.SubBalance(ContractAddress, amountU256)
+		stateDB.AddBalance(owner, amountU256)
+	}
+
+	output, err := PackClaimRebateOutput(amount)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// createRebatePrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+// Access to setRebate is controlled by an allow list for [ContractAddress], and
+// access to claimRebate is restricted to the owner of each rebate.
+func createRebatePrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"setRebate":     setRebate,
+		"getRebate":     getRebate,
+		"accruedRebate": accruedRebate,
+		"claimRebate":   claimRebate,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := RebateABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
@@ -0,0 +1,291 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rebate_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/core/vm"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/core/extstate"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rebate"
+	"github.com/ava-labs/subnet-evm/precompile/precompiletest"
+)
+
+var (
+	testTarget = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testOwner  = allowlisttest.TestNoRoleAddr
+	testRate   = uint64(2_500) // 25%
+
+	tests = []precompiletest.PrecompileTest{
+		{
+			Name:       "calling_setRebate_from_NoRole_should_fail",
+			Caller:     allowlisttest.TestNoRoleAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(rebate.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackSetRebate(testTarget, testOwner, testRate)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.SetRebateGasCost,
+			ReadOnly:    false,
+			ExpectedErr: rebate.ErrCannotSetRebate,
+		},
+		{
+			Name:       "calling_setRebate_from_Enabled_should_succeed",
+			Caller:     allowlisttest.TestEnabledAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(rebate.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackSetRebate(testTarget, testOwner, testRate)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.SetRebateGasCost + rebate.RebateSetEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, stateDB *extstate.StateDB) {
+				owner, rate := rebate.GetRebate(stateDB, testTarget)
+				require.Equal(t, testOwner, owner)
+				require.Equal(t, testRate, rate)
+				require.Equal(t, uint64(1), rebate.RegisteredCount(stateDB))
+
+				logs := stateDB.Logs()
+				require.Len(t, logs, 1)
+				require.Equal(t, []common.Hash{
+					rebate.RebateABI.Events["RebateSet"].ID,
+					common.BytesToHash(allowlisttest.TestEnabledAddr[:]),
+					common.BytesToHash(testTarget[:]),
+				}, logs[0].Topics)
+				gotOwner, gotRate, err := rebate.UnpackRebateSetEventData(logs[0].Data)
+				require.NoError(t, err)
+				require.Equal(t, testOwner, gotOwner)
+				require.Equal(t, testRate, gotRate.Uint64())
+			},
+		},
+		{
+			Name:   "calling_setRebate_with_zero_rate_should_unregister",
+			Caller: allowlisttest.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(rebate.Module.Address)(t, state)
+				rebate.SetRebate(state, testTarget, testOwner, testRate)
+				rebate.SetRebate(state, testTarget, testOwner, testRate)
+				require.Equal(t, uint64(1), rebate.RegisteredCount(state))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackSetRebate(testTarget, testOwner, 0)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.SetRebateGasCost + rebate.RebateSetEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, stateDB *extstate.StateDB) {
+				_, rate := rebate.GetRebate(stateDB, testTarget)
+				require.Zero(t, rate)
+				require.Zero(t, rebate.RegisteredCount(stateDB))
+			},
+		},
+		{
+			Name:       "calling_setRebate_with_rate_too_high_should_fail",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(rebate.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackSetRebate(testTarget, testOwner, rebate.MaxRebateRate+1)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.SetRebateGasCost,
+			ReadOnly:    false,
+			ExpectedErr: rebate.ErrInvalidRebateRate,
+		},
+		{
+			Name:       "calling_setRebate_with_zero_owner_should_fail",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(rebate.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackSetRebate(testTarget, common.Address{}, testRate)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.SetRebateGasCost,
+			ReadOnly:    false,
+			ExpectedErr: rebate.ErrInvalidRebateOwner,
+		},
+		{
+			Name:       "readOnly_setRebate_should_fail",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(rebate.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackSetRebate(testTarget, testOwner, testRate)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.SetRebateGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vm.ErrWriteProtection,
+		},
+		{
+			Name:       "insufficient_gas_setRebate_should_fail",
+			Caller:     allowlisttest.TestAdminAddr,
+			BeforeHook: allowlisttest.SetDefaultRoles(rebate.Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackSetRebate(testTarget, testOwner, testRate)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.SetRebateGasCost + rebate.RebateSetEventGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vm.ErrOutOfGas,
+		},
+		{
+			Name:   "calling_getRebate_from_NoRole_should_succeed",
+			Caller: allowlisttest.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(rebate.Module.Address)(t, state)
+				rebate.SetRebate(state, testTarget, testOwner, testRate)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackGetRebate(testTarget)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.GetRebateGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := rebate.PackGetRebateOutput(testOwner, testRate)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		{
+			Name:   "calling_accruedRebate_from_NoRole_should_succeed",
+			Caller: allowlisttest.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(rebate.Module.Address)(t, state)
+				rebate.SetRebate(state, testTarget, testOwner, testRate)
+				rebate.Accrue(state, testTarget, uint256.NewInt(1_000))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackAccruedRebate(testTarget)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.AccruedRebateGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := rebate.PackAccruedRebateOutput(big.NewInt(250))
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		{
+			Name:   "calling_claimRebate_from_owner_should_succeed",
+			Caller: testOwner,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(rebate.Module.Address)(t, state)
+				rebate.SetRebate(state, testTarget, testOwner, testRate)
+				rebate.Accrue(state, testTarget, uint256.NewInt(1_000))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackClaimRebate(testTarget)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.ClaimRebateGasCost + rebate.RebateClaimedEventGasCost,
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				res, err := rebate.PackClaimRebateOutput(big.NewInt(250))
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			AfterHook: func(t testing.TB, stateDB *extstate.StateDB) {
+				require.Zero(t, rebate.GetAccruedRebate(stateDB, testTarget).Sign())
+				require.Equal(t, uint256.NewInt(250), stateDB.GetBalance(testOwner))
+				require.True(t, stateDB.GetBalance(rebate.ContractAddress).IsZero())
+				assertRebateClaimedEvent(t, stateDB.Logs(), testTarget, testOwner, big.NewInt(250))
+			},
+		},
+		{
+			Name:   "calling_claimRebate_from_non_owner_should_fail",
+			Caller: allowlisttest.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(rebate.Module.Address)(t, state)
+				rebate.SetRebate(state, testTarget, testOwner, testRate)
+				rebate.Accrue(state, testTarget, uint256.NewInt(1_000))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackClaimRebate(testTarget)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.ClaimRebateGasCost,
+			ReadOnly:    false,
+			ExpectedErr: rebate.ErrCannotClaimRebate,
+		},
+		{
+			Name:   "readOnly_claimRebate_should_fail",
+			Caller: testOwner,
+			BeforeHook: func(t testing.TB, state *extstate.StateDB) {
+				allowlisttest.SetDefaultRoles(rebate.Module.Address)(t, state)
+				rebate.SetRebate(state, testTarget, testOwner, testRate)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := rebate.PackClaimRebate(testTarget)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: rebate.ClaimRebateGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vm.ErrWriteProtection,
+		},
+		{
+			Name:   "initial_rebates_are_set_on_activation",
+			Caller: allowlisttest.TestNoRoleAddr,
+			Config: &rebate.Config{
+				InitialRebates: map[common.Address]*rebate.InitialRebate{
+					testTarget: {Owner: testOwner, Rate: testRate},
+				},
+			},
+			AfterHook: func(t testing.TB, stateDB *extstate.StateDB) {
+				owner, rate := rebate.GetRebate(stateDB, testTarget)
+				require.Equal(t, testOwner, owner)
+				require.Equal(t, testRate, rate)
+				require.Equal(t, uint64(1), rebate.RegisteredCount(stateDB))
+			},
+		},
+	}
+)
+
+func TestRebateRun(t *testing.T) {
+	allowlisttest.RunPrecompileWithAllowListTests(t, rebate.Module, tests)
+}
+
+func assertRebateClaimedEvent(t testing.TB, logs []*types.Log, target common.Address, owner common.Address, amount *big.Int) {
+	require.Len(t, logs, 1)
+	log := logs[0]
+	require.Equal(
+		t,
+		[]common.Hash{
+			rebate.RebateABI.Events["RebateClaimed"].ID,
+			common.BytesToHash(target[:]),
+			common.BytesToHash(owner[:]),
+		},
+		log.Topics,
+	)
+	gotAmount, err := rebate.UnpackRebateClaimedEventData(log.Data)
+	require.NoError(t, err)
+	require.Zero(t, amount.Cmp(gotAmount))
+}
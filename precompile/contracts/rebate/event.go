@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rebate
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/libevm/common"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+)
+
+const (
+	// RebateSetEventGasCost is the gas cost of a RebateSet event.
+	// It is the base gas cost + the gas cost of the topics (signature, sender, target)
+	// and the gas cost of the non-indexed data (owner, rate).
+	RebateSetEventGasCost = contract.LogGas + contract.LogTopicGas*3 + 2*common.HashLength*contract.LogDataGas
+	// RebateClaimedEventGasCost is the gas cost of a RebateClaimed event.
+	// It is the base gas cost + the gas cost of the topics (signature, target, owner)
+	// and the gas cost of the non-indexed data (amount).
+	RebateClaimedEventGasCost = contract.LogGas + contract.LogTopicGas*3 + common.HashLength*contract.LogDataGas
+)
+
+// PackRebateSetEvent packs the event into the appropriate arguments for RebateSet.
+// It returns topic hashes and the encoded non-indexed data.
+func PackRebateSetEvent(sender common.Address, target common.Address, owner common.Address, rate uint64) ([]common.Hash, []byte, error) {
+	return RebateABI.PackEvent("RebateSet", sender, target, owner, new(big.Int).SetUint64(rate))
+}
+
+// UnpackRebateSetEventData attempts to unpack the non-indexed [dataBytes]
+// of a RebateSet event into the owner and rate.
+func UnpackRebateSetEventData(dataBytes []byte) (common.Address, *big.Int, error) {
+	var eventData struct {
+		Owner common.Address
+		Rate  *big.Int
+	}
+	if err := RebateABI.UnpackIntoInterface(&eventData, "RebateSet", dataBytes); err != nil {
+		return common.Address{}, nil, err
+	}
+	return eventData.Owner, eventData.Rate, nil
+}
+
+// PackRebateClaimedEvent packs the event into the appropriate arguments for RebateClaimed.
+// It returns topic hashes and the encoded non-indexed data.
+func PackRebateClaimedEvent(target common.Address, owner common.Address, amount *big.Int) ([]common.Hash, []byte, error) {
+	return RebateABI.PackEvent("RebateClaimed", target, owner, amount)
+}
+
+// UnpackRebateClaimedEventData attempts to unpack the non-indexed [dataBytes]
+// of a RebateClaimed event into the claimed amount.
+func UnpackRebateClaimedEventData(dataBytes []byte) (*big.Int, error) {
+	var eventData struct {
+		Amount *big.Int
+	}
+	if err := RebateABI.UnpackIntoInterface(&eventData, "RebateClaimed", dataBytes); err != nil {
+		return nil, err
+	}
+	return eventData.Amount, nil
+}
@@ -21,6 +21,19 @@ type Module struct {
 	Contract contract.StatefulPrecompiledContract
 	// Configurator is used to configure the stateful precompile when the config is enabled.
 	contract.Configurator
+	// Queries are the read-only views of the precompile state that other precompiles can call
+	// through [contract.AccessibleState.QueryPrecompile] while this precompile is active.
+	Queries []contract.Query
+}
+
+// GetQuery returns the query of the module named [name].
+func (m Module) GetQuery(name string) (contract.Query, bool) {
+	for _, query := range m.Queries {
+		if query.Name == name {
+			return query, true
+		}
+	}
+	return contract.Query{}, false
 }
 
 type moduleArray []Module
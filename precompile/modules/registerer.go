@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/libevm/common"
 
 	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/utils"
 )
 
@@ -36,6 +37,7 @@ var (
 
 	errBlackholeAddress          = fmt.Errorf("cannot register module that overlaps with blackhole address %s", constants.BlackholeAddr)
 	errAddressNotInReservedRange = errors.New("address is not in a reserved range for custom precompiles")
+	errDuplicateQuery            = errors.New("cannot register module with duplicated query name")
 )
 
 // ReservedAddress returns true if [addr] is in a reserved range for custom precompiles
@@ -61,6 +63,14 @@ func RegisterModule(stm Module) error {
 		return fmt.Errorf("%w: address %s ", errAddressNotInReservedRange, address)
 	}
 
+	queryNames := make(map[string]struct{}, len(stm.Queries))
+	for _, query := range stm.Queries {
+		if _, ok := queryNames[query.Name]; ok {
+			return fmt.Errorf("%w: %s", errDuplicateQuery, query.Name)
+		}
+		queryNames[query.Name] = struct{}{}
+	}
+
 	for _, registeredModule := range registeredModules {
 		if registeredModule.ConfigKey == key {
 			return fmt.Errorf("name %s already used by a stateful precompile", key)
@@ -69,6 +79,7 @@ func RegisterModule(stm Module) error {
 			return fmt.Errorf("address %s already used by a stateful precompile", address)
 		}
 	}
+	contract.RegisterMetrics(address, stm.Contract)
 	// sort by address to ensure deterministic iteration
 	registeredModules = insertSortedByAddress(registeredModules, stm)
 	return nil
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
 )
 
 func TestInsertSortedByAddress(t *testing.T) {
@@ -58,3 +59,31 @@ func TestRegisterModuleInvalidAddresses(t *testing.T) {
 	err = RegisterModule(m)
 	require.ErrorIs(t, err, errAddressNotInReservedRange)
 }
+
+func TestRegisterModuleDuplicateQuery(t *testing.T) {
+	m := Module{
+		ConfigKey: "duplicateQueryConfig",
+		Address:   common.HexToAddress("0x03000000000000000000000000000000000000ff"),
+		Queries: []contract.Query{
+			{Name: "get"},
+			{Name: "get"},
+		},
+	}
+	err := RegisterModule(m)
+	require.ErrorIs(t, err, errDuplicateQuery)
+}
+
+func TestGetQuery(t *testing.T) {
+	m := Module{
+		Queries: []contract.Query{
+			{Name: "get", GasCost: 1},
+			{Name: "list", GasCost: 2},
+		},
+	}
+	query, ok := m.GetQuery("list")
+	require.True(t, ok)
+	require.Equal(t, uint64(2), query.GasCost)
+
+	_, ok = m.GetQuery("set")
+	require.False(t, ok)
+}
@@ -87,6 +87,7 @@ type ChainConfig interface {
 
 // Rules defines the interface that provides information about the current rules of the chain.
 type Rules interface {
+	IsHeliconActivated() bool
 	IsGraniteActivated() bool
 	IsDurangoActivated() bool
 }
@@ -193,6 +193,21 @@ func (test PrecompileTest) setup(t testing.TB, module modules.Module, state *tes
 	accessibleState.EXPECT().GetBlockContext().Return(blockContext).AnyTimes()
 	accessibleState.EXPECT().GetSnowContext().Return(snowContext).AnyTimes()
 	accessibleState.EXPECT().GetRules().Return(rules).AnyTimes()
+	accessibleState.EXPECT().GetChainConfig().Return(chainConfig).AnyTimes()
+	// Queries are routed to the registered modules and run against the test state.
+	accessibleState.EXPECT().QueryPrecompile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(addr common.Address, name string, input any, suppliedGas uint64) (any, uint64, error) {
+			queried, ok := modules.GetPrecompileModuleByAddress(addr)
+			if !ok {
+				return nil, suppliedGas, contract.ErrPrecompileNotActive
+			}
+			query, ok := queried.GetQuery(name)
+			if !ok {
+				return nil, suppliedGas, contract.ErrQueryNotFound
+			}
+			return query.Execute(state, input, suppliedGas)
+		},
+	).AnyTimes()
 
 	if test.Config != nil {
 		require.NoError(t, module.Configure(chainConfig, test.Config, state, blockContext))
@@ -9,7 +9,9 @@ package registry
 import (
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/feetoken"
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/rebate"
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/warp"
@@ -33,5 +35,7 @@ import (
 // FeeManagerAddress                = common.HexToAddress("0x0200000000000000000000000000000000000003")
 // RewardManagerAddress             = common.HexToAddress("0x0200000000000000000000000000000000000004")
 // WarpAddress                      = common.HexToAddress("0x0200000000000000000000000000000000000005")
+// FeeTokenAddress                  = common.HexToAddress("0x0200000000000000000000000000000000000006")
+// RebateAddress                    = common.HexToAddress("0x0200000000000000000000000000000000000007")
 // ADD YOUR PRECOMPILE HERE
 // {YourPrecompile}Address          = common.HexToAddress("0x03000000000000000000000000000000000000??")
@@ -0,0 +1,564 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesyncclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/log"
+	"github.com/ava-labs/libevm/rlp"
+
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+// A state sync archive holds the responses to the requests of a state sync to a
+// summary, as served by a node. It starts with [archiveMagic] and the archive
+// version, followed by records of a kind byte, a payload length (uint32 big
+// endian) and the payload:
+//   - the summary,
+//   - the summary block and its parents, in the encoding of the block responses,
+//   - the leafs of the account trie and of every storage trie, in chunks of a
+//     leafs response with its range proof, prefixed by the trie root, the start
+//     key and the limit of the request,
+//   - the code of the accounts, prefixed by the code hash,
+//   - an end record, detecting truncated archives.
+//
+// The responses are verified against the summary as the responses of peers are
+// when the archive is imported, so archives can be copied from untrusted media.
+const (
+	archiveMagic   = "subnet-evm-state-sync-archive"
+	archiveVersion = uint16(0)
+
+	archiveRecordHeaderLen = 1 + wrappers.IntLen
+	archiveLeafsHeaderLen  = 2*common.HashLength + wrappers.ShortLen
+
+	// archiveBlocksPerRequest is the number of blocks exported per block request.
+	archiveBlocksPerRequest = 32
+)
+
+const (
+	archiveSummaryRecord byte = iota + 1
+	archiveBlockRecord
+	archiveLeafsRecord
+	archiveCodeRecord
+	archiveEndRecord
+)
+
+var (
+	_ Client = (*ArchiveClient)(nil)
+
+	errInvalidArchive        = errors.New("invalid state sync archive")
+	errArchiveMissingTrie    = errors.New("trie not found in state sync archive")
+	errArchiveMissingCode    = errors.New("code not found in state sync archive")
+	errArchiveMissingBlock   = errors.New("block not found in state sync archive")
+	errArchiveNoResponse     = errors.New("no response to archived request")
+	errArchiveInvalidAccount = errors.New("could not decode account")
+)
+
+// ArchiveExportConfig defines the source and the target of an archive export.
+type ArchiveExportConfig struct {
+	Codec       codec.Manager
+	Handler     message.RequestHandler // serves the requests to archive
+	BlockParser EthBlockParser
+	Summary     message.Syncable
+	Blocks      int    // number of blocks to archive, from the summary block back
+	RequestSize uint16 // number of leafs per chunk
+}
+
+// ExportArchive writes the state of [config.Summary] served by [config.Handler]
+// to [w] as a state sync archive. The storage roots and code hashes of the
+// accounts are held in memory until the account trie is exported.
+func ExportArchive(ctx context.Context, w io.Writer, config *ArchiveExportConfig) error {
+	e := &archiveExporter{
+		ArchiveExportConfig: config,
+		w:                   bufio.NewWriter(w),
+		storageTries:        make(map[common.Hash]common.Hash),
+		codeHashes:          make(map[common.Hash]struct{}),
+	}
+	if err := e.export(ctx); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+type archiveExporter struct {
+	*ArchiveExportConfig
+	w *bufio.Writer
+
+	storageTries map[common.Hash]common.Hash // storage root to an account holding it
+	codeHashes   map[common.Hash]struct{}
+}
+
+func (e *archiveExporter) export(ctx context.Context) error {
+	if _, err := e.w.WriteString(archiveMagic); err != nil {
+		return err
+	}
+	if err := binary.Write(e.w, binary.BigEndian, archiveVersion); err != nil {
+		return err
+	}
+	if err := e.writeRecord(archiveSummaryRecord, e.Summary.Bytes()); err != nil {
+		return err
+	}
+	if err := e.exportBlocks(ctx); err != nil {
+		return err
+	}
+
+	log.Info("state sync archive: exporting account trie", "root", e.Summary.GetBlockRoot())
+	if err := e.exportTrie(ctx, e.Summary.GetBlockRoot(), common.Hash{}, e.onAccounts); err != nil {
+		return err
+	}
+
+	roots := make([]common.Hash, 0, len(e.storageTries))
+	for root := range e.storageTries {
+		roots = append(roots, root)
+	}
+	slices.SortFunc(roots, common.Hash.Cmp)
+	log.Info("state sync archive: exporting storage tries", "tries", len(roots))
+	for _, root := range roots {
+		if err := e.exportTrie(ctx, root, e.storageTries[root], nil); err != nil {
+			return err
+		}
+	}
+
+	if err := e.exportCode(ctx); err != nil {
+		return err
+	}
+	return e.writeRecord(archiveEndRecord, nil)
+}
+
+// exportBlocks archives [e.Blocks] blocks from the summary block back.
+func (e *archiveExporter) exportBlocks(ctx context.Context) error {
+	hash, height := e.Summary.GetBlockHash(), e.Summary.Height()
+	for remaining := e.Blocks; remaining > 0; {
+		request := message.BlockRequest{
+			Hash:    hash,
+			Height:  height,
+			Parents: uint16(min(remaining, archiveBlocksPerRequest)),
+		}
+		response, err := e.Handler.HandleBlockRequest(ctx, ids.EmptyNodeID, 0, request)
+		if err != nil {
+			return err
+		}
+		if response == nil {
+			return fmt.Errorf("%w: %s", errArchiveNoResponse, request)
+		}
+		// Verify the blocks as a syncing node would.
+		client := &client{blockParser: e.BlockParser}
+		blocksIntf, _, err := client.parseBlocks(e.Codec, request, response)
+		if err != nil {
+			return err
+		}
+		var blockResponse message.BlockResponse
+		if _, err := e.Codec.Unmarshal(response, &blockResponse); err != nil {
+			return err
+		}
+		for _, blockBytes := range blockResponse.Blocks {
+			if err := e.writeRecord(archiveBlockRecord, blockBytes); err != nil {
+				return err
+			}
+		}
+
+		blocks := blocksIntf.(types.Blocks)
+		last := blocks[len(blocks)-1]
+		remaining -= len(blocks)
+		if last.NumberU64() == 0 {
+			return nil
+		}
+		hash, height = last.ParentHash(), last.NumberU64()-1
+	}
+	return nil
+}
+
+// exportTrie archives the leafs of the trie with [root], invoking [onLeafs] on
+// every chunk if it is not nil.
+func (e *archiveExporter) exportTrie(ctx context.Context, root common.Hash, account common.Hash, onLeafs func(keys, vals [][]byte) error) error {
+	var start common.Hash
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		request := message.LeafsRequest{
+			Root:     root,
+			Account:  account,
+			Start:    start.Bytes(),
+			Limit:    e.RequestSize,
+			NodeType: message.StateTrieNode,
+		}
+		response, err := e.Handler.HandleLeafsRequest(ctx, ids.EmptyNodeID, 0, request)
+		if err != nil {
+			return err
+		}
+		if response == nil {
+			return fmt.Errorf("%w: %s", errArchiveNoResponse, request)
+		}
+		// Verify the chunk as a syncing node would.
+		leafsIntf, _, err := parseLeafsResponse(e.Codec, request, response)
+		if err != nil {
+			return err
+		}
+		leafs := leafsIntf.(message.LeafsResponse)
+
+		payload := make([]byte, 0, archiveLeafsHeaderLen+len(response))
+		payload = append(payload, root[:]...)
+		payload = append(payload, start[:]...)
+		payload = binary.BigEndian.AppendUint16(payload, e.RequestSize)
+		payload = append(payload, response...)
+		if err := e.writeRecord(archiveLeafsRecord, payload); err != nil {
+			return err
+		}
+		if onLeafs != nil {
+			if err := onLeafs(leafs.Keys, leafs.Vals); err != nil {
+				return err
+			}
+		}
+
+		if !leafs.More {
+			return nil
+		}
+		start = common.BytesToHash(leafs.Keys[len(leafs.Keys)-1])
+		utils.IncrOne(start[:])
+	}
+}
+
+// onAccounts collects the storage roots and code hashes of the accounts.
+func (e *archiveExporter) onAccounts(keys, vals [][]byte) error {
+	for i, key := range keys {
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(vals[i], &acc); err != nil {
+			return fmt.Errorf("%w %x: %w", errArchiveInvalidAccount, key, err)
+		}
+		if acc.Root != (common.Hash{}) && acc.Root != types.EmptyRootHash {
+			if _, ok := e.storageTries[acc.Root]; !ok {
+				e.storageTries[acc.Root] = common.BytesToHash(key)
+			}
+		}
+		codeHash := common.BytesToHash(acc.CodeHash)
+		if codeHash != (common.Hash{}) && codeHash != types.EmptyCodeHash {
+			e.codeHashes[codeHash] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// exportCode archives the code of the accounts.
+func (e *archiveExporter) exportCode(ctx context.Context) error {
+	hashes := make([]common.Hash, 0, len(e.codeHashes))
+	for hash := range e.codeHashes {
+		hashes = append(hashes, hash)
+	}
+	slices.SortFunc(hashes, common.Hash.Cmp)
+	log.Info("state sync archive: exporting code", "hashes", len(hashes))
+
+	for len(hashes) > 0 {
+		batch := hashes[:min(len(hashes), message.MaxCodeHashesPerRequest)]
+		hashes = hashes[len(batch):]
+
+		request := message.NewCodeRequest(batch)
+		response, err := e.Handler.HandleCodeRequest(ctx, ids.EmptyNodeID, 0, request)
+		if err != nil {
+			return err
+		}
+		if response == nil {
+			return fmt.Errorf("%w: %s", errArchiveNoResponse, request)
+		}
+		codeIntf, _, err := parseCode(e.Codec, request, response)
+		if err != nil {
+			return err
+		}
+		for i, code := range codeIntf.([][]byte) {
+			if err := e.writeRecord(archiveCodeRecord, append(batch[i].Bytes(), code...)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *archiveExporter) writeRecord(kind byte, payload []byte) error {
+	var header [archiveRecordHeaderLen]byte
+	header[0] = kind
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+	if _, err := e.w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := e.w.Write(payload)
+	return err
+}
+
+// archiveRecord locates a payload in the archive.
+type archiveRecord struct {
+	offset int64
+	length uint32
+}
+
+type archiveChunk struct {
+	start common.Hash
+	limit uint16
+	archiveRecord
+}
+
+type archivedBlock struct {
+	bytes  []byte
+	parent common.Hash
+}
+
+// ArchiveClient serves the requests of a state sync from a state sync archive
+// written by [ExportArchive]. The responses are verified as responses from
+// peers are.
+type ArchiveClient struct {
+	file        *os.File
+	codec       codec.Manager
+	blockParser EthBlockParser
+
+	summary []byte
+	blocks  map[common.Hash]archivedBlock
+	tries   map[common.Hash][]archiveChunk // chunks of each trie, ordered by start
+	code    map[common.Hash]archiveRecord
+}
+
+// OpenArchive opens the state sync archive at [path] and indexes its records.
+func OpenArchive(path string, codec codec.Manager, blockParser EthBlockParser) (*ArchiveClient, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	a := &ArchiveClient{
+		file:        file,
+		codec:       codec,
+		blockParser: blockParser,
+		blocks:      make(map[common.Hash]archivedBlock),
+		tries:       make(map[common.Hash][]archiveChunk),
+		code:        make(map[common.Hash]archiveRecord),
+	}
+	if err := a.index(); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to open state sync archive %s: %w", path, err)
+	}
+	return a, nil
+}
+
+func (a *ArchiveClient) index() error {
+	header := make([]byte, len(archiveMagic)+wrappers.ShortLen)
+	if _, err := a.file.ReadAt(header, 0); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidArchive, err)
+	}
+	if string(header[:len(archiveMagic)]) != archiveMagic {
+		return fmt.Errorf("%w: unexpected header", errInvalidArchive)
+	}
+	if version := binary.BigEndian.Uint16(header[len(archiveMagic):]); version != archiveVersion {
+		return fmt.Errorf("%w: unsupported version %d", errInvalidArchive, version)
+	}
+
+	offset := int64(len(header))
+	for {
+		var recordHeader [archiveRecordHeaderLen]byte
+		if _, err := a.file.ReadAt(recordHeader[:], offset); err != nil {
+			return fmt.Errorf("%w: truncated at offset %d: %w", errInvalidArchive, offset, err)
+		}
+		kind := recordHeader[0]
+		record := archiveRecord{
+			offset: offset + archiveRecordHeaderLen,
+			length: binary.BigEndian.Uint32(recordHeader[1:]),
+		}
+		offset = record.offset + int64(record.length)
+
+		switch kind {
+		case archiveSummaryRecord:
+			summary, err := a.read(record)
+			if err != nil {
+				return err
+			}
+			a.summary = summary
+		case archiveBlockRecord:
+			blockBytes, err := a.read(record)
+			if err != nil {
+				return err
+			}
+			block, err := a.blockParser.ParseEthBlock(blockBytes)
+			if err != nil {
+				return fmt.Errorf("%w: %w", errInvalidArchive, err)
+			}
+			a.blocks[block.Hash()] = archivedBlock{bytes: blockBytes, parent: block.ParentHash()}
+		case archiveLeafsRecord:
+			if record.length < archiveLeafsHeaderLen {
+				return fmt.Errorf("%w: leafs record too short at offset %d", errInvalidArchive, record.offset)
+			}
+			leafsHeader, err := a.read(archiveRecord{offset: record.offset, length: archiveLeafsHeaderLen})
+			if err != nil {
+				return err
+			}
+			root := common.BytesToHash(leafsHeader[:common.HashLength])
+			a.tries[root] = append(a.tries[root], archiveChunk{
+				start: common.BytesToHash(leafsHeader[common.HashLength : 2*common.HashLength]),
+				limit: binary.BigEndian.Uint16(leafsHeader[2*common.HashLength:]),
+				archiveRecord: archiveRecord{
+					offset: record.offset + archiveLeafsHeaderLen,
+					length: record.length - archiveLeafsHeaderLen,
+				},
+			})
+		case archiveCodeRecord:
+			if record.length < common.HashLength {
+				return fmt.Errorf("%w: code record too short at offset %d", errInvalidArchive, record.offset)
+			}
+			hash, err := a.read(archiveRecord{offset: record.offset, length: common.HashLength})
+			if err != nil {
+				return err
+			}
+			a.code[common.BytesToHash(hash)] = archiveRecord{
+				offset: record.offset + common.HashLength,
+				length: record.length - common.HashLength,
+			}
+		case archiveEndRecord:
+			if a.summary == nil {
+				return fmt.Errorf("%w: missing summary", errInvalidArchive)
+			}
+			for root, chunks := range a.tries {
+				if !slices.IsSortedFunc(chunks, func(x, y archiveChunk) int { return x.start.Cmp(y.start) }) {
+					return fmt.Errorf("%w: unordered chunks of trie %s", errInvalidArchive, root)
+				}
+			}
+			return nil
+		default:
+			return fmt.Errorf("%w: unknown record kind %d at offset %d", errInvalidArchive, kind, record.offset)
+		}
+	}
+}
+
+func (a *ArchiveClient) read(record archiveRecord) ([]byte, error) {
+	data := make([]byte, record.length)
+	if _, err := a.file.ReadAt(data, record.offset); err != nil {
+		return nil, fmt.Errorf("%w: truncated at offset %d: %w", errInvalidArchive, record.offset, err)
+	}
+	return data, nil
+}
+
+// Summary returns the bytes of the summary the archive holds the state of.
+func (a *ArchiveClient) Summary() []byte {
+	return a.summary
+}
+
+// Close closes the archive file.
+func (a *ArchiveClient) Close() error {
+	return a.file.Close()
+}
+
+// GetLeafs serves [request] from the archived chunk holding its start key, after
+// verifying the chunk against its range proof. The response holds the leafs of
+// the chunk from the start key, up to the limit of [request].
+func (a *ArchiveClient) GetLeafs(_ context.Context, request message.LeafsRequest) (message.LeafsResponse, error) {
+	chunks, ok := a.tries[request.Root]
+	if !ok {
+		return message.LeafsResponse{}, fmt.Errorf("%w: %s", errArchiveMissingTrie, request.Root)
+	}
+	start := common.BytesToHash(request.Start)
+	i := sort.Search(len(chunks), func(i int) bool {
+		return chunks[i].start.Cmp(start) > 0
+	}) - 1
+	if i < 0 {
+		return message.LeafsResponse{}, fmt.Errorf("%w: %s from %s", errArchiveMissingTrie, request.Root, start)
+	}
+	chunk := chunks[i]
+
+	response, err := a.read(chunk.archiveRecord)
+	if err != nil {
+		return message.LeafsResponse{}, err
+	}
+	chunkRequest := message.LeafsRequest{
+		Root:     request.Root,
+		Account:  request.Account,
+		Start:    chunk.start.Bytes(),
+		Limit:    chunk.limit,
+		NodeType: request.NodeType,
+	}
+	leafsIntf, _, err := parseLeafsResponse(a.codec, chunkRequest, response)
+	if err != nil {
+		return message.LeafsResponse{}, err
+	}
+	leafs := leafsIntf.(message.LeafsResponse)
+
+	first := sort.Search(len(leafs.Keys), func(i int) bool {
+		return bytes.Compare(leafs.Keys[i], start[:]) >= 0
+	})
+	last := min(len(leafs.Keys), first+int(request.Limit))
+	return message.LeafsResponse{
+		Keys: leafs.Keys[first:last],
+		Vals: leafs.Vals[first:last],
+		More: leafs.More || last < len(leafs.Keys),
+	}, nil
+}
+
+// GetBlocks serves the blocks from [hash] back from the archive, verified as
+// block responses are.
+func (a *ArchiveClient) GetBlocks(_ context.Context, hash common.Hash, height uint64, parents uint16) ([]*types.Block, error) {
+	request := message.BlockRequest{
+		Hash:    hash,
+		Height:  height,
+		Parents: parents,
+	}
+	var blocks [][]byte
+	for next := hash; len(blocks) < int(parents); {
+		block, ok := a.blocks[next]
+		if !ok {
+			break
+		}
+		blocks = append(blocks, block.bytes)
+		next = block.parent
+	}
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("%w: %s", errArchiveMissingBlock, hash)
+	}
+
+	response, err := a.codec.Marshal(message.Version, message.BlockResponse{Blocks: blocks})
+	if err != nil {
+		return nil, err
+	}
+	client := &client{blockParser: a.blockParser}
+	blocksIntf, _, err := client.parseBlocks(a.codec, request, response)
+	if err != nil {
+		return nil, err
+	}
+	return blocksIntf.(types.Blocks), nil
+}
+
+// GetCode serves the code of [hashes] from the archive, verified as code
+// responses are.
+func (a *ArchiveClient) GetCode(_ context.Context, hashes []common.Hash) ([][]byte, error) {
+	code := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		record, ok := a.code[hash]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errArchiveMissingCode, hash)
+		}
+		var err error
+		code[i], err = a.read(record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := a.codec.Marshal(message.Version, message.CodeResponse{Data: code})
+	if err != nil {
+		return nil, err
+	}
+	codeIntf, _, err := parseCode(a.codec, message.CodeRequest{Hashes: hashes}, response)
+	if err != nil {
+		return nil, err
+	}
+	return codeIntf.([][]byte), nil
+}
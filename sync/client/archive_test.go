@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesyncclient
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/ava-labs/libevm/core/rawdb"
+	"github.com/ava-labs/libevm/core/types"
+	"github.com/ava-labs/libevm/crypto"
+	"github.com/ava-labs/libevm/triedb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/plugin/evm/message"
+	"github.com/ava-labs/subnet-evm/sync/handlers"
+	"github.com/ava-labs/subnet-evm/sync/statesync/statesynctest"
+	"github.com/ava-labs/subnet-evm/utils"
+
+	handlerstats "github.com/ava-labs/subnet-evm/sync/handlers/stats"
+)
+
+type archiveTest struct {
+	path     string
+	summary  message.Syncable
+	server   *TestClient
+	blocks   []*types.Block // blocks by height, from genesis
+	codeHash common.Hash
+}
+
+// newArchiveTest exports an archive of a chain of [numBlocks] blocks, whose
+// summary has the state of accounts with storage and code.
+func newArchiveTest(t *testing.T, numBlocks int, exportBlocks int) *archiveTest {
+	t.Helper()
+	r := rand.New(rand.NewSource(1))
+
+	gspec := &core.Genesis{
+		Config: params.TestChainConfig,
+	}
+	memdb := rawdb.NewMemoryDatabase()
+	genesis := gspec.MustCommit(memdb, triedb.NewDatabase(memdb, nil))
+	chain, _, err := core.GenerateChain(params.TestChainConfig, genesis, dummy.NewETHFaker(), memdb, numBlocks, 0, func(_ int, _ *core.BlockGen) {})
+	require.NoError(t, err)
+	blocks := append([]*types.Block{genesis}, chain...)
+
+	code := []byte("archived code")
+	codeHash := crypto.Keccak256Hash(code)
+	serverDB := rawdb.NewMemoryDatabase()
+	rawdb.WriteCode(serverDB, codeHash, code)
+	serverTrieDB := triedb.NewDatabase(serverDB, nil)
+	root, _ := statesynctest.FillAccountsWithOverlappingStorage(t, r, serverTrieDB, common.Hash{}, 300, 2)
+	root, _ = statesynctest.FillAccounts(t, r, serverTrieDB, root, 1, func(_ *testing.T, _ int, account types.StateAccount) types.StateAccount {
+		account.CodeHash = codeHash[:]
+		return account
+	})
+
+	server := NewTestClient(
+		message.Codec,
+		handlers.NewLeafsRequestHandler(serverTrieDB, message.StateTrieKeyLength, nil, message.Codec, handlerstats.NewNoopHandlerStats()),
+		handlers.NewCodeRequestHandler(serverDB, message.Codec, handlerstats.NewNoopHandlerStats()),
+		handlers.NewBlockRequestHandler(&handlers.TestBlockProvider{
+			GetBlockFn: func(hash common.Hash, height uint64) *types.Block {
+				if height >= uint64(len(blocks)) || blocks[height].Hash() != hash {
+					return nil
+				}
+				return blocks[height]
+			},
+		}, message.Codec, handlerstats.NewNoopHandlerStats()),
+	)
+	last := blocks[len(blocks)-1]
+	summary, err := message.NewBlockSyncSummary(last.Hash(), last.NumberU64(), root)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "archive")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, ExportArchive(t.Context(), file, &ArchiveExportConfig{
+		Codec:       message.Codec,
+		Handler:     server,
+		BlockParser: newTestBlockParser(),
+		Summary:     summary,
+		Blocks:      exportBlocks,
+		RequestSize: 64,
+	}))
+	require.NoError(t, file.Close())
+
+	return &archiveTest{
+		path:     path,
+		summary:  summary,
+		server:   server,
+		blocks:   blocks,
+		codeHash: codeHash,
+	}
+}
+
+func (test *archiveTest) open(t *testing.T) *ArchiveClient {
+	t.Helper()
+	archive, err := OpenArchive(test.path, message.Codec, newTestBlockParser())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, archive.Close())
+	})
+	return archive
+}
+
+func TestArchive(t *testing.T) {
+	require := require.New(t)
+	test := newArchiveTest(t, 40, 16)
+	archive := test.open(t)
+	require.Equal(test.summary.Bytes(), archive.Summary())
+
+	// The leafs of the archive match the leafs served by the server, for
+	// requests of another size than the exported chunks.
+	root := test.summary.GetBlockRoot()
+	for _, start := range [][]byte{nil, common.Hash{0x80}.Bytes()} {
+		expectedKeys, expectedVals := iterateLeafs(t, test.server, root, start)
+		keys, vals := iterateLeafs(t, archive, root, start)
+		require.Equal(expectedKeys, keys)
+		require.Equal(expectedVals, vals)
+	}
+	_, err := archive.GetLeafs(t.Context(), message.LeafsRequest{Root: common.Hash{1}, Limit: 100})
+	require.ErrorIs(err, errArchiveMissingTrie)
+
+	code, err := archive.GetCode(t.Context(), []common.Hash{test.codeHash})
+	require.NoError(err)
+	require.Equal([][]byte{[]byte("archived code")}, code)
+	_, err = archive.GetCode(t.Context(), []common.Hash{{1}})
+	require.ErrorIs(err, errArchiveMissingCode)
+
+	// The blocks are archived from the summary block back.
+	blocks, err := archive.GetBlocks(t.Context(), test.summary.GetBlockHash(), test.summary.Height(), 256)
+	require.NoError(err)
+	require.Len(blocks, 16)
+	for i, block := range blocks {
+		require.Equal(test.blocks[40-i].Hash(), block.Hash())
+	}
+	_, err = archive.GetBlocks(t.Context(), test.blocks[10].Hash(), 10, 1)
+	require.ErrorIs(err, errArchiveMissingBlock)
+}
+
+// iterateLeafs returns the leafs of the trie with [root] from [start] served by
+// [client], requested as the leaf syncer does.
+func iterateLeafs(t *testing.T, client LeafClient, root common.Hash, start []byte) ([][]byte, [][]byte) {
+	t.Helper()
+	var keys, vals [][]byte
+	for {
+		response, err := client.GetLeafs(t.Context(), message.LeafsRequest{
+			Root:     root,
+			Start:    start,
+			Limit:    100,
+			NodeType: message.StateTrieNode,
+		})
+		require.NoError(t, err)
+		keys = append(keys, response.Keys...)
+		vals = append(vals, response.Vals...)
+		if !response.More {
+			return keys, vals
+		}
+		start = common.CopyBytes(response.Keys[len(response.Keys)-1])
+		utils.IncrOne(start)
+	}
+}
+
+func TestArchiveBlocksToGenesis(t *testing.T) {
+	test := newArchiveTest(t, 10, 256)
+	archive := test.open(t)
+
+	blocks, err := archive.GetBlocks(t.Context(), test.summary.GetBlockHash(), test.summary.Height(), 256)
+	require.NoError(t, err)
+	require.Len(t, blocks, 11)
+	require.Equal(t, test.blocks[0].Hash(), blocks[10].Hash())
+}
+
+func TestArchiveCorrupted(t *testing.T) {
+	test := newArchiveTest(t, 10, 4)
+	data, err := os.ReadFile(test.path)
+	require.NoError(t, err)
+	root := test.summary.GetBlockRoot()
+	chunk := test.open(t).tries[root][0]
+
+	tests := map[string]struct {
+		corrupt     func([]byte) []byte
+		expectedErr error // expected when opening the archive, or nil to expect it when syncing the account trie
+	}{
+		"truncated": {
+			corrupt: func(data []byte) []byte {
+				return data[:len(data)-archiveRecordHeaderLen]
+			},
+			expectedErr: errInvalidArchive,
+		},
+		"truncated record": {
+			corrupt: func(data []byte) []byte {
+				return data[:chunk.offset+1]
+			},
+			expectedErr: errInvalidArchive,
+		},
+		"wrong magic": {
+			corrupt: func(data []byte) []byte {
+				data[0]++
+				return data
+			},
+			expectedErr: errInvalidArchive,
+		},
+		"tampered leafs": {
+			corrupt: func(data []byte) []byte {
+				data[chunk.offset+int64(chunk.length)-1]++
+				return data
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "archive")
+			require.NoError(t, os.WriteFile(path, test.corrupt(bytes.Clone(data)), 0o600))
+
+			archive, err := OpenArchive(path, message.Codec, newTestBlockParser())
+			require.ErrorIs(t, err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			defer archive.Close()
+
+			_, err = archive.GetLeafs(t.Context(), message.LeafsRequest{
+				Root:     root,
+				Limit:    100,
+				NodeType: message.StateTrieNode,
+			})
+			require.Error(t, err)
+		})
+	}
+}
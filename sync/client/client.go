@@ -79,6 +79,7 @@ type client struct {
 	stateSyncNodes   []ids.NodeID
 	stateSyncNodeIdx uint32
 	stats            stats.ClientSyncerStats
+	throughput       *PeerThroughputTracker
 	blockParser      EthBlockParser
 }
 
@@ -88,6 +89,9 @@ type ClientConfig struct {
 	Stats            stats.ClientSyncerStats
 	StateSyncNodeIDs []ids.NodeID
 	BlockParser      EthBlockParser
+	// Throughput is an optional tracker of the throughput of the peers
+	// serving the requests, and can be nil.
+	Throughput *PeerThroughputTracker
 }
 
 type EthBlockParser interface {
@@ -100,6 +104,7 @@ func NewClient(config *ClientConfig) *client {
 		codec:          config.Codec,
 		stats:          config.Stats,
 		stateSyncNodes: config.StateSyncNodeIDs,
+		throughput:     config.Throughput,
 		blockParser:    config.BlockParser,
 	}
 }
@@ -339,6 +344,7 @@ func (c *client) get(ctx context.Context, request message.Request, parseFn parse
 			log.Debug("request failed, retrying", ctx...)
 			metric.IncFailed()
 			c.networkClient.TrackBandwidth(nodeID, 0)
+			c.observeThroughput(nodeID, 0, 0, true)
 			time.Sleep(failedRequestSleepInterval)
 			continue
 		} else {
@@ -347,16 +353,27 @@ func (c *client) get(ctx context.Context, request message.Request, parseFn parse
 				lastErr = err
 				log.Debug("could not validate response, retrying", "nodeID", nodeID, "attempt", attempt, "request", request, "err", err)
 				c.networkClient.TrackBandwidth(nodeID, 0)
+				c.observeThroughput(nodeID, 0, 0, true)
 				metric.IncFailed()
 				metric.IncInvalidResponse()
 				continue
 			}
 
-			bandwidth := float64(len(response)) / (time.Since(start).Seconds() + epsilon)
+			elapsed := time.Since(start)
+			bandwidth := float64(len(response)) / (elapsed.Seconds() + epsilon)
 			c.networkClient.TrackBandwidth(nodeID, bandwidth)
+			c.observeThroughput(nodeID, len(response), elapsed, false)
 			metric.IncSucceeded()
 			metric.IncReceived(int64(numElements))
 			return responseIntf, nil
 		}
 	}
 }
+
+// observeThroughput records the outcome of a request to [nodeID] if the
+// throughput of the peers is tracked.
+func (c *client) observeThroughput(nodeID ids.NodeID, size int, elapsed time.Duration, failed bool) {
+	if c.throughput != nil {
+		c.throughput.observe(nodeID, size, elapsed, failed)
+	}
+}
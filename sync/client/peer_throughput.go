@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesyncclient
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const throughputHalfLife = 1 * time.Minute
+
+// PeerThroughput is the throughput of the responses of a peer to sync requests.
+type PeerThroughput struct {
+	NodeID         ids.NodeID
+	Requests       uint64
+	Failures       uint64
+	BytesReceived  uint64
+	BytesPerSecond float64
+}
+
+type peerThroughput struct {
+	requests      uint64
+	failures      uint64
+	bytesReceived uint64
+	bytesRate     safemath.Averager
+}
+
+// PeerThroughputTracker tracks the throughput of the peers serving sync requests.
+// The zero value is not usable, use [NewPeerThroughputTracker].
+type PeerThroughputTracker struct {
+	lock  sync.Mutex
+	peers map[ids.NodeID]*peerThroughput
+}
+
+func NewPeerThroughputTracker() *PeerThroughputTracker {
+	return &PeerThroughputTracker{
+		peers: make(map[ids.NodeID]*peerThroughput),
+	}
+}
+
+// observe records the response of [nodeID] of [size] bytes received after
+// [elapsed], or a failed request if [failed] is true.
+func (p *PeerThroughputTracker) observe(nodeID ids.NodeID, size int, elapsed time.Duration, failed bool) {
+	if nodeID == ids.EmptyNodeID {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	peer, ok := p.peers[nodeID]
+	if !ok {
+		peer = &peerThroughput{}
+		p.peers[nodeID] = peer
+	}
+	peer.requests++
+	if failed {
+		peer.failures++
+		return
+	}
+	peer.bytesReceived += uint64(size)
+
+	now := time.Now()
+	rate := float64(size) / (elapsed.Seconds() + epsilon)
+	if peer.bytesRate == nil {
+		peer.bytesRate = safemath.NewAverager(rate, throughputHalfLife, now)
+	} else {
+		peer.bytesRate.Observe(rate, now)
+	}
+}
+
+// Peers returns the throughput of every peer a sync request was sent to,
+// ordered by node ID.
+func (p *PeerThroughputTracker) Peers() []PeerThroughput {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	peers := make([]PeerThroughput, 0, len(p.peers))
+	for nodeID, peer := range p.peers {
+		throughput := PeerThroughput{
+			NodeID:        nodeID,
+			Requests:      peer.requests,
+			Failures:      peer.failures,
+			BytesReceived: peer.bytesReceived,
+		}
+		if peer.bytesRate != nil {
+			throughput.BytesPerSecond = peer.bytesRate.Read()
+		}
+		peers = append(peers, throughput)
+	}
+	slices.SortFunc(peers, func(a, b PeerThroughput) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+	return peers
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesyncclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerThroughputTracker(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		tracker = NewPeerThroughputTracker()
+		nodeA   = ids.BuildTestNodeID([]byte{1})
+		nodeB   = ids.BuildTestNodeID([]byte{2})
+	)
+	tracker.observe(nodeB, 1000, time.Second, false)
+	tracker.observe(nodeA, 0, 0, true)
+	tracker.observe(nodeA, 500, time.Second, false)
+	// Requests sent to no peer in particular are not tracked.
+	tracker.observe(ids.EmptyNodeID, 0, 0, true)
+
+	peers := tracker.Peers()
+	require.Len(peers, 2)
+
+	require.Equal(nodeA, peers[0].NodeID)
+	require.Equal(uint64(2), peers[0].Requests)
+	require.Equal(uint64(1), peers[0].Failures)
+	require.Equal(uint64(500), peers[0].BytesReceived)
+	require.InDelta(500, peers[0].BytesPerSecond, 1)
+
+	require.Equal(nodeB, peers[1].NodeID)
+	require.Equal(uint64(1), peers[1].Requests)
+	require.Zero(peers[1].Failures)
+	require.Equal(uint64(1000), peers[1].BytesReceived)
+	require.InDelta(1000, peers[1].BytesPerSecond, 1)
+}
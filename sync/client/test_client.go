@@ -19,8 +19,9 @@ import (
 )
 
 var (
-	_ Client         = (*TestClient)(nil)
-	_ EthBlockParser = (*testBlockParser)(nil)
+	_ Client                 = (*TestClient)(nil)
+	_ message.RequestHandler = (*TestClient)(nil)
+	_ EthBlockParser         = (*testBlockParser)(nil)
 )
 
 type TestClient struct {
@@ -138,6 +139,22 @@ func (ml *TestClient) BlocksReceived() int32 {
 	return atomic.LoadInt32(&ml.blocksReceived)
 }
 
+// HandleLeafsRequest serves [request] with the leafs handler of the test
+// client, so it can be the handler of an archive export.
+func (ml *TestClient) HandleLeafsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, request message.LeafsRequest) ([]byte, error) {
+	return ml.leafsHandler.OnLeafsRequest(ctx, nodeID, requestID, request)
+}
+
+// HandleBlockRequest serves [request] with the blocks handler of the test client.
+func (ml *TestClient) HandleBlockRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, request message.BlockRequest) ([]byte, error) {
+	return ml.blocksHandler.OnBlockRequest(ctx, nodeID, requestID, request)
+}
+
+// HandleCodeRequest serves [request] with the code handler of the test client.
+func (ml *TestClient) HandleCodeRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, request message.CodeRequest) ([]byte, error) {
+	return ml.codesHandler.OnCodeRequest(ctx, nodeID, requestID, request)
+}
+
 type testBlockParser struct{}
 
 func newTestBlockParser() *testBlockParser {
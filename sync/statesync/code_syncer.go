@@ -257,3 +257,11 @@ func (c *codeSyncer) setError(err error) {
 
 // Done returns an error channel to indicate the return status of code syncing.
 func (c *codeSyncer) Done() <-chan error { return c.errChan }
+
+// outstanding returns the number of code hashes queued and not fetched yet.
+func (c *codeSyncer) outstanding() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.outstandingCodeHashes.Len()
+}
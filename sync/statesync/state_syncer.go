@@ -142,6 +142,12 @@ func (t *stateSync) onStorageTrieFinished(root common.Hash) error {
 func (t *stateSync) onMainTrieFinished() error {
 	t.codeSyncer.notifyAccountTrieCompleted()
 
+	// stop tracking the main trie before counting the storage tries, so it is
+	// not counted as one of them.
+	if _, err := t.removeTrieInProgress(t.root); err != nil {
+		return err
+	}
+
 	// count the number of storage tries we need to sync for eta purposes.
 	numStorageTries, err := t.trieQueue.countTries()
 	if err != nil {
@@ -151,8 +157,7 @@ func (t *stateSync) onMainTrieFinished() error {
 
 	// mark the main trie done
 	close(t.mainTrieDone)
-	_, err = t.removeTrieInProgress(t.root)
-	return err
+	return nil
 }
 
 // onSyncComplete is called after the account trie and
@@ -292,6 +297,13 @@ func (t *stateSync) removeTrieInProgress(root common.Hash) (int, error) {
 	return len(t.triesInProgress), nil
 }
 
+// Progress returns a snapshot of the progress of the sync.
+func (t *stateSync) Progress() Progress {
+	progress := t.stats.progress()
+	progress.OutstandingCodeHashes = t.codeSyncer.outstanding()
+	return progress
+}
+
 // onSyncFailure is called if the sync fails, this writes all
 // batches of in-progress trie segments to disk to have maximum
 // progress to restore.
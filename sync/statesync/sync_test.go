@@ -8,6 +8,8 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"runtime/pprof"
 	"sync/atomic"
 	"testing"
@@ -43,6 +45,9 @@ type syncTest struct {
 	expectedError     error
 	GetLeafsIntercept func(message.LeafsRequest, message.LeafsResponse) (message.LeafsResponse, error)
 	GetCodeIntercept  func([]common.Hash, [][]byte) ([][]byte, error)
+	// fromArchive syncs from an archive exported from the server instead of
+	// from the server.
+	fromArchive bool
 }
 
 func testSync(t *testing.T, test syncTest) {
@@ -59,9 +64,13 @@ func testSync(t *testing.T, test syncTest) {
 	// Set intercept functions for the mock client
 	mockClient.GetLeafsIntercept = test.GetLeafsIntercept
 	mockClient.GetCodeIntercept = test.GetCodeIntercept
+	var client statesyncclient.Client = mockClient
+	if test.fromArchive {
+		client = exportTestArchive(t, mockClient, root)
+	}
 
 	s, err := NewStateSyncer(&StateSyncerConfig{
-		Client:                   mockClient,
+		Client:                   client,
 		Root:                     root,
 		DB:                       clientDB,
 		BatchSize:                1000, // Use a lower batch size in order to get test coverage of batches being written early.
@@ -78,6 +87,39 @@ func testSync(t *testing.T, test syncTest) {
 	}
 
 	assertDBConsistency(t, root, clientDB, serverTrieDB, triedb.NewDatabase(clientDB, nil))
+
+	// A finished sync has no tries in progress nor outstanding work.
+	progress := s.Progress()
+	require.Empty(t, progress.TriesInProgress)
+	require.Zero(t, progress.TriesRemaining)
+	require.Zero(t, progress.OutstandingCodeHashes)
+}
+
+// exportTestArchive exports the state at [root] served by [handler] to an
+// archive and opens it.
+func exportTestArchive(t *testing.T, handler message.RequestHandler, root common.Hash) *statesyncclient.ArchiveClient {
+	t.Helper()
+	summary, err := message.NewBlockSyncSummary(common.Hash{1}, 1, root)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "archive")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, statesyncclient.ExportArchive(t.Context(), file, &statesyncclient.ArchiveExportConfig{
+		Codec:       message.Codec,
+		Handler:     handler,
+		Summary:     summary,
+		RequestSize: 100, // Use a request size lower than the syncer's, so requests span chunks.
+	}))
+	require.NoError(t, file.Close())
+
+	archive, err := statesyncclient.OpenArchive(path, message.Codec, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, archive.Close())
+	})
+	require.Equal(t, summary.Bytes(), archive.Summary())
+	return archive
 }
 
 // testSyncResumes tests a series of syncTests work as expected, invoking a callback function after each
@@ -204,6 +246,50 @@ func TestSimpleSyncCases(t *testing.T) {
 	}
 }
 
+func TestSyncFromArchive(t *testing.T) {
+	numAccounts := 250
+	tests := map[string]syncTest{
+		"accounts with code and storage": {
+			prepareForTest: func(t *testing.T, r *rand.Rand) (ethdb.Database, ethdb.Database, *triedb.Database, common.Hash) {
+				serverDB := rawdb.NewMemoryDatabase()
+				serverTrieDB := triedb.NewDatabase(serverDB, nil)
+				root := fillAccountsWithStorage(t, r, serverDB, serverTrieDB, common.Hash{}, numAccounts)
+				return rawdb.NewMemoryDatabase(), serverDB, serverTrieDB, root
+			},
+			fromArchive: true,
+		},
+		"accounts with overlapping storage": {
+			prepareForTest: func(t *testing.T, r *rand.Rand) (ethdb.Database, ethdb.Database, *triedb.Database, common.Hash) {
+				serverDB := rawdb.NewMemoryDatabase()
+				serverTrieDB := triedb.NewDatabase(serverDB, nil)
+				root, _ := statesynctest.FillAccountsWithOverlappingStorage(t, r, serverTrieDB, common.Hash{}, numAccounts, 3)
+				return rawdb.NewMemoryDatabase(), serverDB, serverTrieDB, root
+			},
+			fromArchive: true,
+		},
+		"large storage trie": {
+			prepareForTest: func(t *testing.T, r *rand.Rand) (ethdb.Database, ethdb.Database, *triedb.Database, common.Hash) {
+				serverDB := rawdb.NewMemoryDatabase()
+				serverTrieDB := triedb.NewDatabase(serverDB, nil)
+				largeStorageRoot, _, _ := statesynctest.GenerateTrie(t, r, serverTrieDB, 2000, common.HashLength)
+				root, _ := statesynctest.FillAccounts(t, r, serverTrieDB, common.Hash{}, numAccounts, func(_ *testing.T, index int, account types.StateAccount) types.StateAccount {
+					if index%10 == 0 {
+						account.Root = largeStorageRoot
+					}
+					return account
+				})
+				return rawdb.NewMemoryDatabase(), serverDB, serverTrieDB, root
+			},
+			fromArchive: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			testSync(t, test)
+		})
+	}
+}
+
 func TestCancelSync(t *testing.T) {
 	r := rand.New(rand.NewSource(1))
 	serverDB := rawdb.NewMemoryDatabase()
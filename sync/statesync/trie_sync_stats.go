@@ -34,8 +34,11 @@ type trieSyncStats struct {
 	triesSynced      int
 	triesStartTime   time.Time
 	leafsSinceUpdate uint64
+	leafs            uint64
+	eta              time.Duration
 
 	remainingLeafs map[*trieSegment]uint64
+	trieLeafs      map[common.Hash]uint64 // leafs fetched for each trie in progress
 
 	// metrics
 	totalLeafs     metrics.Counter
@@ -47,6 +50,7 @@ func newTrieSyncStats() *trieSyncStats {
 	now := time.Now()
 	return &trieSyncStats{
 		remainingLeafs: make(map[*trieSegment]uint64),
+		trieLeafs:      make(map[common.Hash]uint64),
 		lastUpdated:    now,
 
 		// metrics
@@ -69,12 +73,14 @@ func (t *trieSyncStats) incLeafs(segment *trieSegment, count uint64, remaining u
 
 	t.totalLeafs.Inc(int64(count))
 	t.leafsSinceUpdate += count
+	t.leafs += count
 	t.remainingLeafs[segment] = remaining
+	t.trieLeafs[segment.trie.root] += count
 
 	now := time.Now()
 	sinceUpdate := now.Sub(t.lastUpdated)
 	if sinceUpdate > updateFrequency {
-		t.updateETA(sinceUpdate, now)
+		t.eta = t.updateETA(sinceUpdate, now)
 		t.lastUpdated = now
 		t.leafsSinceUpdate = 0
 	}
@@ -108,6 +114,7 @@ func (t *trieSyncStats) trieDone(root common.Hash) {
 			delete(t.remainingLeafs, segment)
 		}
 	}
+	delete(t.trieLeafs, root)
 
 	t.triesSynced++
 	t.triesRemaining--
@@ -151,6 +158,46 @@ func (t *trieSyncStats) setTriesRemaining(triesRemaining int) {
 	t.triesStartTime = time.Now()
 }
 
+// Progress is a snapshot of the progress of a state sync.
+type Progress struct {
+	LeafsFetched   uint64
+	LeafsPerSecond float64
+	TriesSynced    int
+	// TriesRemaining is the number of storage tries left to sync, which is
+	// only known once the account trie is synced.
+	TriesRemaining int
+	// TriesInProgress is the number of leafs fetched for each trie being
+	// synced, by root.
+	TriesInProgress map[common.Hash]uint64
+	// OutstandingCodeHashes is the number of code hashes queued and not
+	// fetched yet.
+	OutstandingCodeHashes int
+	// TimeRemaining is the last estimate of the time to finish the sync, which
+	// is 0 until enough leafs were fetched to estimate it.
+	TimeRemaining time.Duration
+}
+
+// progress takes a lock and returns a snapshot of the stats.
+func (t *trieSyncStats) progress() Progress {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	progress := Progress{
+		LeafsFetched:    t.leafs,
+		TriesSynced:     t.triesSynced,
+		TriesRemaining:  t.triesRemaining,
+		TriesInProgress: make(map[common.Hash]uint64, len(t.trieLeafs)),
+		TimeRemaining:   t.eta,
+	}
+	if t.leafsRate != nil {
+		progress.LeafsPerSecond = t.leafsRate.Read()
+	}
+	for root, leafs := range t.trieLeafs {
+		progress.TriesInProgress[root] = leafs
+	}
+	return progress
+}
+
 // roundETA rounds [d] to a minute and chops off the "0s" suffix
 // returns "<1m" if [d] rounds to 0 minutes.
 func roundETA(d time.Duration) string {
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ava-labs/libevm/common"
 	"github.com/ava-labs/libevm/metrics"
 	"github.com/stretchr/testify/require"
 )
@@ -24,3 +25,28 @@ func TestETAShouldNotOverflow(t *testing.T) {
 	}
 	require.Positive(stats.updateETA(time.Minute, now))
 }
+
+func TestTrieSyncStatsProgress(t *testing.T) {
+	require := require.New(t)
+
+	stats := newTrieSyncStats()
+	mainTrie := &trieToSync{root: common.Hash{1}}
+	storageTrie := &trieToSync{root: common.Hash{2}}
+	segments := []*trieSegment{{trie: mainTrie}, {trie: mainTrie}, {trie: storageTrie}}
+
+	stats.incLeafs(segments[0], 10, 100)
+	stats.incLeafs(segments[1], 5, 100)
+	stats.incLeafs(segments[2], 3, 100)
+	progress := stats.progress()
+	require.Equal(uint64(18), progress.LeafsFetched)
+	require.Equal(map[common.Hash]uint64{{1}: 15, {2}: 3}, progress.TriesInProgress)
+	require.Zero(progress.TimeRemaining)
+
+	stats.setTriesRemaining(2)
+	stats.trieDone(mainTrie.root)
+	progress = stats.progress()
+	require.Equal(uint64(18), progress.LeafsFetched)
+	require.Equal(map[common.Hash]uint64{{2}: 3}, progress.TriesInProgress)
+	require.Equal(1, progress.TriesSynced)
+	require.Equal(1, progress.TriesRemaining)
+}
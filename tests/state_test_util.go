@@ -50,6 +50,7 @@ import (
 	ethparams "github.com/ava-labs/libevm/params"
 	"github.com/ava-labs/libevm/rlp"
 	"github.com/ava-labs/libevm/triedb"
+	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/extstate"
 	"github.com/ava-labs/subnet-evm/core/state/snapshot"
@@ -306,7 +307,7 @@ func (t *StateTest) RunNoVerify(subtest StateSubtest, vmconfig vm.Config, snapsh
 
 	// Prepare the EVM.
 	txContext := core.NewEVMTxContext(msg)
-	context := core.NewEVMBlockContext(block.Header(), nil, &t.json.Env.Coinbase)
+	context := core.NewEVMBlockContext(block.Header(), &dummyChain{config: config}, &t.json.Env.Coinbase)
 	context.GetHash = vmTestBlockHash
 	context.BaseFee = baseFee
 	context.Random = nil
@@ -539,3 +540,13 @@ func (st *StateTestState) Close() {
 		st.TempDir = ""
 	}
 }
+
+// dummyChain is a [core.ChainContext] providing only the chain config, as the
+// state tests override the block hashes and the blob base fee of the context.
+type dummyChain struct {
+	config *params.ChainConfig
+}
+
+func (*dummyChain) Engine() consensus.Engine                    { return nil }
+func (*dummyChain) GetHeader(common.Hash, uint64) *types.Header { return nil }
+func (d *dummyChain) Config() *params.ChainConfig               { return d.config }